@@ -12,10 +12,12 @@ Reference: https://www.ietf.org/archive/id/draft-irtf-cfrg-voprf-12.html#name-di
 package dleq
 
 import (
+	"io"
 	"math/big"
 
 	"github.com/cymony/cryptomony/eccgroup"
 	"github.com/cymony/cryptomony/hash"
+	"github.com/cymony/cryptomony/internal/typedgroup"
 	"github.com/cymony/cryptomony/utils"
 )
 
@@ -30,6 +32,8 @@ var (
 type Configuration struct {
 	DST   []byte         // Domain separation tag
 	Group eccgroup.Group // prime-order elliptic curve group
+	Hash  hash.Hashing   // hash function, required for groups registered with eccgroup.Register
+	Rand  io.Reader      // source of the proof randomness, crypto/rand if nil
 }
 
 type dlq struct {
@@ -40,6 +44,10 @@ type dlq struct {
 func newDleq(c *Configuration) (*dlq, error) {
 	var d dlq
 
+	if !c.Group.Available() {
+		return nil, ErrUnsupportedGroup
+	}
+
 	switch c.Group.String() {
 	case eccgroup.P256Sha256.String():
 		d.hash = hash.SHA256
@@ -49,8 +57,16 @@ func newDleq(c *Configuration) (*dlq, error) {
 		d.hash = hash.SHA512
 	case eccgroup.Ristretto255Sha512.String():
 		d.hash = hash.SHA512
+	case eccgroup.Secp256k1Sha256.String():
+		d.hash = hash.SHA256
+	case eccgroup.Decaf448Shake256.String():
+		d.hash = hash.SHAKE256
 	default:
-		return nil, ErrUnsupportedGroup
+		if c.Hash == 0 || !c.Hash.Available() {
+			return nil, ErrUnsupportedGroup
+		}
+
+		d.hash = c.Hash
 	}
 
 	d.c = c
@@ -73,19 +89,28 @@ func (dl *dlq) generateProof(k *eccgroup.Scalar, a, b *eccgroup.Element, c, d []
 		return nil, err
 	}
 
-	var r *eccgroup.Scalar
+	r := rnd
 	//nolint:gocritic //not a commented code
 	// r = G.RandomScalar()
-	if rnd == nil {
-		r = dl.c.Group.NewScalar().Random()
-	} else {
-		r = rnd
+	if r == nil {
+		r, err = dl.c.Group.RandomScalarFrom(dl.c.Rand)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// t2 = r * A
-	t2 := dl.c.Group.NewElement().Add(a).Multiply(r)
+	// t2 and t3 are serialized once computed, so they share an element
+	t := dl.c.Group.NewElement()
+
+	// t2 = r * A, with the generator tables when A is the group generator
+	//nolint:gocritic //not a commented code
+	// a2 = G.SerializeElement(t2)
+	a2 := typedgroup.MultiplyInto(t, r, a).Encode()
+
 	// t3 = r * M
-	t3 := dl.c.Group.NewElement().Add(M).Multiply(r)
+	//nolint:gocritic //not a commented code
+	// a3 = G.SerializeElement(t3)
+	a3 := typedgroup.MultiplyInto(t, r, M).Encode()
 
 	//nolint:gocritic //not a commented code
 	// Bm = G.SerializeElement(B)
@@ -99,14 +124,6 @@ func (dl *dlq) generateProof(k *eccgroup.Scalar, a, b *eccgroup.Element, c, d []
 	// a1 = G.SerializeElement(Z)
 	a1 := Z.Encode()
 
-	//nolint:gocritic //not a commented code
-	// a2 = G.SerializeElement(t2)
-	a2 := t2.Encode()
-
-	//nolint:gocritic //not a commented code
-	// a3 = G.SerializeElement(t3)
-	a3 := t3.Encode()
-
 	//nolint:gocritic //not a commented code
 	// I2OSP(len(Bm), 2)
 	bmI2Osp2, err := utils.I2osp(big.NewInt(int64(len(Bm))), 2)
@@ -199,15 +216,11 @@ func (dl *dlq) VerifyProof(a, b *eccgroup.Element, c, d []*eccgroup.Element, pro
 
 	//nolint:gocritic //not a commented code
 	// t2 = ((s * A) + (c * B))
-	sA := dl.c.Group.NewElement().Add(a).Multiply(s)
-	cB := dl.c.Group.NewElement().Add(b).Multiply(cc)
-	t2 := dl.c.Group.NewElement().Add(sA).Add(cB)
+	t2 := dl.c.Group.MultiScalarMult([]*eccgroup.Scalar{s, cc}, []*eccgroup.Element{a, b})
 
 	//nolint:gocritic //not a commented code
 	// t3 = ((s * M) + (c * Z))
-	sM := dl.c.Group.NewElement().Add(M).Multiply(s)
-	cZ := dl.c.Group.NewElement().Add(Z).Multiply(cc)
-	t3 := dl.c.Group.NewElement().Add(sM).Add(cZ)
+	t3 := dl.c.Group.MultiScalarMult([]*eccgroup.Scalar{s, cc}, []*eccgroup.Element{M, Z})
 
 	Bm := b.Encode()
 	a0 := M.Encode()
@@ -312,13 +325,8 @@ func (dl *dlq) computeComposites(k *eccgroup.Scalar, b *eccgroup.Element, c, d [
 
 	hashToScalarDST := utils.Concat([]byte(labelHashToScalar), dl.c.DST)
 
-	//nolint:gocritic //not a commented code
-	// M = G.Identity()
-	M := dl.c.Group.NewElement().Identity()
-
-	//nolint:gocritic //not a commented code
-	// Z = G.Identity() // used if k is not nil
-	Z := dl.c.Group.NewElement().Identity()
+	// composite weights, accumulated with a single multi-scalar multiplication
+	ds := make([]*eccgroup.Scalar, len(c))
 
 	//nolint:gocritic //not a commented code
 	// for i in range(m):
@@ -350,28 +358,21 @@ func (dl *dlq) computeComposites(k *eccgroup.Scalar, b *eccgroup.Element, c, d [
 		h2Input := utils.Concat(lenSeedI2osp2, seed, iI2osp2, ciI2osp2, Ci, diI2osp2, Di, []byte(labelComposite))
 
 		// di = G.HashToScalar(h2Input)
-		di := dl.c.Group.HashToScalar(h2Input, hashToScalarDST)
-
-		//   M = di * C[i] + M
-		diCi := dl.c.Group.NewElement()
-		diCi.Add(c[i])
-		diCi.Multiply(di)
-		M.Add(diCi)
-
-		if k == nil {
-			// Z = di * D[i] + Z
-			diDi := dl.c.Group.NewElement()
-			diDi.Add(d[i])
-			diDi.Multiply(di)
-			Z.Add(diDi)
-		}
+		ds[i] = dl.c.Group.HashToScalar(h2Input, hashToScalarDST)
 	}
 
-	if k != nil {
+	//nolint:gocritic //not a commented code
+	// M = d0 * C[0] + ... + dm * C[m]
+	M := dl.c.Group.MultiScalarMult(ds, c)
+
+	var Z *eccgroup.Element
+
+	if k == nil {
+		// Z = d0 * D[0] + ... + dm * D[m]
+		Z = dl.c.Group.MultiScalarMult(ds, d)
+	} else {
 		// Z = k * M
-		Z = dl.c.Group.NewElement()
-		Z.Add(M)
-		Z.Multiply(k)
+		Z = typedgroup.MultiplyInto(dl.c.Group.NewElement(), k, M)
 	}
 
 	// return (M, Z)
@@ -9,15 +9,19 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/cymony/cryptomony/drbg"
 	"github.com/cymony/cryptomony/eccgroup"
+	"github.com/cymony/cryptomony/hash"
 	"github.com/cymony/cryptomony/internal/test"
 )
 
 var allGroups = []eccgroup.Group{
 	eccgroup.Ristretto255Sha512,
+	eccgroup.Decaf448Shake256,
 	eccgroup.P256Sha256,
 	eccgroup.P384Sha384,
 	eccgroup.P521Sha512,
+	eccgroup.Secp256k1Sha256,
 }
 var dst = []byte("my_domain_separation_tag_for_test")
 
@@ -57,7 +61,7 @@ func TestDLEQ(t *testing.T) {
 func TestWithRandomness(t *testing.T) {
 	for _, group := range allGroups {
 		t.Run(fmt.Sprintf("Group/%s", group.String()), func(t *testing.T) {
-			conf := &Configuration{dst, group}
+			conf := &Configuration{DST: dst, Group: group}
 
 			prover, err := NewProver(conf)
 			test.CheckNoErr(t, err, "new prover err")
@@ -84,9 +88,87 @@ func TestWithRandomness(t *testing.T) {
 	}
 }
 
+func TestWithRandomReader(t *testing.T) {
+	newConf := func(group eccgroup.Group) *Configuration {
+		r, err := drbg.NewHash(hash.SHA256, bytes.Repeat([]byte{0x2a}, 32), []byte("dleq"), nil)
+		test.CheckNoErr(t, err, "drbg instantiation err")
+
+		return &Configuration{DST: dst, Group: group, Rand: r}
+	}
+
+	for _, group := range allGroups {
+		t.Run(fmt.Sprintf("Group/%s", group.String()), func(t *testing.T) {
+			proverOne, err := NewProver(newConf(group))
+			test.CheckNoErr(t, err, "new prover err")
+
+			proverTwo, err := NewProver(newConf(group))
+			test.CheckNoErr(t, err, "new prover err")
+
+			verifier, err := NewVerifier(newConf(group))
+			test.CheckNoErr(t, err, "new verifier err")
+
+			k := group.RandomScalar()
+			A := group.Base()
+			B := group.Base().Multiply(k)
+			C := group.RandomElement()
+			D := group.NewElement().Add(C).Multiply(k)
+
+			proofOne, err := proverOne.GenerateProof(k, A, B, []*eccgroup.Element{C}, []*eccgroup.Element{D})
+			test.CheckNoErr(t, err, "generate proof err")
+
+			proofTwo, err := proverTwo.GenerateProof(k, A, B, []*eccgroup.Element{C}, []*eccgroup.Element{D})
+			test.CheckNoErr(t, err, "generate proof err")
+
+			if !bytes.Equal(proofOne, proofTwo) {
+				test.Report(t, "not equal", "equal", proofOne, proofTwo)
+			}
+
+			test.CheckOk(t, verifier.VerifyProof(A, B, []*eccgroup.Element{C}, []*eccgroup.Element{D}, proofOne), "proof is not verified")
+		})
+	}
+}
+
+// renamedGroup is a group implementation registered under another ciphersuite identifier.
+type renamedGroup struct {
+	eccgroup.GroupImpl
+}
+
+func (r *renamedGroup) Ciphersuite() string {
+	return "dleq-test-P256"
+}
+
+func TestRegisteredGroup(t *testing.T) {
+	group, err := eccgroup.Register(&renamedGroup{eccgroup.P256Sha256.Impl()})
+	test.CheckNoErr(t, err, "registration failed")
+
+	_, err = NewProver(&Configuration{DST: dst, Group: group})
+	test.CheckIsErr(t, err, "registered group without hash should be unsupported")
+
+	_, err = NewProver(&Configuration{DST: dst, Group: eccgroup.Group(0xff)})
+	test.CheckIsErr(t, err, "unavailable group should be unsupported")
+
+	conf := &Configuration{DST: dst, Group: group, Hash: hash.SHA256}
+
+	prover, err := NewProver(conf)
+	test.CheckNoErr(t, err, "new prover err")
+
+	verifier, err := NewVerifier(conf)
+	test.CheckNoErr(t, err, "new verifier err")
+
+	k := group.RandomScalar()
+	A := group.Base()
+	B := group.Base().Multiply(k)
+	C := group.RandomElement()
+	D := C.Copy().Multiply(k)
+
+	proof, err := prover.GenerateProof(k, A, B, []*eccgroup.Element{C}, []*eccgroup.Element{D})
+	test.CheckNoErr(t, err, "generate proof err")
+	test.CheckOk(t, verifier.VerifyProof(A, B, []*eccgroup.Element{C}, []*eccgroup.Element{D}, proof), "proof not verified")
+}
+
 func BenchmarkDLEQ(b *testing.B) {
 	for _, group := range allGroups {
-		conf := &Configuration{dst, group}
+		conf := &Configuration{DST: dst, Group: group}
 
 		Peggy, err := NewProver(conf)
 		test.CheckNoErr(b, err, "new prover err")
@@ -104,12 +186,16 @@ func BenchmarkDLEQ(b *testing.B) {
 		kB := group.NewElement().Add(B).Multiply(k)
 
 		b.Run(fmt.Sprintf("%s/GenerateProof", group.String()), func(b *testing.B) {
+			b.ReportAllocs()
+
 			for i := 0; i < b.N; i++ {
 				_, _ = Peggy.GenerateProof(k, A, kA, []*eccgroup.Element{B}, []*eccgroup.Element{kB}) //nolint:errcheck //benchmark
 			}
 		})
 
 		b.Run(fmt.Sprintf("%s/GenerateProofWithRandomness", group.String()), func(b *testing.B) {
+			b.ReportAllocs()
+
 			for i := 0; i < b.N; i++ {
 				_, _ = Peggy.GenerateProofWithRandomness(k, A, kA, []*eccgroup.Element{B}, []*eccgroup.Element{kB}, rnd) //nolint:errcheck //benchmark
 			}
@@ -119,6 +205,8 @@ func BenchmarkDLEQ(b *testing.B) {
 		test.CheckNoErr(b, err, "generate proof err")
 
 		b.Run(fmt.Sprintf("%s/VerifyProof", group.String()), func(b *testing.B) {
+			b.ReportAllocs()
+
 			for i := 0; i < b.N; i++ {
 				_ = Victor.VerifyProof(A, kA, []*eccgroup.Element{B}, []*eccgroup.Element{kB}, proof)
 			}
@@ -0,0 +1,94 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package drbg implements the HMAC_DRBG and Hash_DRBG deterministic random bit generators of NIST SP 800-90A.
+// Both are io.Reader values, and can be supplied as the source of randomness of the eccgroup, oprf, dleq and opaque
+// packages, e.g. to reproduce protocol transcripts from a seed.
+// Reference: https://nvlpubs.nist.gov/nistpubs/SpecialPublications/NIST.SP.800-90Ar1.pdf
+package drbg
+
+import (
+	"errors"
+	"io"
+
+	"github.com/cymony/cryptomony/hash"
+)
+
+var (
+	// ErrInvalidHash is returned when the hash function is not approved for the DRBG mechanisms.
+	ErrInvalidHash = errors.New("drbg: unsupported hash function")
+	// ErrEntropyTooShort is returned when the entropy input is shorter than the security strength.
+	ErrEntropyTooShort = errors.New("drbg: entropy input is too short")
+	// ErrRequestTooLarge is returned when more than MaxRequestSize bytes are requested to Generate.
+	ErrRequestTooLarge = errors.New("drbg: requested output is too large")
+	// ErrReseedRequired is returned when the generator must be reseeded before generating more output.
+	ErrReseedRequired = errors.New("drbg: reseed required")
+)
+
+const (
+	// MaxRequestSize is the maximum number of bytes generated by a Generate call (2^19 bits).
+	MaxRequestSize = 1 << 16
+	// ReseedInterval is the maximum number of Generate calls between two reseeds.
+	ReseedInterval = 1 << 48
+)
+
+// DRBG is a deterministic random bit generator. Read fills the buffer with successive Generate calls of at most
+// MaxRequestSize bytes, without additional input. DRBG values are safe for concurrent use.
+type DRBG interface {
+	io.Reader
+	// Generate fills out with pseudorandom bytes, after mixing the optional additional input into the state.
+	Generate(out, additionalInput []byte) error
+	// Reseed mixes fresh entropy input and the optional additional input into the state.
+	Reseed(entropy, additionalInput []byte) error
+	// SecurityStrength returns the security strength of the generator in bits.
+	SecurityStrength() int
+}
+
+// securityStrength returns the maximum security strength in bits supported by the hash function, or 0 if it is not
+// approved for the DRBG mechanisms.
+func securityStrength(h hash.Hashing) int {
+	switch h {
+	case hash.SHA224, hash.SHA512_224:
+		return 192 //nolint:gomnd //SP 800-90A table 2
+	case hash.SHA256, hash.SHA512_256, hash.SHA384, hash.SHA512:
+		return 256 //nolint:gomnd //SP 800-90A table 2
+	default:
+		return 0
+	}
+}
+
+// checkInstantiate validates the hash function and the entropy input, and returns the security strength.
+func checkInstantiate(h hash.Hashing, entropy []byte) (int, error) {
+	strength := securityStrength(h)
+	if strength == 0 || !h.Available() {
+		return 0, ErrInvalidHash
+	}
+
+	if 8*len(entropy) < strength {
+		return 0, ErrEntropyTooShort
+	}
+
+	return strength, nil
+}
+
+// read fills p with Generate calls of at most MaxRequestSize bytes.
+func read(d DRBG, p []byte) (int, error) {
+	read := 0
+
+	for len(p) > 0 {
+		n := len(p)
+		if n > MaxRequestSize {
+			n = MaxRequestSize
+		}
+
+		if err := d.Generate(p[:n], nil); err != nil {
+			return read, err
+		}
+
+		read += n
+		p = p[n:]
+	}
+
+	return read, nil
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package drbg_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/cymony/cryptomony/drbg"
+	"github.com/cymony/cryptomony/hash"
+	"github.com/cymony/cryptomony/internal/test"
+)
+
+func hexDecode(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	test.CheckNoErr(t, err, "decode string err")
+
+	return b
+}
+
+// First SHA-256 samples of the CAVP HMAC_DRBG.rsp and Hash_DRBG.rsp files, without prediction resistance,
+// personalization string nor additional input. The second of two 1024 bits Generate calls is checked.
+func TestVectors(t *testing.T) {
+	for _, tc := range []struct {
+		name                    string
+		newDRBG                 func(h hash.Hashing, entropy, nonce, personalization []byte) (drbg.DRBG, error)
+		entropy, nonce, results string
+	}{
+		{
+			name: "HMAC_DRBG",
+			newDRBG: func(h hash.Hashing, entropy, nonce, personalization []byte) (drbg.DRBG, error) {
+				return drbg.NewHMAC(h, entropy, nonce, personalization)
+			},
+			entropy: "ca851911349384bffe89de1cbdc46e6831e44d34a4fb935ee285dd14b71a7488",
+			nonce:   "659ba96c601dc69fc902940805ec0ca8",
+			results: "e528e9abf2dece54d47c7e75e5fe302149f817ea9fb4bee6f4199697d04d5b89d54fbb978a15b5c443c9ec21036d2460b6f73ebad0dc2aba6e624abf07745bc107694bb7547bb0995f70de25d6b29e2d3011bb19d27676c07162c8b5ccde0668961df86803482cb37ed6d5c0bb8d50cf1f50d476aa0458bdaba806f48be9dcb8",
+		},
+		{
+			name: "Hash_DRBG",
+			newDRBG: func(h hash.Hashing, entropy, nonce, personalization []byte) (drbg.DRBG, error) {
+				return drbg.NewHash(h, entropy, nonce, personalization)
+			},
+			entropy: "a65ad0f345db4e0effe875c3a2e71f42c7129d620ff5c119a9ef55f05185e0fb",
+			nonce:   "8581f9317517276e06e9607ddbcbcc2e",
+			results: "d3e160c35b99f340b2628264d1751060e0045da383ff57a57d73a673d2b8d80daaf6a6c35a91bb4579d73fd0c8fed111b0391306828adfed528f018121b3febdc343e797b87dbb63db1333ded9d1ece177cfa6b71fe8ab1da46624ed6415e51ccde2c7ca86e283990eeaeb91120415528b2295910281b02dd431f4c9f70427df",
+		},
+	} {
+		d, err := tc.newDRBG(hash.SHA256, hexDecode(t, tc.entropy), hexDecode(t, tc.nonce), nil)
+		test.CheckNoErr(t, err, tc.name+" instantiation failed")
+		test.CheckOk(t, d.SecurityStrength() == 256, tc.name+" wrong security strength")
+
+		out := make([]byte, 128)
+		test.CheckNoErr(t, d.Generate(out, nil), tc.name+" generate failed")
+		test.CheckNoErr(t, d.Generate(out, nil), tc.name+" generate failed")
+
+		if want := hexDecode(t, tc.results); !bytes.Equal(out, want) {
+			test.Report(t, out, want, tc.name)
+		}
+	}
+}
+
+func TestDRBG(t *testing.T) {
+	entropy, nonce := bytes.Repeat([]byte{0x42}, 32), []byte("nonce")
+
+	for _, h := range []hash.Hashing{hash.SHA224, hash.SHA256, hash.SHA384, hash.SHA512, hash.SHA512_256} {
+		for name, newDRBG := range map[string]func(personalization []byte) (drbg.DRBG, error){
+			"HMAC_DRBG": func(personalization []byte) (drbg.DRBG, error) {
+				return drbg.NewHMAC(h, entropy, nonce, personalization)
+			},
+			"Hash_DRBG": func(personalization []byte) (drbg.DRBG, error) {
+				return drbg.NewHash(h, entropy, nonce, personalization)
+			},
+		} {
+			name += " " + h.New().String()
+
+			d1, err := newDRBG(nil)
+			test.CheckNoErr(t, err, name+" instantiation failed")
+			d2, err := newDRBG(nil)
+			test.CheckNoErr(t, err, name+" instantiation failed")
+			d3, err := newDRBG([]byte("personalization"))
+			test.CheckNoErr(t, err, name+" instantiation failed")
+
+			// Read splits the requests in Generate calls of MaxRequestSize bytes
+			long := make([]byte, drbg.MaxRequestSize+100)
+			_, err = io.ReadFull(d1, long)
+			test.CheckNoErr(t, err, name+" read failed")
+
+			first, second := make([]byte, drbg.MaxRequestSize), make([]byte, 100)
+			test.CheckNoErr(t, d2.Generate(first, nil), name+" generate failed")
+			test.CheckNoErr(t, d2.Generate(second, nil), name+" generate failed")
+			test.CheckOk(t, bytes.Equal(long, append(first, second...)), name+" Read should match Generate")
+
+			test.CheckIsErr(t, d2.Generate(make([]byte, drbg.MaxRequestSize+1), nil), name+" too large request should fail")
+
+			a, b, c := make([]byte, 64), make([]byte, 64), make([]byte, 64)
+			test.CheckNoErr(t, d1.Generate(a, nil), name+" generate failed")
+			test.CheckNoErr(t, d2.Generate(b, []byte("additional input")), name+" generate failed")
+			test.CheckNoErr(t, d3.Generate(c, nil), name+" generate failed")
+			test.CheckOk(t, !bytes.Equal(a, b), name+" additional input should change the output")
+
+			first = first[:100]
+			_, _ = d3.Read(first)
+			test.CheckOk(t, !bytes.Equal(first, long[:100]), name+" personalization should change the output")
+
+			test.CheckIsErr(t, d1.Reseed(entropy[:8], nil), name+" short entropy should fail")
+			test.CheckNoErr(t, d1.Reseed(entropy, nil), name+" reseed failed")
+			test.CheckNoErr(t, d1.Generate(a, nil), name+" generate failed")
+			test.CheckOk(t, !bytes.Equal(a, b), name+" reseed should change the output")
+		}
+	}
+
+	_, err := drbg.NewHMAC(hash.SHA3_256, entropy, nonce, nil)
+	test.CheckIsErr(t, err, "SHA3-256 should be rejected")
+	_, err = drbg.NewHash(hash.BLAKE3, entropy, nonce, nil)
+	test.CheckIsErr(t, err, "BLAKE3 should be rejected")
+	_, err = drbg.NewHMAC(hash.SHA256, entropy[:16], nonce, nil)
+	test.CheckIsErr(t, err, "short entropy should be rejected")
+	_, err = drbg.NewHash(hash.SHA224, entropy[:24], nonce, nil)
+	test.CheckNoErr(t, err, "SHA-224 entropy of 192 bits should be accepted")
+}
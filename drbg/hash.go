@@ -0,0 +1,153 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package drbg
+
+import (
+	"encoding/binary"
+	stdHash "hash"
+	"sync"
+
+	"github.com/cymony/cryptomony/hash"
+	"github.com/cymony/cryptomony/utils"
+)
+
+const (
+	// seed lengths of Hash_DRBG in bytes, SP 800-90A table 2
+	seedLen     = 55  // 440 bits
+	seedLenWide = 111 // 888 bits, for SHA-384 and SHA-512
+)
+
+// Hash is the Hash_DRBG mechanism of SP 800-90A section 10.1.1.
+type Hash struct {
+	mu       sync.Mutex
+	h        stdHash.Hash
+	v        []byte
+	c        []byte
+	counter  uint64
+	strength int
+}
+
+// NewHash instantiates a Hash_DRBG with the SHA-2 hash function, from the entropy input, the nonce and the optional
+// personalization string. The entropy input must be at least as long as the security strength.
+func NewHash(h hash.Hashing, entropy, nonce, personalization []byte) (*Hash, error) {
+	strength, err := checkInstantiate(h, entropy)
+	if err != nil {
+		return nil, err
+	}
+
+	l := seedLen
+	if h == hash.SHA384 || h == hash.SHA512 {
+		l = seedLenWide
+	}
+
+	d := &Hash{h: h.CryptoID().New(), v: make([]byte, l), c: make([]byte, l), strength: strength}
+	d.seed(entropy, nonce, personalization)
+
+	return d, nil
+}
+
+// seed sets V = Hash_df(seedMaterial) and C = Hash_df(0x00 || V), and resets the reseed counter.
+func (d *Hash) seed(seedMaterial ...[]byte) {
+	d.df(d.v, seedMaterial...)
+	d.df(d.c, []byte{0x00}, d.v)
+	d.counter = 1
+}
+
+func (d *Hash) hash(inputs ...[]byte) []byte {
+	d.h.Reset()
+
+	for _, in := range inputs {
+		_, _ = d.h.Write(in) //nolint:errcheck //hash writes never fail
+	}
+
+	return d.h.Sum(nil)
+}
+
+// df is the Hash_df derivation function, which fills out from the concatenated inputs.
+func (d *Hash) df(out []byte, inputs ...[]byte) {
+	var bits [4]byte
+
+	binary.BigEndian.PutUint32(bits[:], uint32(8*len(out)))
+	input := utils.Concat(inputs...)
+
+	for off, counter := 0, byte(1); off < len(out); counter++ {
+		off += copy(out[off:], d.hash([]byte{counter}, bits[:], input))
+	}
+}
+
+// Reseed mixes fresh entropy input and the optional additional input into the state.
+func (d *Hash) Reseed(entropy, additionalInput []byte) error {
+	if 8*len(entropy) < d.strength {
+		return ErrEntropyTooShort
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.seed([]byte{0x01}, append([]byte(nil), d.v...), entropy, additionalInput)
+
+	return nil
+}
+
+// Generate fills out with pseudorandom bytes, after mixing the optional additional input into the state.
+func (d *Hash) Generate(out, additionalInput []byte) error {
+	if len(out) > MaxRequestSize {
+		return ErrRequestTooLarge
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.counter > ReseedInterval {
+		return ErrReseedRequired
+	}
+
+	if len(additionalInput) != 0 {
+		addMod(d.v, d.hash([]byte{0x02}, d.v, additionalInput))
+	}
+
+	// Hashgen
+	data := append([]byte(nil), d.v...)
+	for off := 0; off < len(out); {
+		off += copy(out[off:], d.hash(data))
+		addMod(data, []byte{0x01})
+	}
+
+	var counter [8]byte
+
+	binary.BigEndian.PutUint64(counter[:], d.counter)
+
+	addMod(d.v, d.hash([]byte{0x03}, d.v))
+	addMod(d.v, d.c)
+	addMod(d.v, counter[:])
+	d.counter++
+
+	return nil
+}
+
+// Read fills p with pseudorandom bytes. It only returns an error when the generator must be reseeded.
+func (d *Hash) Read(p []byte) (int, error) {
+	return read(d, p)
+}
+
+// SecurityStrength returns the security strength of the generator in bits.
+func (d *Hash) SecurityStrength() int {
+	return d.strength
+}
+
+// addMod sets x = (x + y) mod 2^(8*len(x)), where x and y are big-endian and y is not longer than x.
+func addMod(x, y []byte) {
+	var carry uint16
+
+	for i, j := len(x)-1, len(y)-1; i >= 0; i, j = i-1, j-1 {
+		sum := uint16(x[i]) + carry
+		if j >= 0 {
+			sum += uint16(y[j])
+		}
+
+		x[i] = byte(sum)
+		carry = sum >> 8 //nolint:gomnd //byte carry
+	}
+}
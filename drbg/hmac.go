@@ -0,0 +1,124 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package drbg
+
+import (
+	"crypto/hmac"
+	stdHash "hash"
+	"sync"
+
+	"github.com/cymony/cryptomony/hash"
+	"github.com/cymony/cryptomony/utils"
+)
+
+// HMAC is the HMAC_DRBG mechanism of SP 800-90A section 10.1.2.
+type HMAC struct {
+	mu       sync.Mutex
+	newHash  func() stdHash.Hash
+	k        []byte
+	v        []byte
+	counter  uint64
+	strength int
+}
+
+// NewHMAC instantiates a HMAC_DRBG with the SHA-2 hash function, from the entropy input, the nonce and the optional
+// personalization string. The entropy input must be at least as long as the security strength.
+func NewHMAC(h hash.Hashing, entropy, nonce, personalization []byte) (*HMAC, error) {
+	strength, err := checkInstantiate(h, entropy)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &HMAC{
+		newHash:  h.CryptoID().New,
+		k:        make([]byte, h.Size()),
+		v:        make([]byte, h.Size()),
+		strength: strength,
+	}
+
+	for i := range d.v {
+		d.v[i] = 0x01
+	}
+
+	d.update(utils.Concat(entropy, nonce, personalization))
+	d.counter = 1
+
+	return d, nil
+}
+
+func (d *HMAC) mac(inputs ...[]byte) []byte {
+	m := hmac.New(d.newHash, d.k)
+	for _, in := range inputs {
+		_, _ = m.Write(in) //nolint:errcheck //hash writes never fail
+	}
+
+	return m.Sum(nil)
+}
+
+// update is the HMAC_DRBG_Update function.
+func (d *HMAC) update(provided []byte) {
+	d.k = d.mac(d.v, []byte{0x00}, provided)
+	d.v = d.mac(d.v)
+
+	if len(provided) == 0 {
+		return
+	}
+
+	d.k = d.mac(d.v, []byte{0x01}, provided)
+	d.v = d.mac(d.v)
+}
+
+// Reseed mixes fresh entropy input and the optional additional input into the state.
+func (d *HMAC) Reseed(entropy, additionalInput []byte) error {
+	if 8*len(entropy) < d.strength {
+		return ErrEntropyTooShort
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.update(utils.Concat(entropy, additionalInput))
+	d.counter = 1
+
+	return nil
+}
+
+// Generate fills out with pseudorandom bytes, after mixing the optional additional input into the state.
+func (d *HMAC) Generate(out, additionalInput []byte) error {
+	if len(out) > MaxRequestSize {
+		return ErrRequestTooLarge
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.counter > ReseedInterval {
+		return ErrReseedRequired
+	}
+
+	if len(additionalInput) != 0 {
+		d.update(additionalInput)
+	}
+
+	for off := 0; off < len(out); {
+		d.v = d.mac(d.v)
+		off += copy(out[off:], d.v)
+	}
+
+	d.update(additionalInput)
+	d.counter++
+
+	return nil
+}
+
+// Read fills p with pseudorandom bytes. It only returns an error when the generator must be reseeded.
+func (d *HMAC) Read(p []byte) (int, error) {
+	return read(d, p)
+}
+
+// SecurityStrength returns the security strength of the generator in bits.
+func (d *HMAC) SecurityStrength() int {
+	return d.strength
+}
@@ -5,26 +5,31 @@
 package eccgroup
 
 import (
+	"errors"
+
 	"github.com/cymony/cryptomony/eccgroup/internal"
 )
 
+var errSEC1Unsupported = errors.New("SEC 1 encodings are only supported by the NIST and secp256k1 groups")
+
 // Element represents an element on the curve of the prime-order group.
 type Element struct {
 	internal.Element
+	g Group
 }
 
-func newPoint(p internal.Element) *Element {
-	return &Element{p}
+func newPoint(g Group, p internal.Element) *Element {
+	return &Element{p, g}
 }
 
 // Base sets the receiver to the group's base point a.k.a. canonical generator, and returns the receiver.
 func (e *Element) Base() *Element {
-	return &Element{e.Element.Base()}
+	return &Element{e.Element.Base(), e.g}
 }
 
 // Identity sets the receiver to the point at infinity of the Group's underlying curve, and returns the reveiver.
 func (e *Element) Identity() *Element {
-	return &Element{e.Element.Identity()}
+	return &Element{e.Element.Identity(), e.g}
 }
 
 // Add sets the receiver to the sum of the input and the receiver, and returns the receiver.
@@ -95,7 +100,7 @@ func (e *Element) Set(element *Element) *Element {
 
 // Copy returns a copy of the receiver.
 func (e *Element) Copy() *Element {
-	return &Element{e.Element.Copy()}
+	return &Element{e.Element.Copy(), e.g}
 }
 
 // Encode returns the compressed byte encoding of the element.
@@ -108,6 +113,64 @@ func (e *Element) Decode(data []byte) error {
 	return e.Element.Decode(data)
 }
 
+// EncodeUncompressed returns the SEC 1 uncompressed encoding 0x04 || x || y of the element, or the single 0x00 byte
+// for the point at infinity. It returns an error for groups other than the NIST and secp256k1 groups.
+func (e *Element) EncodeUncompressed() ([]byte, error) {
+	sec1, ok := e.Element.(internal.SEC1Element)
+	if !ok {
+		return nil, errSEC1Unsupported
+	}
+
+	return sec1.EncodeUncompressed(), nil
+}
+
+// EncodeX returns the x-coordinate of the element, as used by x-only public keys. It returns an error for the point
+// at infinity, and for groups other than the NIST and secp256k1 groups.
+func (e *Element) EncodeX() ([]byte, error) {
+	sec1, ok := e.Element.(internal.SEC1Element)
+	if !ok {
+		return nil, errSEC1Unsupported
+	}
+
+	return sec1.EncodeX()
+}
+
+// DecodeAny sets the receiver to a decoding of the input data, and returns an error on failure.
+// For the NIST and secp256k1 groups, it accepts the compressed, uncompressed, hybrid and infinity SEC 1 encodings,
+// and checks the point is on the curve. Other groups have a single encoding, and DecodeAny is the same as Decode.
+func (e *Element) DecodeAny(data []byte) error {
+	sec1, ok := e.Element.(internal.SEC1Element)
+	if !ok {
+		return e.Element.Decode(data)
+	}
+
+	return sec1.DecodeAny(data)
+}
+
+// DecodeX sets the receiver to the element with the given x-coordinate and an even y-coordinate, and returns an
+// error on failure. It returns an error for groups other than the NIST and secp256k1 groups.
+func (e *Element) DecodeX(data []byte) error {
+	sec1, ok := e.Element.(internal.SEC1Element)
+	if !ok {
+		return errSEC1Unsupported
+	}
+
+	return sec1.DecodeX(data)
+}
+
+// Preimage returns uniformly random bytes that the group's MapToElement maps to the element. Encoding elements with
+// their preimages makes them indistinguishable from random strings, and a new preimage is drawn on every call.
+// It runs in variable time, and returns an error for groups that do not support it, i.e. all but ristretto255, P256,
+// P384 and P521.
+func (e *Element) Preimage() ([]byte, error) {
+	m, ok := e.g.get().(internal.Mapper)
+	if !ok {
+		return nil, errNoMapping
+	}
+
+	return m.Preimage(e.Element)
+}
+
 // MarshalBinary returns the compressed byte encoding of the element.
 func (e *Element) MarshalBinary() ([]byte, error) {
 	return e.Element.MarshalBinary()
@@ -6,8 +6,10 @@
 package eccgroup
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/cymony/cryptomony/eccgroup/internal"
 	"github.com/cymony/cryptomony/internal/test"
 )
 
@@ -184,6 +186,81 @@ func testEncodeAndDecode(t *testing.T, testTimes int, g Group) {
 	}
 }
 
+func testSEC1Encodings(t *testing.T, testTimes int, g Group) {
+	t.Helper()
+
+	if _, ok := g.NewElement().Element.(internal.SEC1Element); !ok {
+		r := g.RandomElement()
+
+		_, err := r.EncodeUncompressed()
+		test.CheckIsErr(t, err, "uncompressed encoding should not be supported")
+		_, err = r.EncodeX()
+		test.CheckIsErr(t, err, "x-only encoding should not be supported")
+		err = g.NewElement().DecodeX(r.Encode())
+		test.CheckIsErr(t, err, "x-only encoding should not be supported")
+
+		Q := g.NewElement()
+		test.CheckNoErr(t, Q.DecodeAny(r.Encode()), "DecodeAny should decode the canonical encoding")
+		test.CheckOk(t, Q.Equal(r) == 1, "DecodeAny mismatch")
+
+		return
+	}
+
+	Q := g.NewElement()
+
+	for i := 0; i < testTimes; i++ {
+		r := g.RandomElement()
+		compressed := r.Encode()
+		fieldLen := len(compressed) - 1
+
+		uncompressed, err := r.EncodeUncompressed()
+		test.CheckNoErr(t, err, "EncodeUncompressed failed")
+		test.CheckOk(t, len(uncompressed) == 1+2*fieldLen && uncompressed[0] == 0x04, "wrong uncompressed format")
+		test.CheckOk(t, bytes.Equal(uncompressed[1:1+fieldLen], compressed[1:]), "x-coordinates mismatch")
+		test.CheckOk(t, compressed[0] == 0x02|uncompressed[len(uncompressed)-1]&1, "y parity mismatch")
+
+		test.CheckNoErr(t, Q.DecodeAny(uncompressed), "DecodeAny failed on uncompressed encoding")
+		test.CheckOk(t, Q.Equal(r) == 1, "uncompressed round trip mismatch")
+		test.CheckNoErr(t, Q.DecodeAny(compressed), "DecodeAny failed on compressed encoding")
+		test.CheckOk(t, Q.Equal(r) == 1, "compressed round trip mismatch")
+
+		hybrid := append([]byte{0x06 | uncompressed[len(uncompressed)-1]&1}, uncompressed[1:]...)
+		test.CheckNoErr(t, Q.DecodeAny(hybrid), "DecodeAny failed on hybrid encoding")
+		test.CheckOk(t, Q.Equal(r) == 1, "hybrid round trip mismatch")
+
+		hybrid[0] ^= 1
+		test.CheckIsErr(t, Q.DecodeAny(hybrid), "hybrid encoding with wrong parity should fail")
+
+		offCurve := append([]byte{}, uncompressed...)
+		offCurve[len(offCurve)-1] ^= 1
+		test.CheckIsErr(t, Q.DecodeAny(offCurve), "point not on curve should fail")
+
+		x, err := r.EncodeX()
+		test.CheckNoErr(t, err, "EncodeX failed")
+		test.CheckOk(t, bytes.Equal(x, compressed[1:]), "x-only encoding mismatch")
+
+		test.CheckNoErr(t, Q.DecodeX(x), "DecodeX failed")
+
+		if compressed[0] == 0x02 {
+			test.CheckOk(t, Q.Equal(r) == 1, "x-only round trip mismatch")
+		} else {
+			test.CheckOk(t, Q.Equal(r.Copy().Negate()) == 1, "x-only round trip mismatch")
+		}
+	}
+
+	identity := g.NewElement().Identity()
+
+	uncompressed, err := identity.EncodeUncompressed()
+	test.CheckNoErr(t, err, "EncodeUncompressed failed")
+	test.CheckOk(t, bytes.Equal(uncompressed, []byte{0}), "wrong identity encoding")
+	test.CheckNoErr(t, Q.DecodeAny(uncompressed), "DecodeAny failed on identity")
+	test.CheckOk(t, Q.IsIdentity(), "identity round trip mismatch")
+
+	_, err = identity.EncodeX()
+	test.CheckIsErr(t, err, "identity has no x-coordinate")
+	test.CheckIsErr(t, Q.DecodeAny([]byte{0x05, 1, 2}), "unknown prefix should fail")
+}
+
 func testMarshal(t *testing.T, testTimes int, g Group) {
 	t.Helper()
 
@@ -0,0 +1,36 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eccgroup
+
+import (
+	"github.com/cymony/cryptomony/eccgroup/internal"
+)
+
+// FixedBase holds precomputed multiples of an element, to speed up repeated scalar multiplications of that element,
+// e.g. a long-lived public key.
+type FixedBase struct {
+	g  Group
+	fb internal.FixedBase
+}
+
+// NewFixedBase returns a precomputed table of multiples of the element. The table is independent of the element,
+// which can be modified afterwards.
+func (g Group) NewFixedBase(element *Element) *FixedBase {
+	if element == nil {
+		panic(internal.ErrParamNilPoint)
+	}
+
+	return &FixedBase{g: g, fb: g.get().NewFixedBase(element.Element)}
+}
+
+// Multiply returns a new element set to the scalar multiplication of the precomputed element with the given Scalar.
+// It runs in constant time. If scalar is nil, the identity element is returned.
+func (f *FixedBase) Multiply(scalar *Scalar) *Element {
+	if scalar == nil {
+		return f.g.NewElement()
+	}
+
+	return newPoint(f.g, f.fb.Multiply(scalar.Scalar))
+}
@@ -10,6 +10,14 @@ Supported Curves;
   - P256
   - P384
   - P521
+  - Decaf448
+  - Secp256k1
+  - Edwards25519
+  - Curve25519
+  - BLS12-381 G1 and G2, with the pairing into GT
+
+Ristretto255 and the NIST and secp256k1 groups also have a typed API, see G, whose operations
+use concrete types and write to destination parameters instead of allocating.
 */
 package eccgroup
 
@@ -18,6 +26,9 @@ import (
 	"sync"
 
 	"github.com/cymony/cryptomony/eccgroup/internal"
+	"github.com/cymony/cryptomony/eccgroup/internal/bls12381"
+	"github.com/cymony/cryptomony/eccgroup/internal/decaf448"
+	"github.com/cymony/cryptomony/eccgroup/internal/ed25519"
 	"github.com/cymony/cryptomony/eccgroup/internal/nist"
 	"github.com/cymony/cryptomony/eccgroup/internal/r255"
 )
@@ -38,6 +49,24 @@ const (
 	// P521Sha512 identifies a group over P521 with SHA2-512 hash-to-group hashing
 	P521Sha512
 
+	// Decaf448Shake256 identifies the Decaf448 group with SHAKE256 hash-to-group hashing
+	Decaf448Shake256
+
+	// Secp256k1Sha256 identifies a group over secp256k1 with SHA2-256 hash-to-group hashing
+	Secp256k1Sha256
+
+	// Edwards25519Sha512 identifies the prime-order subgroup of edwards25519 with SHA2-512 hash-to-group hashing
+	Edwards25519Sha512
+
+	// Curve25519Sha512 identifies the prime-order subgroup of curve25519 with SHA2-512 hash-to-group hashing
+	Curve25519Sha512
+
+	// BLS12381G1Sha256 identifies the G1 group of BLS12-381 with SHA2-256 hash-to-group hashing
+	BLS12381G1Sha256
+
+	// BLS12381G2Sha256 identifies the G2 group of BLS12-381 with SHA2-256 hash-to-group hashing
+	BLS12381G2Sha256
+
 	maxID
 
 	dstfmt               = "%s-V%02d-CS%02d-%s"
@@ -50,21 +79,32 @@ var (
 	groups        [maxID - 1]internal.Group
 	errInvalidID  = errors.New("invalid group identifier")
 	errZeroLenDST = errors.New("zero-length DST")
+	errNoMapping  = errors.New("the group does not expose its map to elements")
 )
 
-// Available reports whether the given Group is linked into the binary.
+// Available reports whether the given Group is linked into the binary, or registered with Register.
 func (g Group) Available() bool {
-	return 0 < g && g < maxID
+	if 0 < g && g < maxID {
+		return true
+	}
+
+	_, ok := g.registered()
+
+	return ok
 }
 
 func (g Group) get() internal.Group {
-	if !g.Available() {
-		panic(errInvalidID)
+	if 0 < g && g < maxID {
+		once[g-1].Do(g.init)
+		return groups[g-1]
 	}
 
-	once[g-1].Do(g.init)
+	impl, ok := g.registered()
+	if !ok {
+		panic(errInvalidID)
+	}
 
-	return groups[g-1]
+	return impl
 }
 
 // String returns the hash-to-curve string identifier of the ciphersuite.
@@ -74,27 +114,27 @@ func (g Group) String() string {
 
 // NewScalar returns a new, empty, scalar.
 func (g Group) NewScalar() *Scalar {
-	return newScalar(g.get().NewScalar())
+	return newScalar(g, g.get().NewScalar())
 }
 
 // NewElement returns the identity element (point at infinity).
 func (g Group) NewElement() *Element {
-	return newPoint(g.get().NewElement())
+	return newPoint(g, g.get().NewElement())
 }
 
 // RandomScalar returns randomly generated scalar.
 func (g Group) RandomScalar() *Scalar {
-	return newScalar(g.get().RandomScalar())
+	return newScalar(g, g.get().RandomScalar())
 }
 
 // RandomElement returns randomly generated element.
 func (g Group) RandomElement() *Element {
-	return newPoint(g.get().RandomElement())
+	return newPoint(g, g.get().RandomElement())
 }
 
 // Base returns the group's base point a.k.a. canonical generator.
 func (g Group) Base() *Element {
-	return newPoint(g.get().Base())
+	return newPoint(g, g.get().Base())
 }
 
 func checkDST(dst []byte) {
@@ -107,21 +147,67 @@ func checkDST(dst []byte) {
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func (g Group) HashToScalar(input, dst []byte) *Scalar {
 	checkDST(dst)
-	return newScalar(g.get().HashToScalar(input, dst))
+	return newScalar(g, g.get().HashToScalar(input, dst))
 }
 
 // HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func (g Group) HashToGroup(input, dst []byte) *Element {
 	checkDST(dst)
-	return newPoint(g.get().HashToGroup(input, dst))
+	return newPoint(g, g.get().HashToGroup(input, dst))
 }
 
 // EncodeToGroup returns a non-uniform mapping of the arbitrary input to an Element in the Group.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func (g Group) EncodeToGroup(input, dst []byte) *Element {
 	checkDST(dst)
-	return newPoint(g.get().EncodeToGroup(input, dst))
+	return newPoint(g, g.get().EncodeToGroup(input, dst))
+}
+
+// UniformLength returns the byte size of the uniform input of MapToElement, and 0 if the group does not expose
+// its map to elements.
+func (g Group) UniformLength() uint {
+	m, ok := g.get().(internal.Mapper)
+	if !ok {
+		return 0
+	}
+
+	return m.UniformLength()
+}
+
+// MapToElement deterministically maps UniformLength uniform bytes to an Element, with the map HashToGroup applies to
+// the expanded input: the one-way map of ristretto255, and the simplified SWU method for the NIST and secp256k1
+// groups. It returns an error for other groups, or if uniform does not have the expected length.
+func (g Group) MapToElement(uniform []byte) (*Element, error) {
+	m, ok := g.get().(internal.Mapper)
+	if !ok {
+		return nil, errNoMapping
+	}
+
+	e, err := m.MapToElement(uniform)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPoint(g, e), nil
+}
+
+// MultiScalarMult returns the sum of the element-wise products of scalars and elements, i.e.
+// scalars[0] * elements[0] + ... + scalars[n-1] * elements[n-1]. It is much faster than
+// multiplying and adding elements one by one. It panics if the slices have different lengths.
+// The computation runs in variable time, so it must only be used with public scalars.
+func (g Group) MultiScalarMult(scalars []*Scalar, elements []*Element) *Element {
+	ss := make([]internal.Scalar, len(scalars))
+	for i, s := range scalars {
+		ss[i] = s.Scalar
+	}
+
+	es := make([]internal.Element, len(elements))
+	for i, e := range elements {
+		es[i] = e.Element
+	}
+
+	return newPoint(g, g.get().MultiScalarMult(ss, es))
 }
 
 // ScalarLength returns the byte size of an encoded scalar.
@@ -148,6 +234,18 @@ func (g Group) init() {
 		g.initGroup(nist.P384)
 	case P521Sha512:
 		g.initGroup(nist.P521)
+	case Decaf448Shake256:
+		g.initGroup(decaf448.Decaf448)
+	case Secp256k1Sha256:
+		g.initGroup(nist.Secp256k1)
+	case Edwards25519Sha512:
+		g.initGroup(ed25519.Edwards25519)
+	case Curve25519Sha512:
+		g.initGroup(ed25519.Curve25519)
+	case BLS12381G1Sha256:
+		g.initGroup(bls12381.G1)
+	case BLS12381G2Sha256:
+		g.initGroup(bls12381.G2)
 	case maxID:
 		panic("group not recognized")
 	default:
@@ -5,11 +5,18 @@
 package eccgroup
 
 import (
+	"bytes"
+	"fmt"
 	"testing"
 
+	"github.com/cymony/cryptomony/eccgroup/internal/bls12381"
+	"github.com/cymony/cryptomony/eccgroup/internal/decaf448"
+	"github.com/cymony/cryptomony/eccgroup/internal/ed25519"
 	"github.com/cymony/cryptomony/eccgroup/internal/nist"
 	"github.com/cymony/cryptomony/eccgroup/internal/r255"
+	"github.com/cymony/cryptomony/hash"
 	"github.com/cymony/cryptomony/internal/test"
+	"github.com/cymony/cryptomony/msgexpand"
 )
 
 var allGroups = []Group{
@@ -17,6 +24,12 @@ var allGroups = []Group{
 	P384Sha384,
 	P521Sha512,
 	Ristretto255Sha512,
+	Decaf448Shake256,
+	Secp256k1Sha256,
+	Edwards25519Sha512,
+	Curve25519Sha512,
+	BLS12381G1Sha256,
+	BLS12381G2Sha256,
 }
 
 func TestGroups(t *testing.T) {
@@ -36,6 +49,10 @@ func TestGroups(t *testing.T) {
 		t.Run(n+"/Scalar/Copy", func(tt *testing.T) { testCopyScalar(tt, testTimes, g) })
 		t.Run(n+"/Scalar/EncodeAndDecode", func(tt *testing.T) { testEncodeAndDecodeScalar(tt, testTimes, g) })
 		t.Run(n+"/Scalar/MarshalAndUnmarshal", func(tt *testing.T) { testMarshalScalar(tt, testTimes, g) })
+		t.Run(n+"/Scalar/BatchInvert", func(tt *testing.T) { testBatchInvertScalar(tt, testTimes, g) })
+		t.Run(n+"/Scalar/Pow", func(tt *testing.T) { testPowScalar(tt, testTimes, g) })
+		t.Run(n+"/Scalar/BigIntAndUint64", func(tt *testing.T) { testConversionsScalar(tt, testTimes, g) })
+		t.Run(n+"/Scalar/Compare", func(tt *testing.T) { testCompareScalar(tt, testTimes, g) })
 
 		t.Run(n+"/Element/Equal", func(tt *testing.T) { testEqual(tt, testTimes, g) })
 		t.Run(n+"/Element/Base", func(tt *testing.T) { testBase(tt, testTimes, g) })
@@ -46,10 +63,18 @@ func TestGroups(t *testing.T) {
 		t.Run(n+"/Element/Multiply", func(tt *testing.T) { testMultiply(tt, testTimes, g) })
 		t.Run(n+"/Element/Copy", func(tt *testing.T) { testCopy(tt, testTimes, g) })
 		t.Run(n+"/Element/EncodeAndDecode", func(tt *testing.T) { testEncodeAndDecode(tt, testTimes, g) })
+		t.Run(n+"/Element/SEC1Encodings", func(tt *testing.T) { testSEC1Encodings(tt, testTimes, g) })
 		t.Run(n+"/Element/MarshalAndUnmarshal", func(tt *testing.T) { testMarshal(tt, testTimes, g) })
 
+		t.Run(n+"/Tagged/JSON", func(tt *testing.T) { testTaggedJSON(tt, testTimes, g) })
+		t.Run(n+"/Tagged/Binary", func(tt *testing.T) { testTaggedBinary(tt, testTimes, g) })
+
 		t.Run(n+"/Group/Base", func(tt *testing.T) { testBaseGroup(tt, testTimes, g) })
 		t.Run(n+"/Group/ScalarAndElementLength", func(tt *testing.T) { testLengthsGroup(tt, testTimes, g) })
+		t.Run(n+"/Group/MultiScalarMult", func(tt *testing.T) { testMultiScalarMult(tt, testTimes, g) })
+		t.Run(n+"/Group/FixedBase", func(tt *testing.T) { testFixedBase(tt, testTimes, g) })
+		t.Run(n+"/Group/MapToElement", func(tt *testing.T) { testMapToElement(tt, testTimes, g) })
+		t.Run(n+"/Group/RandomFrom", func(tt *testing.T) { testRandomFrom(tt, testTimes, g) })
 	}
 
 	t.Run("Group/checkDST", func(tt *testing.T) { testcheckDST(tt) })
@@ -83,11 +108,172 @@ func testLengthsGroup(t *testing.T, _ int, g Group) {
 	case nist.P521().Ciphersuite():
 		test.CheckOk(t, nist.P521().ScalarLength() == g.ScalarLength(), "scalar length mismatch")
 		test.CheckOk(t, nist.P521().ElementLength() == g.ElementLength(), "element length mismatch")
+	case nist.Secp256k1().Ciphersuite():
+		test.CheckOk(t, nist.Secp256k1().ScalarLength() == g.ScalarLength(), "scalar length mismatch")
+		test.CheckOk(t, nist.Secp256k1().ElementLength() == g.ElementLength(), "element length mismatch")
+	case decaf448.Decaf448().Ciphersuite():
+		test.CheckOk(t, decaf448.Decaf448().ScalarLength() == g.ScalarLength(), "scalar length mismatch")
+		test.CheckOk(t, decaf448.Decaf448().ElementLength() == g.ElementLength(), "element length mismatch")
+	case ed25519.Edwards25519().Ciphersuite():
+		test.CheckOk(t, ed25519.Edwards25519().ScalarLength() == g.ScalarLength(), "scalar length mismatch")
+		test.CheckOk(t, ed25519.Edwards25519().ElementLength() == g.ElementLength(), "element length mismatch")
+	case ed25519.Curve25519().Ciphersuite():
+		test.CheckOk(t, ed25519.Curve25519().ScalarLength() == g.ScalarLength(), "scalar length mismatch")
+		test.CheckOk(t, ed25519.Curve25519().ElementLength() == g.ElementLength(), "element length mismatch")
+	case bls12381.G1().Ciphersuite():
+		test.CheckOk(t, bls12381.G1().ScalarLength() == g.ScalarLength(), "scalar length mismatch")
+		test.CheckOk(t, bls12381.G1().ElementLength() == g.ElementLength(), "element length mismatch")
+	case bls12381.G2().Ciphersuite():
+		test.CheckOk(t, bls12381.G2().ScalarLength() == g.ScalarLength(), "scalar length mismatch")
+		test.CheckOk(t, bls12381.G2().ElementLength() == g.ElementLength(), "element length mismatch")
 	default:
 		t.Error("unrecognized group")
 	}
 }
 
+func testMultiScalarMult(t *testing.T, _ int, g Group) {
+	t.Helper()
+
+	for _, n := range []int{0, 1, 2, 17, 100} {
+		scalars := make([]*Scalar, n)
+		elements := make([]*Element, n)
+		want := g.NewElement().Identity()
+
+		for i := 0; i < n; i++ {
+			scalars[i] = g.RandomScalar()
+			elements[i] = g.RandomElement()
+			want.Add(elements[i].Copy().Multiply(scalars[i]))
+		}
+
+		got := g.MultiScalarMult(scalars, elements)
+		if !(got.Equal(want) == 1) {
+			test.Report(t, got, want, n)
+		}
+	}
+
+	err := test.CheckPanic(func() {
+		g.MultiScalarMult([]*Scalar{g.RandomScalar()}, nil)
+	})
+	test.CheckNoErr(t, err, "panic expected")
+}
+
+func testFixedBase(t *testing.T, testTimes int, g Group) {
+	t.Helper()
+
+	element := g.RandomElement()
+	fb := g.NewFixedBase(element)
+	baseFB := g.NewFixedBase(g.Base())
+
+	for i := 0; i < testTimes; i++ {
+		s := g.RandomScalar()
+
+		want := element.Copy().Multiply(s)
+		got := fb.Multiply(s)
+
+		if !(got.Equal(want) == 1) {
+			test.Report(t, got, want, s)
+		}
+
+		// the generator tables must agree with the generic scalar multiplication
+		want = g.NewElement().Add(g.Base()).Multiply(s)
+		got = g.Base().Multiply(s)
+
+		if !(got.Equal(want) == 1) {
+			test.Report(t, got, want, s)
+		}
+
+		got = baseFB.Multiply(s)
+		if !(got.Equal(want) == 1) {
+			test.Report(t, got, want, s)
+		}
+	}
+
+	test.CheckOk(t, fb.Multiply(g.NewScalar().Zero()).IsIdentity(), "zero multiple should be the identity")
+	test.CheckOk(t, fb.Multiply(nil).IsIdentity(), "nil multiple should be the identity")
+	test.CheckOk(t, fb.Multiply(g.NewScalar().One()).Equal(element) == 1, "one multiple should be the element")
+	test.CheckOk(t, g.Base().Multiply(g.NewScalar().Zero()).IsIdentity(), "zero multiple should be the identity")
+
+	err := test.CheckPanic(func() {
+		g.NewFixedBase(nil)
+	})
+	test.CheckNoErr(t, err, "panic expected")
+}
+
+func testMapToElement(t *testing.T, testTimes int, g Group) {
+	t.Helper()
+
+	hashes := map[Group]hash.Hashing{
+		Ristretto255Sha512: hash.SHA512,
+		P256Sha256:         hash.SHA256,
+		P384Sha384:         hash.SHA384,
+		P521Sha512:         hash.SHA512,
+		Secp256k1Sha256:    hash.SHA256,
+	}
+
+	h, mapped := hashes[g]
+	if !mapped {
+		test.CheckOk(t, g.UniformLength() == 0, "uniform length of unsupported group should be 0")
+
+		_, err := g.MapToElement(nil)
+		test.CheckIsErr(t, err, "map to element error expected")
+
+		_, err = g.RandomElement().Preimage()
+		test.CheckIsErr(t, err, "preimage error expected")
+
+		return
+	}
+
+	length := int(g.UniformLength())
+	dst := []byte("MapToElement-Test-DST")
+
+	_, err := g.MapToElement(make([]byte, length-1))
+	test.CheckIsErr(t, err, "uniform length error expected")
+
+	// HashToGroup is MapToElement applied to the expanded input
+	for i := 0; i < testTimes; i++ {
+		input := []byte(fmt.Sprintf("input %d", i))
+
+		uniform, err := msgexpand.NewMessageExpandXMD(h).Expand(input, dst, length)
+		test.CheckNoErr(t, err, "expand error")
+
+		got, err := g.MapToElement(uniform)
+		test.CheckNoErr(t, err, "map to element error")
+
+		if want := g.HashToGroup(input, dst); !(got.Equal(want) == 1) {
+			test.Report(t, got, want, input)
+		}
+	}
+
+	if g == Secp256k1Sha256 {
+		_, err = g.RandomElement().Preimage()
+		test.CheckIsErr(t, err, "preimage error expected")
+
+		return
+	}
+
+	elements := []*Element{g.NewElement(), g.Base()}
+	for i := 0; i < testTimes/8; i++ {
+		elements = append(elements, g.RandomElement())
+	}
+
+	for _, e := range elements {
+		uniform, err := e.Preimage()
+		test.CheckNoErr(t, err, "preimage error")
+		test.CheckOk(t, len(uniform) == length, "preimage length mismatch")
+
+		got, err := g.MapToElement(uniform)
+		test.CheckNoErr(t, err, "map to element error")
+
+		if !(got.Equal(e) == 1) {
+			test.Report(t, got, e, uniform)
+		}
+
+		other, err := e.Preimage()
+		test.CheckNoErr(t, err, "preimage error")
+		test.CheckOk(t, !bytes.Equal(uniform, other), "preimages should be randomized")
+	}
+}
+
 func testcheckDST(t *testing.T) {
 	t.Helper()
 
@@ -103,8 +289,14 @@ func testAvailable(t *testing.T) {
 
 	for _, g := range allGroups {
 		test.CheckOk(t, g.Available(), "groups should be available")
+
+		got, ok := GroupByCiphersuite(g.String())
+		test.CheckOk(t, ok && got == g, "group not found by ciphersuite")
 	}
 
+	_, ok := GroupByCiphersuite("unknown")
+	test.CheckOk(t, !ok, "unknown ciphersuite should not be found")
+
 	test.CheckOk(t, !maxID.Available(), "maxID should not be available")
 
 	err := test.CheckPanic(func() {
@@ -112,3 +304,58 @@ func testAvailable(t *testing.T) {
 	})
 	test.CheckNoErr(t, err, "panic expected")
 }
+
+func BenchmarkMultiScalarMult(b *testing.B) {
+	for _, group := range allGroups {
+		for _, n := range []int{16, 256} {
+			scalars := make([]*Scalar, n)
+			elements := make([]*Element, n)
+
+			for i := 0; i < n; i++ {
+				scalars[i] = group.RandomScalar()
+				elements[i] = group.RandomElement()
+			}
+
+			b.Run(fmt.Sprintf("%s/%d/MultiScalarMult", group, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					group.MultiScalarMult(scalars, elements)
+				}
+			})
+
+			b.Run(fmt.Sprintf("%s/%d/MultiplyAndAdd", group, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					acc := group.NewElement()
+					for j := range elements {
+						acc.Add(elements[j].Copy().Multiply(scalars[j]))
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkFixedBase(b *testing.B) {
+	for _, group := range allGroups {
+		element := group.RandomElement()
+		fb := group.NewFixedBase(element)
+		s := group.RandomScalar()
+
+		b.Run(group.String()+"/FixedBase", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				fb.Multiply(s)
+			}
+		})
+
+		b.Run(group.String()+"/Multiply", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				element.Copy().Multiply(s)
+			}
+		})
+
+		b.Run(group.String()+"/BaseMultiply", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				group.Base().Multiply(s)
+			}
+		})
+	}
+}
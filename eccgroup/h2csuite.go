@@ -0,0 +1,146 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eccgroup
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cymony/cryptomony/eccgroup/internal"
+	"github.com/cymony/cryptomony/msgexpand"
+)
+
+var (
+	errNilExpander        = errors.New("nil message expander")
+	errExpanderFixedSuite = errors.New("the group does not support custom message expanders")
+	errUnnamedExpander    = errors.New("the message expander has no identifier")
+)
+
+// HashToCurveSuite is a hash-to-curve suite over a Group with a custom message expander, e.g.
+// P256_XOF:SHAKE-256_SSWU_RO_ with msgexpand.NewMessageExpandXOF(xof.SHAKE256, 128), instead of the expander fixed
+// by the Group's ciphersuite. It is supported by the Ristretto255, NIST and secp256k1 groups.
+type HashToCurveSuite struct {
+	g          Group
+	impl       internal.Expandable
+	expander   msgexpand.MessageExpand
+	expanderID string
+}
+
+// NewHashToCurveSuite returns the hash-to-curve suite over the group with the expander, and an error if the group
+// does not support custom expanders. The expander must implement fmt.Stringer, whose String returns its identifier in
+// the suite identifier, e.g. XOF:SHAKE-256, as the expanders of msgexpand do.
+func NewHashToCurveSuite(g Group, expander msgexpand.MessageExpand) (*HashToCurveSuite, error) {
+	if expander == nil {
+		return nil, errNilExpander
+	}
+
+	named, ok := expander.(fmt.Stringer)
+	if !ok {
+		return nil, errUnnamedExpander
+	}
+
+	if !g.Available() {
+		return nil, errInvalidID
+	}
+
+	impl, ok := g.get().(internal.Expandable)
+	if !ok {
+		return nil, errExpanderFixedSuite
+	}
+
+	return &HashToCurveSuite{g: g, impl: impl, expander: expander, expanderID: named.String()}, nil
+}
+
+// Group returns the group of the suite.
+func (s *HashToCurveSuite) Group() Group {
+	return s.g
+}
+
+// String returns the hash-to-curve suite identifier, e.g. P256_XOF:SHAKE-256_SSWU_RO_.
+func (s *HashToCurveSuite) String() string {
+	return s.impl.SuiteID(s.expanderID, false)
+}
+
+// EncodeToCurveID returns the encode-to-curve suite identifier, e.g. P256_XOF:SHAKE-256_SSWU_NU_.
+func (s *HashToCurveSuite) EncodeToCurveID() string {
+	return s.impl.SuiteID(s.expanderID, true)
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to a Scalar.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (s *HashToCurveSuite) HashToScalar(input, dst []byte) *Scalar {
+	checkDST(dst)
+	return newScalar(s.g, s.impl.HashToScalarWith(s.expander, input, dst))
+}
+
+// HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (s *HashToCurveSuite) HashToGroup(input, dst []byte) *Element {
+	checkDST(dst)
+	return newPoint(s.g, s.impl.HashToGroupWith(s.expander, input, dst))
+}
+
+// EncodeToGroup returns a non-uniform mapping of the arbitrary input to an Element in the Group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (s *HashToCurveSuite) EncodeToGroup(input, dst []byte) *Element {
+	checkDST(dst)
+	return newPoint(s.g, s.impl.EncodeToGroupWith(s.expander, input, dst))
+}
+
+// Register registers the group with the hashing of the suite, under the suite identifier, with Register. The returned
+// Group can then be used wherever the groups of this package are, e.g. to build oprf and opaque suites hashing with
+// SHAKE256.
+func (s *HashToCurveSuite) Register() (Group, error) {
+	sg := &suiteGroup{Group: s.g.get(), suite: s}
+	if m, ok := sg.Group.(internal.Mapper); ok {
+		return Register(&mapperSuiteGroup{suiteGroup: sg, Mapper: m})
+	}
+
+	return Register(sg)
+}
+
+// suiteGroup is the group implementation of a HashToCurveSuite. It is an internal.Expandable as the suite group is.
+type suiteGroup struct {
+	internal.Group
+	suite *HashToCurveSuite
+}
+
+// mapperSuiteGroup is a suiteGroup over an internal.Mapper, whose map does not depend on the expander.
+type mapperSuiteGroup struct {
+	*suiteGroup
+	internal.Mapper
+}
+
+func (sg *suiteGroup) HashToScalar(input, dst []byte) internal.Scalar {
+	return sg.suite.impl.HashToScalarWith(sg.suite.expander, input, dst)
+}
+
+func (sg *suiteGroup) HashToGroup(input, dst []byte) internal.Element {
+	return sg.suite.impl.HashToGroupWith(sg.suite.expander, input, dst)
+}
+
+func (sg *suiteGroup) EncodeToGroup(input, dst []byte) internal.Element {
+	return sg.suite.impl.EncodeToGroupWith(sg.suite.expander, input, dst)
+}
+
+func (sg *suiteGroup) Ciphersuite() string {
+	return sg.suite.String()
+}
+
+func (sg *suiteGroup) SuiteID(expanderID string, nonUniform bool) string {
+	return sg.suite.impl.SuiteID(expanderID, nonUniform)
+}
+
+func (sg *suiteGroup) HashToScalarWith(expander msgexpand.MessageExpand, input, dst []byte) internal.Scalar {
+	return sg.suite.impl.HashToScalarWith(expander, input, dst)
+}
+
+func (sg *suiteGroup) HashToGroupWith(expander msgexpand.MessageExpand, input, dst []byte) internal.Element {
+	return sg.suite.impl.HashToGroupWith(expander, input, dst)
+}
+
+func (sg *suiteGroup) EncodeToGroupWith(expander msgexpand.MessageExpand, input, dst []byte) internal.Element {
+	return sg.suite.impl.EncodeToGroupWith(expander, input, dst)
+}
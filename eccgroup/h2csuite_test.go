@@ -0,0 +1,111 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eccgroup
+
+import (
+	"testing"
+
+	"github.com/cymony/cryptomony/hash"
+	"github.com/cymony/cryptomony/internal/test"
+	"github.com/cymony/cryptomony/msgexpand"
+	"github.com/cymony/cryptomony/xof"
+)
+
+func TestHashToCurveSuite(t *testing.T) {
+	input, dst := []byte("input"), []byte("hash-to-curve-suite-test")
+
+	// the expanders of the ciphersuites give the same results as the groups
+	for _, tc := range []struct {
+		g Group
+		h hash.Hashing
+	}{
+		{Ristretto255Sha512, hash.SHA512},
+		{P256Sha256, hash.SHA256},
+		{P384Sha384, hash.SHA384},
+		{P521Sha512, hash.SHA512},
+		{Secp256k1Sha256, hash.SHA256},
+	} {
+		s, err := NewHashToCurveSuite(tc.g, msgexpand.NewMessageExpandXMD(tc.h))
+		test.CheckNoErr(t, err, "suite creation failed")
+		test.CheckOk(t, s.String() == tc.g.String(), "wrong suite identifier "+s.String())
+		test.CheckOk(t, s.HashToGroup(input, dst).Equal(tc.g.HashToGroup(input, dst)) == 1, "HashToGroup mismatch")
+		test.CheckOk(t, s.EncodeToGroup(input, dst).Equal(tc.g.EncodeToGroup(input, dst)) == 1, "EncodeToGroup mismatch")
+		test.CheckOk(t, s.HashToScalar(input, dst).Equal(tc.g.HashToScalar(input, dst)) == 1, "HashToScalar mismatch")
+	}
+
+	shake, err := NewHashToCurveSuite(P256Sha256, msgexpand.NewMessageExpandXOF(xof.SHAKE256, 128))
+	test.CheckNoErr(t, err, "suite creation failed")
+	test.CheckOk(t, shake.String() == "P256_XOF:SHAKE-256_SSWU_RO_", "wrong suite identifier "+shake.String())
+	test.CheckOk(t, shake.EncodeToCurveID() == "P256_XOF:SHAKE-256_SSWU_NU_", "wrong encode-to-curve identifier")
+	test.CheckOk(t, shake.Group() == P256Sha256, "wrong group")
+	test.CheckOk(t, shake.HashToGroup(input, dst).Equal(P256Sha256.HashToGroup(input, dst)) == 0,
+		"different expanders should give different elements")
+
+	blake, err := NewHashToCurveSuite(Ristretto255Sha512, msgexpand.NewMessageExpandXOF(xof.BLAKE2XB, 128))
+	test.CheckNoErr(t, err, "suite creation failed")
+	test.CheckOk(t, blake.String() == "ristretto255_XOF:BLAKE2XB_R255MAP_RO_", "wrong suite identifier "+blake.String())
+
+	g, err := shake.Register()
+	test.CheckNoErr(t, err, "registration failed")
+	test.CheckOk(t, g.String() == shake.String(), "registered group should have the suite identifier")
+	test.CheckOk(t, g.HashToGroup(input, dst).Equal(shake.HashToGroup(input, dst)) == 1, "registered HashToGroup mismatch")
+	test.CheckOk(t, g.HashToScalar(input, dst).Equal(shake.HashToScalar(input, dst)) == 1, "registered HashToScalar mismatch")
+	test.CheckOk(t, g.Base().Equal(P256Sha256.Base()) == 1, "registered group should share the base point")
+
+	// the registered group keeps the map to elements, and custom expanders, of its group
+	test.CheckOk(t, g.UniformLength() == P256Sha256.UniformLength(), "registered group should keep its map")
+
+	uniform := make([]byte, g.UniformLength())
+	mapped, err := g.MapToElement(uniform)
+	test.CheckNoErr(t, err, "map to element failed")
+
+	want, err := P256Sha256.MapToElement(uniform)
+	test.CheckNoErr(t, err, "map to element failed")
+	test.CheckOk(t, mapped.Equal(want) == 1, "registered MapToElement mismatch")
+
+	xmd, err := NewHashToCurveSuite(g, msgexpand.NewMessageExpandXMD(hash.SHA256))
+	test.CheckNoErr(t, err, "suite over the registered group failed")
+	test.CheckOk(t, xmd.String() == P256Sha256.String(), "wrong suite identifier "+xmd.String())
+	test.CheckOk(t, xmd.HashToGroup(input, dst).Equal(P256Sha256.HashToGroup(input, dst)) == 1, "HashToGroup mismatch")
+
+	_, err = shake.Register()
+	test.CheckIsErr(t, err, "duplicate registration should be rejected")
+
+	_, err = NewHashToCurveSuite(Decaf448Shake256, msgexpand.NewMessageExpandXOF(xof.SHAKE256, 224))
+	test.CheckIsErr(t, err, "decaf448 does not support custom expanders")
+
+	_, err = NewHashToCurveSuite(P256Sha256, nil)
+	test.CheckIsErr(t, err, "nil expander should be rejected")
+
+	_, err = NewHashToCurveSuite(maxID, msgexpand.NewMessageExpandXMD(hash.SHA256))
+	test.CheckIsErr(t, err, "unavailable group should be rejected")
+
+	// an expander implemented outside msgexpand needs an identifier
+	_, err = NewHashToCurveSuite(P256Sha256, unnamedExpander{msgexpand.NewMessageExpandXMD(hash.SHA256)})
+	test.CheckIsErr(t, err, "expander without identifier should be rejected")
+
+	named, err := NewHashToCurveSuite(P256Sha256,
+		namedExpander{unnamedExpander{msgexpand.NewMessageExpandXMD(hash.SHA256)}})
+	test.CheckNoErr(t, err, "suite creation failed")
+	test.CheckOk(t, named.String() == "P256_XMD:custom_SSWU_RO_", "wrong suite identifier "+named.String())
+	test.CheckOk(t, named.HashToGroup(input, dst).Equal(P256Sha256.HashToGroup(input, dst)) == 1, "HashToGroup mismatch")
+}
+
+// unnamedExpander is a message expander implemented outside msgexpand, with only the Expand method.
+type unnamedExpander struct {
+	e msgexpand.MessageExpand
+}
+
+func (e unnamedExpander) Expand(in, dst []byte, lenInBytes int) ([]byte, error) {
+	return e.e.Expand(in, dst, lenInBytes)
+}
+
+type namedExpander struct {
+	unnamedExpander
+}
+
+func (namedExpander) String() string {
+	return "XMD:custom"
+}
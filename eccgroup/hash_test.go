@@ -9,13 +9,14 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/cymony/cryptomony/internal/test"
 )
 
 func TestHashToElement(t *testing.T) {
-	fileNames, err := filepath.Glob("./testdata/P*.json")
+	fileNames, err := filepath.Glob("./testdata/*.json")
 	test.CheckNoErr(t, err, "filepath.Glob error")
 
 	for _, fileName := range fileNames {
@@ -40,6 +41,8 @@ func testHashing(t *testing.T, vs *vectorSuite) {
 
 	var G Group
 
+	encode := point.toBytes
+
 	switch vs.Ciphersuite {
 	case P256Sha256.String(), "P256_XMD:SHA-256_SSWU_NU_":
 		G = P256Sha256
@@ -47,6 +50,20 @@ func testHashing(t *testing.T, vs *vectorSuite) {
 		G = P384Sha384
 	case P521Sha512.String(), "P521_XMD:SHA-512_SSWU_NU_":
 		G = P521Sha512
+	case Secp256k1Sha256.String(), "secp256k1_XMD:SHA-256_SSWU_NU_":
+		G = Secp256k1Sha256
+	case Edwards25519Sha512.String(), "edwards25519_XMD:SHA-512_ELL2_NU_":
+		G = Edwards25519Sha512
+		encode = point.toEdwardsBytes
+	case Curve25519Sha512.String(), "curve25519_XMD:SHA-512_ELL2_NU_":
+		G = Curve25519Sha512
+		encode = point.toMontgomeryBytes
+	case BLS12381G1Sha256.String(), "BLS12381G1_XMD:SHA-256_SSWU_NU_":
+		G = BLS12381G1Sha256
+		encode = point.toBLSBytes
+	case BLS12381G2Sha256.String(), "BLS12381G2_XMD:SHA-256_SSWU_NU_":
+		G = BLS12381G2Sha256
+		encode = point.toBLSBytes
 	default:
 		t.Fatal("non supported suite")
 	}
@@ -62,7 +79,7 @@ func testHashing(t *testing.T, vs *vectorSuite) {
 	for i, v := range vs.Vectors {
 		got := hashFunc([]byte(v.Msg), []byte(vs.Dst))
 
-		err := want.UnmarshalBinary(v.P.toBytes())
+		err := want.UnmarshalBinary(encode(v.P))
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -112,6 +129,58 @@ func (p point) toBytes() []byte {
 	return append(append([]byte{0x04}, x...), y...)
 }
 
+// toEdwardsBytes returns the little-endian y-coordinate, with the sign of the x-coordinate in the most significant bit.
+func (p point) toEdwardsBytes() []byte {
+	return encodeLittleEndianWithSign(p.Y, p.X)
+}
+
+// toMontgomeryBytes returns the little-endian u-coordinate, with the sign of the v-coordinate in the most significant bit.
+func (p point) toMontgomeryBytes() []byte {
+	return encodeLittleEndianWithSign(p.X, p.Y)
+}
+
+// toBLSBytes returns the uncompressed zkcrypto encoding of the point, where the coordinates in Fp2 are
+// written with the most significant component first.
+func (p point) toBLSBytes() []byte {
+	var out []byte
+
+	for _, coordinate := range []string{p.X, p.Y} {
+		components := strings.Split(coordinate, ",")
+
+		for i := len(components) - 1; i >= 0; i-- {
+			c, err := hex.DecodeString(components[i][2:])
+			if err != nil {
+				panic(err)
+			}
+
+			out = append(out, c...)
+		}
+	}
+
+	return out
+}
+
+func encodeLittleEndianWithSign(coordinate, sign string) []byte {
+	c, err := hex.DecodeString(coordinate[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	s, err := hex.DecodeString(sign[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	out := make([]byte, len(c))
+	for i := range c {
+		out[i] = c[len(c)-1-i]
+	}
+
+	out[len(out)-1] |= (s[len(s)-1] & 1) << 7
+
+	return out
+}
+
 type vector struct {
 	P   point    `json:"P"`
 	Q0  point    `json:"Q0,omitempty"`
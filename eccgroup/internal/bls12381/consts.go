@@ -0,0 +1,23 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bls12381
+
+const (
+	// H2CG1 represents the hash-to-curve string identifier for BLS12-381 G1.
+	H2CG1 = "BLS12381G1_XMD:SHA-256_SSWU_RO_"
+
+	// E2CG1 represents the encode-to-curve string identifier for BLS12-381 G1.
+	E2CG1 = "BLS12381G1_XMD:SHA-256_SSWU_NU_"
+
+	// H2CG2 represents the hash-to-curve string identifier for BLS12-381 G2.
+	H2CG2 = "BLS12381G2_XMD:SHA-256_SSWU_RO_"
+
+	// E2CG2 represents the encode-to-curve string identifier for BLS12-381 G2.
+	E2CG2 = "BLS12381G2_XMD:SHA-256_SSWU_NU_"
+
+	scalarSize        = 32
+	secLength         = 48
+	recommendedDSTLen = 16
+)
@@ -0,0 +1,188 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bls12381
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/cymony/cryptomony/eccgroup/internal"
+)
+
+// Element represents a point of the G1 or G2 subgroup of BLS12-381.
+type Element[T any, P blsPoint[T]] struct {
+	p T
+	g *Group[T, P]
+}
+
+func checkElement[T any, P blsPoint[T]](element internal.Element) *Element[T, P] {
+	if element == nil {
+		panic(internal.ErrParamNilPoint)
+	}
+
+	ec, ok := element.(*Element[T, P])
+	if !ok {
+		panic(internal.ErrCastElement)
+	}
+
+	return ec
+}
+
+// Base sets the element to the group's base point a.k.a. canonical generator.
+func (e *Element[T, P]) Base() internal.Element {
+	e.p = *e.g.generator()
+	return e
+}
+
+// Identity sets the element to the point at infinity of the Group's underlying curve.
+func (e *Element[T, P]) Identity() internal.Element {
+	P(&e.p).SetIdentity()
+	return e
+}
+
+// Add sets the receiver to the sum of the input and the receiver, and returns the receiver.
+func (e *Element[T, P]) Add(ee internal.Element) internal.Element {
+	if ee == nil {
+		return e
+	}
+
+	P(&e.p).Add(&e.p, &checkElement[T, P](ee).p)
+
+	return e
+}
+
+// Double sets the receiver to its double, and returns it.
+func (e *Element[T, P]) Double() internal.Element {
+	P(&e.p).Double()
+	return e
+}
+
+// Negate sets the receiver to its negation, and returns it.
+func (e *Element[T, P]) Negate() internal.Element {
+	P(&e.p).Neg()
+	return e
+}
+
+// Subtract subtracts the input from the receiver, and returns the receiver.
+func (e *Element[T, P]) Subtract(ee internal.Element) internal.Element {
+	if ee == nil {
+		return e
+	}
+
+	neg := checkElement[T, P](ee).p
+	P(&neg).Neg()
+	P(&e.p).Add(&e.p, &neg)
+
+	return e
+}
+
+// Multiply sets the receiver to the scalar multiplication of the receiver with the given Scalar, and returns it.
+// If s parameter is nil, then the receiver is set to the identity element.
+func (e *Element[T, P]) Multiply(s internal.Scalar) internal.Element {
+	if s == nil {
+		e.Identity()
+		return e
+	}
+
+	P(&e.p).ScalarMult(&cvtScalar(s).s, &e.p)
+
+	return e
+}
+
+// Equal returns 1 if the elements are equivalent, and 0 otherwise.
+func (e *Element[T, P]) Equal(ee internal.Element) int {
+	if ee == nil {
+		return 0
+	}
+
+	ec := checkElement[T, P](ee)
+
+	if P(&e.p).IsEqual(&ec.p) {
+		return 1
+	}
+
+	return 0
+}
+
+// IsIdentity returns whether the Element is the point at infinity of the Group's underlying curve.
+func (e *Element[T, P]) IsIdentity() bool {
+	return P(&e.p).IsIdentity()
+}
+
+// Set sets the receiver to ee if not nil; else the receiver is set to the identity element; returns the receiver.
+func (e *Element[T, P]) Set(ee internal.Element) internal.Element {
+	if ee == nil {
+		e.Identity()
+		return e
+	}
+
+	e.p = checkElement[T, P](ee).p
+
+	return e
+}
+
+// Copy returns a copy of the receiver.
+func (e *Element[T, P]) Copy() internal.Element {
+	return &Element[T, P]{p: e.p, g: e.g}
+}
+
+// Encode returns the compressed byte encoding of the element, in the format of the zkcrypto/pairing library.
+func (e *Element[T, P]) Encode() []byte {
+	return P(&e.p).BytesCompressed()
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+// Both the compressed and the uncompressed encodings are accepted, and the decoded point must be in the subgroup.
+func (e *Element[T, P]) Decode(data []byte) error {
+	if len(data) != e.g.compressedSize && len(data) != 2*e.g.compressedSize {
+		return ErrInvalidEncoding
+	}
+
+	var p T
+	if err := P(&p).SetBytes(data); err != nil {
+		return ErrInvalidEncoding
+	}
+
+	want := P(&p).Bytes()
+	if len(data) == e.g.compressedSize {
+		want = P(&p).BytesCompressed()
+	}
+
+	// Reject the encodings with unused flags set.
+	if !bytes.Equal(want, data) {
+		return ErrInvalidEncoding
+	}
+
+	e.p = p
+
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (e *Element[T, P]) MarshalBinary() ([]byte, error) {
+	return e.Encode(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (e *Element[T, P]) UnmarshalBinary(data []byte) error {
+	return e.Decode(data)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (e *Element[T, P]) MarshalText() (text []byte, err error) {
+	b := e.Encode()
+	return []byte(base64.StdEncoding.EncodeToString(b)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (e *Element[T, P]) UnmarshalText(text []byte) error {
+	sb, err := base64.StdEncoding.DecodeString(string(text))
+	if err != nil {
+		return fmt.Errorf("bls12381: %w", err)
+	}
+
+	return e.Decode(sb)
+}
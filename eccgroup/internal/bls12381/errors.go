@@ -0,0 +1,21 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bls12381
+
+import "errors"
+
+var (
+	// ErrInvalidEncoding returns when passed unsuitable data for element unmarshaling
+	ErrInvalidEncoding = errors.New("bls12381: invalid element encoding")
+
+	// ErrInvalidScalar returns when passed unsuitable data for scalar unmarshaling
+	ErrInvalidScalar = errors.New("bls12381: invalid scalar encoding")
+
+	// ErrNotInGT returns when the decoded value is not an element of the target group
+	ErrNotInGT = errors.New("bls12381: value is not an element of the target group")
+
+	// ErrPairingInput returns when the pairing inputs are not a G1 element and a G2 element
+	ErrPairingInput = errors.New("bls12381: pairing requires a G1 element and a G2 element")
+)
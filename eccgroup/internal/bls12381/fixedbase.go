@@ -0,0 +1,25 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bls12381
+
+import (
+	"github.com/cymony/cryptomony/eccgroup/internal"
+)
+
+// FixedBase holds an element of G1 or G2. The backend does not expose a constant-time point selection, so there is
+// no precomputed table and Multiply uses the regular constant-time scalar multiplication.
+type FixedBase[T any, P blsPoint[T]] struct {
+	e *Element[T, P]
+}
+
+// NewFixedBase returns a FixedBase of the element.
+func (g *Group[T, P]) NewFixedBase(e internal.Element) internal.FixedBase {
+	return &FixedBase[T, P]{e: checkElement[T, P](e.Copy())}
+}
+
+// Multiply returns the scalar multiplication of the element with the given Scalar.
+func (f *FixedBase[T, P]) Multiply(s internal.Scalar) internal.Element {
+	return f.e.Copy().Multiply(s)
+}
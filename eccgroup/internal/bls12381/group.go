@@ -0,0 +1,178 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package bls12381 implements the G1 and G2 groups of the BLS12-381 pairing-friendly curve with hash-to-curve
+// operations, and the pairing into the target group GT, with backend "github.com/cloudflare/circl/ecc/bls12381"
+package bls12381
+
+import (
+	bls "github.com/cloudflare/circl/ecc/bls12381"
+
+	"github.com/cymony/cryptomony/eccgroup/internal"
+	"github.com/cymony/cryptomony/hash"
+	"github.com/cymony/cryptomony/msgexpand"
+)
+
+var (
+	g1 = Group[bls.G1, *bls.G1]{
+		h2c:            H2CG1,
+		e2c:            E2CG1,
+		generator:      bls.G1Generator,
+		compressedSize: bls.G1SizeCompressed,
+	}
+	g2 = Group[bls.G2, *bls.G2]{
+		h2c:            H2CG2,
+		e2c:            E2CG2,
+		generator:      bls.G2Generator,
+		compressedSize: bls.G2SizeCompressed,
+	}
+)
+
+// G1 returns the single instantiation of the BLS12-381 G1 Group.
+func G1() internal.Group {
+	return &g1
+}
+
+// G2 returns the single instantiation of the BLS12-381 G2 Group.
+func G2() internal.Group {
+	return &g2
+}
+
+// Group represents the G1 or G2 subgroup of the BLS12-381 curve.
+// It exposes a prime-order group API with hash-to-curve operations.
+type Group[T any, P blsPoint[T]] struct {
+	h2c            string
+	e2c            string
+	generator      func() *T
+	compressedSize int
+}
+
+// NewScalar returns a new, empty, scalar.
+func (g *Group[T, P]) NewScalar() internal.Scalar {
+	return newScalar()
+}
+
+// NewElement returns the identity element (point at infinity).
+func (g *Group[T, P]) NewElement() internal.Element {
+	return g.newElement()
+}
+
+func (g *Group[T, P]) newElement() *Element[T, P] {
+	e := &Element[T, P]{g: g}
+	P(&e.p).SetIdentity()
+
+	return e
+}
+
+// RandomScalar returns randomly generated scalar.
+func (g *Group[T, P]) RandomScalar() internal.Scalar {
+	return g.NewScalar().Random()
+}
+
+// RandomElement returns randomly generated element.
+func (g *Group[T, P]) RandomElement() internal.Element {
+	return g.NewElement().Base().Multiply(g.RandomScalar())
+}
+
+// Base returns the group's base point a.k.a. canonical generator.
+func (g *Group[T, P]) Base() internal.Element {
+	return g.NewElement().Base()
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to a Scalar.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group[T, P]) HashToScalar(input, dst []byte) internal.Scalar {
+	uniform, err := msgexpand.NewMessageExpandXMD(hash.SHA256).Expand(input, dst, secLength)
+	if err != nil {
+		panic(err)
+	}
+
+	s := newScalar()
+	s.s.SetBytes(uniform)
+
+	return s
+}
+
+// HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group[T, P]) HashToGroup(input, dst []byte) internal.Element {
+	checkDST(dst)
+
+	e := g.newElement()
+	P(&e.p).Hash(input, dst)
+
+	return e
+}
+
+// EncodeToGroup returns a non-uniform mapping of the arbitrary input to an Element in the Group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group[T, P]) EncodeToGroup(input, dst []byte) internal.Element {
+	checkDST(dst)
+
+	e := g.newElement()
+	P(&e.p).Encode(input, dst)
+
+	return e
+}
+
+// MultiScalarMult returns the sum of the element-wise products of scalars and elements.
+// Small batches use Straus' method, large batches use Pippenger's method.
+// It runs in variable time and must only be used with public scalars.
+func (g *Group[T, P]) MultiScalarMult(scalars []internal.Scalar, elements []internal.Element) internal.Element {
+	if len(scalars) != len(elements) {
+		panic(internal.ErrLengthMismatch)
+	}
+
+	points := make([]*msmPoint[T, P], len(elements))
+	for i := range elements {
+		points[i] = &msmPoint[T, P]{p: checkElement[T, P](elements[i]).p}
+	}
+
+	ss := make([][]byte, len(scalars))
+	for i := range scalars {
+		// The scalars are encoded in big-endian, and MultiScalarMult expects little-endian.
+		be := cvtScalar(scalars[i]).Encode()
+		le := make([]byte, len(be))
+
+		for j := range be {
+			le[j] = be[len(be)-1-j]
+		}
+
+		ss[i] = le
+	}
+
+	out := newMSMPoint[T, P]()
+	internal.MultiScalarMult(out, newMSMPoint[T, P], ss, points)
+
+	e := g.newElement()
+	e.p = out.p
+
+	return e
+}
+
+// Ciphersuite returns the hash-to-curve ciphersuite identifier.
+func (g *Group[T, P]) Ciphersuite() string {
+	return g.h2c
+}
+
+// ScalarLength returns the byte size of an encoded scalar.
+func (g *Group[T, P]) ScalarLength() uint {
+	return scalarSize
+}
+
+// ElementLength returns the byte size of an encoded element.
+func (g *Group[T, P]) ElementLength() uint {
+	return uint(g.compressedSize)
+}
+
+// checkDST applies the domain separation requirements of msgexpand, since hashing is done by the backend.
+func checkDST(dst []byte) {
+	if len(dst) == 0 {
+		panic(msgexpand.ErrZeroLengthDST)
+	}
+
+	if len(dst) < recommendedDSTLen {
+		panic(msgexpand.ErrRecommendedDSTLen)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bls12381
+
+import (
+	"fmt"
+	"testing"
+
+	bls "github.com/cloudflare/circl/ecc/bls12381"
+
+	"github.com/cymony/cryptomony/eccgroup/internal"
+	"github.com/cymony/cryptomony/internal/test"
+	"github.com/cymony/cryptomony/utils"
+)
+
+func TestBLS12381(t *testing.T) {
+	for _, g := range []internal.Group{G1(), G2()} {
+		g := g
+
+		t.Run(g.Ciphersuite()+"/Group/HashToScalarAndGroup", func(tt *testing.T) { testHashToXGroup(tt, g) })
+		t.Run(g.Ciphersuite()+"/Group/MultiScalarMult", func(tt *testing.T) { testMultiScalarMult(tt, g) })
+		t.Run(g.Ciphersuite()+"/Element/Decode", func(tt *testing.T) { testDecode(tt, g) })
+	}
+
+	t.Run("Scalar/Decode", func(tt *testing.T) { testScalarDecode(tt) })
+}
+
+func testHashToXGroup(t *testing.T, g internal.Group) {
+	t.Helper()
+
+	input := utils.RandomBytes(16)
+
+	err := test.CheckPanic(func() { g.HashToScalar(input, nil) })
+	test.CheckNoErr(t, err, "panic expected")
+
+	err = test.CheckPanic(func() { g.HashToGroup(input, nil) })
+	test.CheckNoErr(t, err, "panic expected")
+
+	err = test.CheckPanic(func() { g.EncodeToGroup(input, []byte("shorter")) })
+	test.CheckNoErr(t, err, "panic expected")
+
+	dst := []byte("This is greater than recommended length")
+
+	sc := g.HashToScalar(input, dst)
+	if len(sc.Encode()) != int(g.ScalarLength()) {
+		test.Report(t, len(sc.Encode()), g.ScalarLength())
+	}
+
+	el := g.HashToGroup(input, dst)
+	if len(el.Encode()) != int(g.ElementLength()) {
+		test.Report(t, len(el.Encode()), g.ElementLength())
+	}
+
+	if el.Equal(g.EncodeToGroup(input, dst)) == 1 {
+		test.Report(t, el, g.EncodeToGroup(input, dst), "hash and encode to group should differ")
+	}
+}
+
+func testMultiScalarMult(t *testing.T, g internal.Group) {
+	t.Helper()
+
+	for _, n := range []int{0, 1, 5, 70} {
+		scalars := make([]internal.Scalar, n)
+		elements := make([]internal.Element, n)
+		want := g.NewElement()
+
+		for i := 0; i < n; i++ {
+			scalars[i] = g.RandomScalar()
+			elements[i] = g.RandomElement()
+			want.Add(elements[i].Copy().Multiply(scalars[i]))
+		}
+
+		got := g.MultiScalarMult(scalars, elements)
+		if got.Equal(want) != 1 {
+			test.Report(t, got, want, "multi scalar multiplication mismatch")
+		}
+	}
+
+	err := test.CheckPanic(func() {
+		g.MultiScalarMult([]internal.Scalar{g.RandomScalar()}, nil)
+	})
+	test.CheckNoErr(t, err, "panic expected")
+}
+
+func testDecode(t *testing.T, g internal.Group) {
+	t.Helper()
+
+	e := g.RandomElement()
+	compressed := e.Encode()
+	uncompressed := uncompressedBytes(e)
+
+	for _, want := range []internal.Element{e, g.NewElement()} {
+		for _, enc := range [][]byte{want.Encode(), uncompressedBytes(want)} {
+			d := g.NewElement()
+
+			err := d.Decode(enc)
+			test.CheckNoErr(t, err, "decode err")
+
+			if d.Equal(want) != 1 {
+				test.Report(t, d, want, "decode<>encode roundtrip failed")
+			}
+		}
+	}
+
+	// (0, 2) is on the curve of G1, but not in the subgroup
+	if g.ElementLength() == bls.G1SizeCompressed {
+		notInSubgroup := make([]byte, bls.G1SizeCompressed)
+		notInSubgroup[0] = 0x80
+
+		err := g.NewElement().Decode(notInSubgroup)
+		test.CheckIsErr(t, err, "expected error for point out of the subgroup")
+	}
+
+	for i, enc := range [][]byte{
+		compressed[1:],
+		append(append([]byte{}, compressed...), 0),
+		// the sort flag must not be set in the uncompressed form
+		append([]byte{uncompressed[0] | 0x20}, uncompressed[1:]...),
+		// the infinity flag must come with zero coordinates
+		append([]byte{compressed[0] | 0x40}, compressed[1:]...),
+	} {
+		err := g.NewElement().Decode(enc)
+		test.CheckIsErr(t, err, fmt.Sprintf("#%d: expected decode error", i))
+	}
+}
+
+func uncompressedBytes(e internal.Element) []byte {
+	switch el := e.(type) {
+	case *Element[bls.G1, *bls.G1]:
+		return el.p.Bytes()
+	case *Element[bls.G2, *bls.G2]:
+		return el.p.Bytes()
+	default:
+		panic(internal.ErrCastElement)
+	}
+}
+
+func testScalarDecode(t *testing.T) {
+	t.Helper()
+
+	s := newScalar()
+
+	err := s.Decode(newScalar().Random().Encode())
+	test.CheckNoErr(t, err, "decode err")
+
+	// the group order is not a canonical scalar
+	order := []byte{
+		0x73, 0xed, 0xa7, 0x53, 0x29, 0x9d, 0x7d, 0x48, 0x33, 0x39, 0xd8, 0x08, 0x09, 0xa1, 0xd8, 0x05,
+		0x53, 0xbd, 0xa4, 0x02, 0xff, 0xfe, 0x5b, 0xfe, 0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x01,
+	}
+
+	err = s.Decode(order)
+	test.CheckIsErr(t, err, "expected error for non canonical scalar")
+
+	err = s.Decode(order[1:])
+	test.CheckIsErr(t, err, "expected error for short scalar")
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bls12381
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	bls "github.com/cloudflare/circl/ecc/bls12381"
+
+	"github.com/cymony/cryptomony/eccgroup/internal"
+)
+
+// GT represents an element of the target group of the BLS12-381 pairing, written multiplicatively.
+type GT struct {
+	gt bls.Gt
+}
+
+// NewGT returns the identity element of GT.
+func NewGT() *GT {
+	t := &GT{}
+	t.gt.SetIdentity()
+
+	return t
+}
+
+// Pair returns the optimal ate pairing of the G1 element p and the G2 element q.
+func Pair(p, q internal.Element) (*GT, error) {
+	p1, ok := p.(*Element[bls.G1, *bls.G1])
+	if !ok {
+		return nil, ErrPairingInput
+	}
+
+	q2, ok := q.(*Element[bls.G2, *bls.G2])
+	if !ok {
+		return nil, ErrPairingInput
+	}
+
+	if p1.IsIdentity() || q2.IsIdentity() {
+		return NewGT(), nil
+	}
+
+	// The backend normalizes the G1 point in place.
+	pp := p1.p
+
+	return &GT{gt: *bls.Pair(&pp, &q2.p)}, nil
+}
+
+// Identity sets the receiver to the identity element, and returns it.
+func (t *GT) Identity() *GT {
+	t.gt.SetIdentity()
+	return t
+}
+
+// Multiply sets the receiver to the product of the receiver and the input, and returns the receiver.
+func (t *GT) Multiply(u *GT) *GT {
+	t.gt.Mul(&t.gt, &u.gt)
+	return t
+}
+
+// Invert sets the receiver to its inverse, and returns it.
+func (t *GT) Invert() *GT {
+	t.gt.Inv(&t.gt)
+	return t
+}
+
+// Exponentiate sets the receiver to its s-th power, and returns it.
+func (t *GT) Exponentiate(s internal.Scalar) *GT {
+	t.gt.Exp(&t.gt, &cvtScalar(s).s)
+	return t
+}
+
+// Equal returns 1 if the elements are equal, and 0 otherwise.
+func (t *GT) Equal(u *GT) int {
+	if t.gt.IsEqual(&u.gt) {
+		return 1
+	}
+
+	return 0
+}
+
+// IsIdentity returns whether the receiver is the identity element.
+func (t *GT) IsIdentity() bool {
+	return t.gt.IsIdentity()
+}
+
+// Copy returns a copy of the receiver.
+func (t *GT) Copy() *GT {
+	return &GT{gt: t.gt}
+}
+
+// Encode returns the byte encoding of the element.
+func (t *GT) Encode() []byte {
+	b, err := t.gt.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+// The decoded value must be an element of the order r subgroup of the multiplicative group of Fp12.
+func (t *GT) Decode(data []byte) error {
+	var u bls.Gt
+	if err := u.UnmarshalBinary(data); err != nil {
+		return ErrInvalidEncoding
+	}
+
+	// u^(r - 1) * u is the identity if and only if u is in the subgroup of order r.
+	minusOne := newScalar()
+	minusOne.s.SetOne()
+	minusOne.s.Neg()
+
+	var check bls.Gt
+
+	check.Exp(&u, &minusOne.s)
+	check.Mul(&check, &u)
+
+	if !check.IsIdentity() {
+		return ErrNotInGT
+	}
+
+	t.gt = u
+
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (t *GT) MarshalBinary() ([]byte, error) {
+	return t.Encode(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (t *GT) UnmarshalBinary(data []byte) error {
+	return t.Decode(data)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t *GT) MarshalText() (text []byte, err error) {
+	b := t.Encode()
+	return []byte(base64.StdEncoding.EncodeToString(b)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (t *GT) UnmarshalText(text []byte) error {
+	sb, err := base64.StdEncoding.DecodeString(string(text))
+	if err != nil {
+		return fmt.Errorf("bls12381: %w", err)
+	}
+
+	return t.Decode(sb)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bls12381
+
+import (
+	bls "github.com/cloudflare/circl/ecc/bls12381"
+)
+
+// blsPoint is the generic constraint satisfied by the G1 and G2 points of "github.com/cloudflare/circl/ecc/bls12381".
+type blsPoint[T any] interface {
+	*T
+	// Add sets the receiver to P + Q. The points may overlap.
+	Add(P, Q *T)
+	// Double sets the receiver to its double.
+	Double()
+	// Neg sets the receiver to its negation.
+	Neg()
+	// SetIdentity sets the receiver to the point at infinity.
+	SetIdentity()
+	// ScalarMult sets the receiver to k * P, in constant time.
+	ScalarMult(k *bls.Scalar, P *T)
+	// IsEqual returns whether the receiver and p are equivalent.
+	IsEqual(p *T) bool
+	// IsIdentity returns whether the receiver is the point at infinity.
+	IsIdentity() bool
+	// Bytes returns the uncompressed encoding of the receiver.
+	Bytes() []byte
+	// BytesCompressed returns the compressed encoding of the receiver.
+	BytesCompressed() []byte
+	// SetBytes sets the receiver to the compressed or uncompressed encoding b, and fails if it is not in the subgroup.
+	SetBytes(b []byte) error
+	// Hash sets the receiver to the hash_to_curve mapping of the input.
+	Hash(input, dst []byte)
+	// Encode sets the receiver to the encode_to_curve mapping of the input.
+	Encode(input, dst []byte)
+}
+
+// msmPoint adapts blsPoint to the internal.MSMPoint constraint.
+type msmPoint[T any, P blsPoint[T]] struct {
+	p T
+}
+
+func newMSMPoint[T any, P blsPoint[T]]() *msmPoint[T, P] {
+	m := &msmPoint[T, P]{}
+	P(&m.p).SetIdentity()
+
+	return m
+}
+
+// Add sets the receiver to p1 + p2, and returns it.
+func (m *msmPoint[T, P]) Add(p1, p2 *msmPoint[T, P]) *msmPoint[T, P] {
+	P(&m.p).Add(&p1.p, &p2.p)
+	return m
+}
+
+// Set sets the receiver to p, and returns it.
+func (m *msmPoint[T, P]) Set(p *msmPoint[T, P]) *msmPoint[T, P] {
+	m.p = p.p
+	return m
+}
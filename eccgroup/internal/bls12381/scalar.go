@@ -0,0 +1,200 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bls12381
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	bls "github.com/cloudflare/circl/ecc/bls12381"
+
+	"github.com/cymony/cryptomony/eccgroup/internal"
+	"github.com/cymony/cryptomony/utils"
+)
+
+// Scalar represents an element of the scalar field shared by G1, G2 and GT.
+// It is encoded as a 32 bytes big-endian integer.
+type Scalar struct {
+	s bls.Scalar
+}
+
+func cvtScalar(s internal.Scalar) *Scalar {
+	sc, ok := s.(*Scalar)
+	if !ok {
+		panic(internal.ErrCastScalar)
+	}
+
+	return sc
+}
+
+func newScalar() *Scalar {
+	return &Scalar{}
+}
+
+// Zero sets the scalar to 0, and returns it.
+func (s *Scalar) Zero() internal.Scalar {
+	s.s = bls.Scalar{}
+	return s
+}
+
+// One sets the scalar to 1, and returns it.
+func (s *Scalar) One() internal.Scalar {
+	s.s.SetOne()
+	return s
+}
+
+// Random sets the current scalar to a new random scalar and returns it.
+// The random source is crypto/rand, and this functions is guaranteed to return a non-zero scalar.
+func (s *Scalar) Random() internal.Scalar {
+	for {
+		// 48 bytes reduced modulo the order have a negligible bias.
+		s.s.SetBytes(utils.RandomBytes(secLength))
+
+		if !s.IsZero() {
+			return s
+		}
+	}
+}
+
+// Add sets the receiver to the sum of the input and the receiver, and returns the receiver.
+func (s *Scalar) Add(ss internal.Scalar) internal.Scalar {
+	if ss == nil {
+		return s
+	}
+
+	s.s.Add(&s.s, &cvtScalar(ss).s)
+
+	return s
+}
+
+// Subtract subtracts the input from the receiver, and returns the receiver.
+func (s *Scalar) Subtract(ss internal.Scalar) internal.Scalar {
+	if ss == nil {
+		return s
+	}
+
+	s.s.Sub(&s.s, &cvtScalar(ss).s)
+
+	return s
+}
+
+// Multiply multiplies the receiver with the input, and returns the receiver.
+// If s parameter is nil, then the receiver is set to 0
+func (s *Scalar) Multiply(ss internal.Scalar) internal.Scalar {
+	if ss == nil {
+		s.Zero()
+		return s
+	}
+
+	s.s.Mul(&s.s, &cvtScalar(ss).s)
+
+	return s
+}
+
+// Invert sets the receiver to the scalar's modular inverse ( 1 / scalar ), and returns it.
+// The inverse is computed with Fermat's little theorem, whose exponent is public.
+func (s *Scalar) Invert() internal.Scalar {
+	s.s.Inv(&s.s)
+	return s
+}
+
+// Equal returns 1 if the scalars are equal, and 0 otherwise.
+func (s *Scalar) Equal(ss internal.Scalar) int {
+	if ss == nil {
+		return 0
+	}
+
+	return s.s.IsEqual(&cvtScalar(ss).s)
+}
+
+// IsZero returns whether the scalar is 0.
+func (s *Scalar) IsZero() bool {
+	return s.s.IsZero() == 1
+}
+
+// Set sets the receiver to the value of the argument scalar, and returns the receiver.
+// If s parameter is nil, then the receiver is set to 0
+func (s *Scalar) Set(ss internal.Scalar) internal.Scalar {
+	if ss == nil {
+		s.Zero()
+		return s
+	}
+
+	s.s.Set(&cvtScalar(ss).s)
+
+	return s
+}
+
+// Copy returns a copy of the receiver.
+func (s *Scalar) Copy() internal.Scalar {
+	c := newScalar()
+	c.s.Set(&s.s)
+
+	return c
+}
+
+// Encode returns the 32 bytes big-endian encoding of the scalar.
+func (s *Scalar) Encode() []byte {
+	b, err := s.s.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+// The input must be the canonical 32 bytes big-endian encoding of the scalar.
+func (s *Scalar) Decode(in []byte) error {
+	if len(in) != scalarSize {
+		return ErrInvalidScalar
+	}
+
+	var sc bls.Scalar
+	if err := sc.UnmarshalBinary(in); err != nil {
+		return ErrInvalidScalar
+	}
+
+	s.s.Set(&sc)
+
+	return nil
+}
+
+// BigEndian returns the big-endian encoding of the scalar, with the length of Encode.
+func (s *Scalar) BigEndian() []byte {
+	return s.Encode()
+}
+
+// SetBigEndian sets the receiver to the canonical big-endian encoding in the input, with the length of Encode,
+// and returns an error on failure.
+func (s *Scalar) SetBigEndian(in []byte) error {
+	return s.Decode(in)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (s *Scalar) MarshalBinary() ([]byte, error) {
+	return s.Encode(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *Scalar) UnmarshalBinary(data []byte) error {
+	return s.Decode(data)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s *Scalar) MarshalText() (text []byte, err error) {
+	b := s.Encode()
+	return []byte(base64.StdEncoding.EncodeToString(b)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *Scalar) UnmarshalText(text []byte) error {
+	sb, err := base64.StdEncoding.DecodeString(string(text))
+	if err != nil {
+		return fmt.Errorf("bls12381: %w", err)
+	}
+
+	return s.Decode(sb)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decaf448
+
+import (
+	fp "github.com/cloudflare/circl/math/fp448"
+)
+
+const (
+	// H2C represents the hash-to-curve string identifier.
+	// See https://www.rfc-editor.org/rfc/rfc9497.html#name-oprfdecaf448-shake-256
+	H2C           = "decaf448_XOF:SHAKE256_D448MAP_RO_"
+	canonicalSize = 56
+	uniformSize   = 112
+	scalarWide    = 64
+	securityLevel = 224
+)
+
+// Constants from https://www.rfc-editor.org/rfc/rfc9496.html#name-internal-constants-2
+var (
+	// d = -39081
+	paramD = fp.Elt{
+		0x56, 0x67, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xfe, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	}
+	// ONE_MINUS_D = 1 - d
+	oneMinusD = fp.Elt{0xaa, 0x98}
+	// ONE_MINUS_TWO_D = 1 - 2d
+	oneMinusTwoD = fp.Elt{0x53, 0x31, 0x01}
+	// SQRT_MINUS_D = sqrt(-d)
+	sqrtMinusD = fp.Elt{
+		0x36, 0x27, 0x57, 0x45, 0x0f, 0xef, 0x42, 0x96,
+		0x52, 0xce, 0x20, 0xaa, 0xf6, 0x7b, 0x33, 0x60,
+		0xd2, 0xde, 0x6e, 0xfd, 0xf4, 0x66, 0x9a, 0x83,
+		0xba, 0x14, 0x8c, 0x96, 0x80, 0xd7, 0xa2, 0x64,
+		0x4b, 0xd5, 0xb8, 0xa5, 0xb8, 0xa7, 0xf1, 0xa1,
+		0xa0, 0x6a, 0xa2, 0x2f, 0x72, 0x8d, 0xf6, 0x3b,
+		0x68, 0xf7, 0x24, 0xeb, 0xfb, 0x62, 0xd9, 0x22,
+	}
+	// INVSQRT_MINUS_D = 1 / sqrt(-d)
+	invSqrtMinusD = fp.Elt{
+		0x2c, 0x68, 0x78, 0xb8, 0x5e, 0xbb, 0xaf, 0x53,
+		0xf3, 0x94, 0x9e, 0xf1, 0x79, 0x24, 0xbb, 0xef,
+		0x15, 0xba, 0x1f, 0xc2, 0xe2, 0x7e, 0x70, 0xbe,
+		0x1a, 0x52, 0xa6, 0x28, 0xf1, 0x56, 0xba, 0xd6,
+		0xa7, 0x27, 0x5b, 0x3a, 0x0c, 0x95, 0x90, 0x5a,
+		0x07, 0xc8, 0xca, 0x0b, 0x5a, 0xe3, 0x2b, 0x90,
+		0x57, 0xc0, 0x22, 0xe2, 0x52, 0x06, 0xf4, 0x6e,
+	}
+)
+
+// generatorEncoding is the canonical encoding of the decaf448 generator.
+// See https://www.rfc-editor.org/rfc/rfc9496.html#name-multiples-of-the-generator-2
+var generatorEncoding = []byte{
+	0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66,
+	0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66,
+	0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66,
+	0x66, 0x66, 0x66, 0x66, 0x33, 0x33, 0x33, 0x33,
+	0x33, 0x33, 0x33, 0x33, 0x33, 0x33, 0x33, 0x33,
+	0x33, 0x33, 0x33, 0x33, 0x33, 0x33, 0x33, 0x33,
+	0x33, 0x33, 0x33, 0x33, 0x33, 0x33, 0x33, 0x33,
+}
+
+var (
+	zero = fp.Elt{}
+	one  = fp.One()
+)
@@ -0,0 +1,401 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decaf448
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	fp "github.com/cloudflare/circl/math/fp448"
+
+	"github.com/cymony/cryptomony/eccgroup/internal"
+)
+
+// generator is the internal representation of the canonical generator.
+var generator = func() point {
+	e := &Element{}
+	if err := e.Decode(generatorEncoding); err != nil {
+		panic(err)
+	}
+
+	return e.p
+}()
+
+// Element represents decaf448 point
+type Element struct {
+	p point
+}
+
+func cvtEl(ee internal.Element) *Element {
+	if ee == nil {
+		panic(internal.ErrParamNilPoint)
+	}
+
+	ec, ok := ee.(*Element)
+	if !ok {
+		panic(internal.ErrCastElement)
+	}
+
+	return ec
+}
+
+func newElement() internal.Element {
+	return &Element{p: *newIdentityPoint()}
+}
+
+// Base sets the element to the group's base point a.k.a. canonical generator.
+func (e *Element) Base() internal.Element {
+	e.p = generator
+	return e
+}
+
+// Identity sets the element to the point at infinity of the Group's underlying curve.
+func (e *Element) Identity() internal.Element {
+	e.p = *newIdentityPoint()
+	return e
+}
+
+// Add sets the receiver to the sum of the input and the receiver, and returns the receiver.
+func (e *Element) Add(ee internal.Element) internal.Element {
+	if ee == nil {
+		return e
+	}
+
+	ec := cvtEl(ee)
+	e.p.Add(&e.p, &ec.p)
+
+	return e
+}
+
+// Double sets the receiver to its double, and returns it.
+func (e *Element) Double() internal.Element {
+	e.p.Double(&e.p)
+	return e
+}
+
+// Negate sets the receiver to its negation, and returns it.
+func (e *Element) Negate() internal.Element {
+	e.p.Negate(&e.p)
+	return e
+}
+
+// Subtract subtracts the input from the receiver, and returns the receiver.
+func (e *Element) Subtract(ee internal.Element) internal.Element {
+	if ee == nil {
+		return e
+	}
+
+	var neg point
+
+	ec := cvtEl(ee)
+	e.p.Add(&e.p, neg.Negate(&ec.p))
+
+	return e
+}
+
+// Multiply sets the receiver to the scalar multiplication of the receiver with the given Scalar, and returns it.
+// If s parameter is nil, then the receiver is set to the identity element
+func (e *Element) Multiply(s internal.Scalar) internal.Element {
+	if s == nil {
+		e.Identity()
+		return e
+	}
+
+	sc := cvtScalar(s)
+	k := sc.s
+	k.Red()
+	e.p.ScalarMult(&k, &e.p)
+
+	return e
+}
+
+// Equal returns 1 if e is equivalent to ee, and 0 otherwise.
+//
+// Note that Elements must not be compared in any other way.
+func (e *Element) Equal(ee internal.Element) int {
+	if ee == nil {
+		return 0
+	}
+
+	ec := cvtEl(ee)
+
+	// x1 * y2 == y1 * x2
+	var f0, f1 fp.Elt
+
+	fp.Mul(&f0, &e.p.x, &ec.p.y)
+	fp.Mul(&f1, &e.p.y, &ec.p.x)
+
+	return int(equal(&f0, &f1))
+}
+
+// IsIdentity returns whether the Element is the point at infinity of the Group's underlying curve.
+func (e *Element) IsIdentity() bool {
+	return e.Equal(newElement()) == 1
+}
+
+// Set sets the receiver to ee if not nil; else the receiver is set to the identity element; returns the receiver.
+func (e *Element) Set(ee internal.Element) internal.Element {
+	if ee == nil {
+		e.Identity()
+		return e
+	}
+
+	e.p = cvtEl(ee).p
+
+	return e
+}
+
+// Copy returns a copy of the receiver.
+func (e *Element) Copy() internal.Element {
+	return &Element{p: e.p}
+}
+
+// Encode returns the 56 bytes canonical encoding of the element.
+// See https://www.rfc-editor.org/rfc/rfc9496.html#name-encode-2
+func (e *Element) Encode() []byte {
+	x0, z0, t0 := &e.p.x, &e.p.z, &e.p.t
+
+	var u1, u2, tmp, invSqrt, ratio, s fp.Elt
+
+	//nolint:gocritic // it is not commented code
+	// u1 = (x0 + t0) * (x0 - t0)
+	fp.Add(&u1, x0, t0)
+	fp.Sub(&tmp, x0, t0)
+	fp.Mul(&u1, &u1, &tmp)
+
+	// Ignore was_square since this is always square
+	// (_, invsqrt) = SQRT_RATIO_M1(1, u1 * ONE_MINUS_D * x0^2)
+	fp.Sqr(&tmp, x0)
+	fp.Mul(&tmp, &tmp, &oneMinusD)
+	fp.Mul(&tmp, &tmp, &u1)
+	sqrtRatio(&invSqrt, &one, &tmp)
+
+	//nolint:gocritic // it is not commented code
+	// ratio = CT_ABS(invsqrt * u1 * SQRT_MINUS_D)
+	fp.Mul(&ratio, &invSqrt, &u1)
+	fp.Mul(&ratio, &ratio, &sqrtMinusD)
+	ctAbs(&ratio, &ratio)
+
+	//nolint:gocritic // it is not commented code
+	// u2 = INVSQRT_MINUS_D * ratio * z0 - t0
+	fp.Mul(&u2, &invSqrtMinusD, &ratio)
+	fp.Mul(&u2, &u2, z0)
+	fp.Sub(&u2, &u2, t0)
+
+	//nolint:gocritic // it is not commented code
+	// s = CT_ABS(ONE_MINUS_D * invsqrt * x0 * u2)
+	fp.Mul(&s, &oneMinusD, &invSqrt)
+	fp.Mul(&s, &s, x0)
+	fp.Mul(&s, &s, &u2)
+	ctAbs(&s, &s)
+
+	// Return the canonical little-endian encoding of s.
+	out := make([]byte, canonicalSize)
+	if err := fp.ToBytes(out, &s); err != nil {
+		panic(err)
+	}
+
+	return out
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+// See https://www.rfc-editor.org/rfc/rfc9496.html#name-decode-2
+func (e *Element) Decode(data []byte) error {
+	if len(data) != canonicalSize {
+		return ErrInvalidEncoding
+	}
+
+	// First, interpret the string as an integer s in little-endian representation.
+	var s fp.Elt
+
+	copy(s[:], data)
+
+	// If the resulting value is >= p, decoding fails.
+	canonical := s
+	fp.Modp(&canonical)
+
+	if subtle.ConstantTimeCompare(canonical[:], data) != 1 {
+		return ErrInvalidEncoding
+	}
+
+	// If IS_NEGATIVE(s) returns TRUE, decoding fails.
+	if isNegative(&s) == 1 {
+		return ErrInvalidEncoding
+	}
+
+	var ss, u1, u2, tmp, invSqrt, u3 fp.Elt
+
+	// ss = s^2
+	fp.Sqr(&ss, &s)
+	// u1 = 1 + ss
+	fp.Add(&u1, &one, &ss)
+
+	//nolint:gocritic // it is not commented code
+	// u2 = u1^2 - 4 * D * ss
+	fp.Mul(&tmp, &paramD, &ss)
+	fp.Add(&tmp, &tmp, &tmp)
+	fp.Add(&tmp, &tmp, &tmp)
+	fp.Sqr(&u2, &u1)
+	fp.Sub(&u2, &u2, &tmp)
+
+	// (was_square, invsqrt) = SQRT_RATIO_M1(1, u2 * u1^2)
+	fp.Sqr(&tmp, &u1)
+	fp.Mul(&tmp, &tmp, &u2)
+	wasSquare := sqrtRatio(&invSqrt, &one, &tmp)
+
+	//nolint:gocritic // it is not commented code
+	// u3 = CT_ABS(2 * s * invsqrt * u1 * SQRT_MINUS_D)
+	fp.Add(&u3, &s, &s)
+	fp.Mul(&u3, &u3, &invSqrt)
+	fp.Mul(&u3, &u3, &u1)
+	fp.Mul(&u3, &u3, &sqrtMinusD)
+	ctAbs(&u3, &u3)
+
+	var p point
+
+	//nolint:gocritic // it is not commented code
+	// x = u3 * invsqrt * u2 * INVSQRT_MINUS_D
+	fp.Mul(&p.x, &u3, &invSqrt)
+	fp.Mul(&p.x, &p.x, &u2)
+	fp.Mul(&p.x, &p.x, &invSqrtMinusD)
+
+	//nolint:gocritic // it is not commented code
+	// y = (1 - ss) * invsqrt * u1
+	fp.Sub(&p.y, &one, &ss)
+	fp.Mul(&p.y, &p.y, &invSqrt)
+	fp.Mul(&p.y, &p.y, &u1)
+
+	// t = x * y
+	fp.Mul(&p.t, &p.x, &p.y)
+	p.z = one
+
+	// If was_square is FALSE then decoding fails.
+	if wasSquare == 0 {
+		return ErrInvalidEncoding
+	}
+
+	// Otherwise, return the internal representation in extended coordinates (x, y, 1, t).
+	e.p = p
+
+	return nil
+}
+
+// SetUniformBytes deterministically sets e to an uniformly distributed value
+// given 112 uniformly distributed random bytes.
+//
+// This can be used for hash-to-group operations or to obtain a random element.
+// See https://www.rfc-editor.org/rfc/rfc9496.html#name-element-derivation-2
+func (e *Element) SetUniformBytes(b []byte) (internal.Element, error) {
+	if len(b) != uniformSize {
+		return nil, errors.New("decaf448: SetUniformBytes input is not 112 bytes long")
+	}
+
+	var t fp.Elt
+
+	var p1, p2 point
+
+	copy(t[:], b[:canonicalSize])
+	mapToPoint(&p1, &t)
+
+	copy(t[:], b[canonicalSize:])
+	mapToPoint(&p2, &t)
+
+	e.p.Add(&p1, &p2)
+
+	return e, nil
+}
+
+// mapToPoint implements the MAP function of decaf448 element derivation. The field element t may be unreduced.
+func mapToPoint(out *point, t *fp.Elt) {
+	var r, u0, u1, tmp, v, vPrime, sgn, s, sSqr fp.Elt
+
+	//nolint:gocritic // it is not commented code
+	// r = -t^2
+	fp.Sqr(&r, t)
+	fp.Neg(&r, &r)
+
+	//nolint:gocritic // it is not commented code
+	// u0 = d * (r - 1)
+	fp.Sub(&u0, &r, &one)
+	fp.Mul(&u0, &u0, &paramD)
+
+	//nolint:gocritic // it is not commented code
+	// u1 = (u0 + 1) * (u0 - r)
+	fp.Add(&u1, &u0, &one)
+	fp.Sub(&tmp, &u0, &r)
+	fp.Mul(&u1, &u1, &tmp)
+
+	// (was_square, v) = SQRT_RATIO_M1(ONE_MINUS_TWO_D, (r + 1) * u1)
+	fp.Add(&tmp, &r, &one)
+	fp.Mul(&tmp, &tmp, &u1)
+	wasSquare := sqrtRatio(&v, &oneMinusTwoD, &tmp)
+
+	// v_prime = CT_SELECT(v IF was_square ELSE t * v)
+	fp.Mul(&vPrime, t, &v)
+	fp.Cmov(&vPrime, &v, wasSquare)
+
+	// sgn = CT_SELECT(1 IF was_square ELSE -1)
+	fp.Neg(&sgn, &one)
+	fp.Cmov(&sgn, &one, wasSquare)
+
+	//nolint:gocritic // it is not commented code
+	// s = v_prime * (r + 1)
+	fp.Add(&tmp, &r, &one)
+	fp.Mul(&s, &vPrime, &tmp)
+	fp.Sqr(&sSqr, &s)
+
+	var w0, w1, w2, w3 fp.Elt
+
+	//nolint:gocritic // it is not commented code
+	// w0 = 2 * CT_ABS(s)
+	ctAbs(&w0, &s)
+	fp.Add(&w0, &w0, &w0)
+	// w1 = s^2 + 1
+	fp.Add(&w1, &sSqr, &one)
+	// w2 = s^2 - 1
+	fp.Sub(&w2, &sSqr, &one)
+
+	//nolint:gocritic // it is not commented code
+	// w3 = v_prime * s * (r - 1) * ONE_MINUS_TWO_D + sgn
+	fp.Sub(&tmp, &r, &one)
+	fp.Mul(&w3, &vPrime, &s)
+	fp.Mul(&w3, &w3, &tmp)
+	fp.Mul(&w3, &w3, &oneMinusTwoD)
+	fp.Add(&w3, &w3, &sgn)
+
+	// return (w0*w3, w2*w1, w1*w3, w0*w2)
+	fp.Mul(&out.x, &w0, &w3)
+	fp.Mul(&out.y, &w2, &w1)
+	fp.Mul(&out.z, &w1, &w3)
+	fp.Mul(&out.t, &w0, &w2)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (e *Element) MarshalBinary() ([]byte, error) {
+	return e.Encode(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (e *Element) UnmarshalBinary(data []byte) error {
+	return e.Decode(data)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (e *Element) MarshalText() (text []byte, err error) {
+	b := e.Encode()
+	return []byte(base64.StdEncoding.EncodeToString(b)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (e *Element) UnmarshalText(text []byte) error {
+	sb, err := base64.StdEncoding.DecodeString(string(text))
+	if err != nil {
+		return fmt.Errorf("decaf448: %w", err)
+	}
+
+	return e.Decode(sb)
+}
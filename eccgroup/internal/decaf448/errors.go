@@ -0,0 +1,15 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decaf448
+
+import "errors"
+
+var (
+	// ErrInvalidEncoding returns when passed unsuitable data for element unmarshaling
+	ErrInvalidEncoding = errors.New("decaf448: invalid element encoding")
+
+	// ErrInvalidScalar returns when passed unsuitable data for scalar unmarshaling
+	ErrInvalidScalar = errors.New("decaf448: invalid scalar encoding")
+)
@@ -0,0 +1,112 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decaf448
+
+import (
+	"crypto/subtle"
+
+	fp "github.com/cloudflare/circl/math/fp448"
+)
+
+// Constant-time helpers over GF(2^448 - 2^224 - 1). The functions return 1 for true and 0 for false.
+
+// isZero returns 1 if x == 0, and 0 otherwise.
+func isZero(x *fp.Elt) uint {
+	t := *x
+	fp.Modp(&t)
+
+	return uint(subtle.ConstantTimeCompare(t[:], zero[:]))
+}
+
+// equal returns 1 if x == y, and 0 otherwise.
+func equal(x, y *fp.Elt) uint {
+	var t fp.Elt
+
+	fp.Sub(&t, x, y)
+
+	return isZero(&t)
+}
+
+// isNegative returns 1 if the canonical representation of x is odd, and 0 otherwise.
+func isNegative(x *fp.Elt) uint {
+	t := *x
+	fp.Modp(&t)
+
+	return uint(t[0] & 1)
+}
+
+// ctAbs sets z to |x|, i.e. -x if x is negative and x otherwise.
+func ctAbs(z, x *fp.Elt) {
+	var n fp.Elt
+
+	fp.Neg(&n, x)
+
+	t := *x
+	fp.Cmov(&t, &n, isNegative(x))
+	*z = t
+}
+
+// sqrN sets z to x^(2^n).
+func sqrN(z, x *fp.Elt, n int) {
+	*z = *x
+	for i := 0; i < n; i++ {
+		fp.Sqr(z, z)
+	}
+}
+
+// powPMinus3Div4 sets z to x^((p-3)/4), where (p-3)/4 = (2^223 - 1) * 2^223 + (2^222 - 1).
+func powPMinus3Div4(z, x *fp.Elt) {
+	var t, a2, a3, a6, a12, a15, a24, a48, a96, a111, a222, a223 fp.Elt
+
+	// a_k = x^(2^k - 1), computed with a_(m+n) = a_m^(2^n) * a_n
+	sqrN(&t, x, 1)
+	fp.Mul(&a2, &t, x)
+	sqrN(&t, &a2, 1)
+	fp.Mul(&a3, &t, x)
+	sqrN(&t, &a3, 3) //nolint:gomnd //addition chain
+	fp.Mul(&a6, &t, &a3)
+	sqrN(&t, &a6, 6) //nolint:gomnd //addition chain
+	fp.Mul(&a12, &t, &a6)
+	sqrN(&t, &a12, 3) //nolint:gomnd //addition chain
+	fp.Mul(&a15, &t, &a3)
+	sqrN(&t, &a12, 12) //nolint:gomnd //addition chain
+	fp.Mul(&a24, &t, &a12)
+	sqrN(&t, &a24, 24) //nolint:gomnd //addition chain
+	fp.Mul(&a48, &t, &a24)
+	sqrN(&t, &a48, 48) //nolint:gomnd //addition chain
+	fp.Mul(&a96, &t, &a48)
+	sqrN(&t, &a96, 15) //nolint:gomnd //addition chain
+	fp.Mul(&a111, &t, &a15)
+	sqrN(&t, &a111, 111) //nolint:gomnd //addition chain
+	fp.Mul(&a222, &t, &a111)
+	sqrN(&t, &a222, 1)
+	fp.Mul(&a223, &t, x)
+	sqrN(&t, &a223, 223) //nolint:gomnd //addition chain
+	fp.Mul(z, &t, &a222)
+}
+
+// sqrtRatio implements SQRT_RATIO_M1 for decaf448. It sets z to CT_ABS(sqrt(u/v)) and returns 1 if u/v is
+// square, otherwise it sets z to CT_ABS(sqrt(-u/v)) and returns 0.
+// See https://www.rfc-editor.org/rfc/rfc9496.html#name-square-root-of-a-ratio-of-f
+func sqrtRatio(z, u, v *fp.Elt) uint {
+	var uv, r, check fp.Elt
+
+	// r = u * (u * v)^((p - 3) / 4)
+	fp.Mul(&uv, u, v)
+	powPMinus3Div4(&r, &uv)
+	fp.Mul(&r, &r, u)
+
+	// check = v * r^2
+	fp.Sqr(&check, &r)
+	fp.Mul(&check, &check, v)
+
+	// was_square = CT_EQ(check, u)
+	wasSquare := equal(&check, u)
+
+	// r = CT_ABS(r)
+	ctAbs(z, &r)
+
+	return wasSquare
+}
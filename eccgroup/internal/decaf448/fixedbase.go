@@ -0,0 +1,30 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decaf448
+
+import (
+	"github.com/cymony/cryptomony/eccgroup/internal"
+)
+
+// FixedBase is a precomputed table of multiples of a Decaf448 element.
+type FixedBase struct {
+	table *internal.FixedBaseTable[*point]
+}
+
+// NewFixedBase returns a precomputed table of multiples of the element, for faster scalar multiplications.
+func (g *Group) NewFixedBase(e internal.Element) internal.FixedBase {
+	return &FixedBase{table: internal.NewFixedBaseTable(newIdentityPoint, &cvtEl(e).p, canonicalSize)}
+}
+
+// Multiply returns the scalar multiplication of the precomputed element with the given Scalar, in constant time.
+func (f *FixedBase) Multiply(s internal.Scalar) internal.Element {
+	k := cvtScalar(s).s
+	k.Red()
+
+	out := &Element{}
+	f.table.Multiply(&out.p, k[:])
+
+	return out
+}
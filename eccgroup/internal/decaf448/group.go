@@ -0,0 +1,121 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package decaf448 implements Decaf448 prime-order group with field arithmetic backend "github.com/cloudflare/circl"
+package decaf448
+
+import (
+	"github.com/cymony/cryptomony/eccgroup/internal"
+	"github.com/cymony/cryptomony/msgexpand"
+	"github.com/cymony/cryptomony/xof"
+)
+
+// Group represents the Decaf448 group. It exposes a prime-order group API with hash-to-curve operations.
+type Group struct{}
+
+// Decaf448 returns a new instantiation of the Decaf448 Group.
+func Decaf448() internal.Group {
+	return &Group{}
+}
+
+// NewScalar returns a new, empty, scalar.
+func (g *Group) NewScalar() internal.Scalar {
+	return newScalar()
+}
+
+// NewElement returns the identity element (point at infinity).
+func (g *Group) NewElement() internal.Element {
+	return newElement()
+}
+
+// RandomScalar returns randomly generated scalar.
+func (g *Group) RandomScalar() internal.Scalar {
+	return g.NewScalar().Random()
+}
+
+// RandomElement returns randomly generated element.
+func (g *Group) RandomElement() internal.Element {
+	return g.NewElement().Base().Multiply(g.RandomScalar())
+}
+
+// Base returns the group's base point a.k.a. canonical generator.
+func (g *Group) Base() internal.Element {
+	return g.NewElement().Base()
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to a Scalar.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group) HashToScalar(input, dst []byte) internal.Scalar {
+	expander := msgexpand.NewMessageExpandXOF(xof.SHAKE256, securityLevel)
+
+	uniform, err := expander.Expand(input, dst, scalarWide)
+	if err != nil {
+		panic(err)
+	}
+
+	return cvtScalar(newScalar()).SetUniformBytes(uniform)
+}
+
+// HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group) HashToGroup(input, dst []byte) internal.Element {
+	expander := msgexpand.NewMessageExpandXOF(xof.SHAKE256, securityLevel)
+
+	uniform, err := expander.Expand(input, dst, uniformSize)
+	if err != nil {
+		panic(err)
+	}
+
+	el, err := cvtEl(newElement()).SetUniformBytes(uniform)
+	if err != nil {
+		panic(err)
+	}
+
+	return el
+}
+
+// EncodeToGroup returns a non-uniform mapping of the arbitrary input to an Element in the Group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group) EncodeToGroup(input, dst []byte) internal.Element {
+	return g.HashToGroup(input, dst)
+}
+
+// MultiScalarMult returns the sum of the element-wise products of scalars and elements.
+// Small batches use Straus' method, large batches use Pippenger's method.
+// It runs in variable time and must only be used with public scalars.
+func (g *Group) MultiScalarMult(scalars []internal.Scalar, elements []internal.Element) internal.Element {
+	if len(scalars) != len(elements) {
+		panic(internal.ErrLengthMismatch)
+	}
+
+	points := make([]*point, len(elements))
+	for i := range elements {
+		points[i] = &cvtEl(elements[i]).p
+	}
+
+	ss := make([][]byte, len(scalars))
+	for i := range scalars {
+		ss[i] = cvtScalar(scalars[i]).Encode()
+	}
+
+	out := &Element{}
+	internal.MultiScalarMult(&out.p, newIdentityPoint, ss, points)
+
+	return out
+}
+
+// Ciphersuite returns the hash-to-curve ciphersuite identifier.
+func (g *Group) Ciphersuite() string {
+	return H2C
+}
+
+// ScalarLength returns the byte size of an encoded scalar.
+func (g *Group) ScalarLength() uint {
+	return canonicalSize
+}
+
+// ElementLength returns the byte size of an encoded element.
+func (g *Group) ElementLength() uint {
+	return canonicalSize
+}
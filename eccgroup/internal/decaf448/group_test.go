@@ -0,0 +1,78 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decaf448
+
+import (
+	"testing"
+
+	"github.com/cymony/cryptomony/eccgroup/internal"
+	"github.com/cymony/cryptomony/internal/test"
+	"github.com/cymony/cryptomony/utils"
+)
+
+func TestDecaf448(t *testing.T) {
+	g, ok := Decaf448().(*Group)
+	test.CheckOk(t, ok, "type assertion err")
+
+	t.Run("Group/HashToScalarAndGroup", func(tt *testing.T) { testHashToXGroup(tt, g) })
+	t.Run("Group/MultiScalarMult", func(tt *testing.T) { testMultiScalarMult(tt, g) })
+}
+
+func testHashToXGroup(t *testing.T, g *Group) {
+	t.Helper()
+
+	input := utils.RandomBytes(16)
+
+	err := test.CheckPanic(func() { g.HashToScalar(input, nil) })
+	test.CheckNoErr(t, err, "panic expected")
+
+	err = test.CheckPanic(func() { g.HashToGroup(input, []byte("shorter")) })
+	test.CheckNoErr(t, err, "panic expected")
+
+	dst := []byte("This is greater than recommended length")
+
+	sc := g.HashToScalar(input, dst)
+	if len(sc.Encode()) != int(g.ScalarLength()) {
+		test.Report(t, len(sc.Encode()), g.ScalarLength())
+	}
+
+	el := g.HashToGroup(input, dst)
+	if len(el.Encode()) != int(g.ElementLength()) {
+		test.Report(t, len(el.Encode()), g.ElementLength())
+	}
+
+	if el.Equal(g.EncodeToGroup(input, dst)) != 1 {
+		test.Report(t, el, g.EncodeToGroup(input, dst), "hash and encode to group differ")
+	}
+
+	_, err = g.NewElement().(*Element).SetUniformBytes(utils.RandomBytes(canonicalSize))
+	test.CheckIsErr(t, err, "expected error for short uniform bytes")
+}
+
+func testMultiScalarMult(t *testing.T, g *Group) {
+	t.Helper()
+
+	for _, n := range []int{0, 1, 5, 40} {
+		scalars := make([]internal.Scalar, n)
+		elements := make([]internal.Element, n)
+		want := g.NewElement()
+
+		for i := 0; i < n; i++ {
+			scalars[i] = g.RandomScalar()
+			elements[i] = g.RandomElement()
+			want.Add(elements[i].Copy().Multiply(scalars[i]))
+		}
+
+		got := g.MultiScalarMult(scalars, elements)
+		if got.Equal(want) != 1 {
+			test.Report(t, got, want, "multi scalar multiplication mismatch")
+		}
+	}
+
+	err := test.CheckPanic(func() {
+		g.MultiScalarMult([]internal.Scalar{g.RandomScalar()}, nil)
+	})
+	test.CheckNoErr(t, err, "panic expected")
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decaf448
+
+import (
+	"crypto/subtle"
+
+	"github.com/cloudflare/circl/ecc/goldilocks"
+	fp "github.com/cloudflare/circl/math/fp448"
+)
+
+const scalarWindow = 4
+
+// point is a point of the Edwards curve x^2 + y^2 = 1 + d*x^2*y^2 in extended coordinates (X:Y:Z:T),
+// with x = X/Z, y = Y/Z and x*y = T/Z. Since d is not a square, the addition formulas are complete.
+type point struct {
+	x, y, z, t fp.Elt
+}
+
+func newIdentityPoint() *point {
+	return &point{y: one, z: one}
+}
+
+// Set sets the receiver to q, and returns it.
+func (p *point) Set(q *point) *point {
+	*p = *q
+	return p
+}
+
+// Add sets the receiver to q + r, and returns it. The points may overlap.
+// See https://hyperelliptic.org/EFD/g1p/auto-twisted-extended.html#addition-add-2008-hwcd
+func (p *point) Add(q, r *point) *point {
+	var a, b, c, dd, e, f, g, h, t0, t1 fp.Elt
+
+	fp.Mul(&a, &q.x, &r.x)
+	fp.Mul(&b, &q.y, &r.y)
+	fp.Mul(&c, &q.t, &r.t)
+	fp.Mul(&c, &c, &paramD)
+	fp.Mul(&dd, &q.z, &r.z)
+	fp.Add(&t0, &q.x, &q.y)
+	fp.Add(&t1, &r.x, &r.y)
+	fp.Mul(&e, &t0, &t1)
+	fp.Sub(&e, &e, &a)
+	fp.Sub(&e, &e, &b)
+	fp.Sub(&f, &dd, &c)
+	fp.Add(&g, &dd, &c)
+	fp.Sub(&h, &b, &a)
+
+	fp.Mul(&p.x, &e, &f)
+	fp.Mul(&p.y, &g, &h)
+	fp.Mul(&p.t, &e, &h)
+	fp.Mul(&p.z, &f, &g)
+
+	return p
+}
+
+// Double sets the receiver to 2 * q, and returns it.
+// See https://hyperelliptic.org/EFD/g1p/auto-twisted-extended.html#doubling-dbl-2008-hwcd
+func (p *point) Double(q *point) *point {
+	var a, b, c, e, f, g, h fp.Elt
+
+	fp.Sqr(&a, &q.x)
+	fp.Sqr(&b, &q.y)
+	fp.Sqr(&c, &q.z)
+	fp.Add(&c, &c, &c)
+	fp.Add(&e, &q.x, &q.y)
+	fp.Sqr(&e, &e)
+	fp.Sub(&e, &e, &a)
+	fp.Sub(&e, &e, &b)
+	fp.Add(&g, &a, &b)
+	fp.Sub(&f, &g, &c)
+	fp.Sub(&h, &a, &b)
+
+	fp.Mul(&p.x, &e, &f)
+	fp.Mul(&p.y, &g, &h)
+	fp.Mul(&p.t, &e, &h)
+	fp.Mul(&p.z, &f, &g)
+
+	return p
+}
+
+// Negate sets the receiver to -q, and returns it.
+func (p *point) Negate(q *point) *point {
+	p.y, p.z = q.y, q.z
+	fp.Neg(&p.x, &q.x)
+	fp.Neg(&p.t, &q.t)
+
+	return p
+}
+
+// selectPoint sets the receiver to q if cond == 1, and leaves it unchanged if cond == 0.
+func (p *point) selectPoint(q *point, cond uint) {
+	fp.Cmov(&p.x, &q.x, cond)
+	fp.Cmov(&p.y, &q.y, cond)
+	fp.Cmov(&p.z, &q.z, cond)
+	fp.Cmov(&p.t, &q.t, cond)
+}
+
+// Select sets the receiver to p1 if cond == 1, and to p2 if cond == 0, and returns it.
+func (p *point) Select(p1, p2 *point, cond int) *point {
+	sel := *p2
+	sel.selectPoint(p1, uint(cond))
+	*p = sel
+
+	return p
+}
+
+// ScalarMult sets the receiver to k * q in constant time, and returns it.
+// It uses a fixed 4-bit window with a constant-time table lookup.
+func (p *point) ScalarMult(k *goldilocks.Scalar, q *point) *point {
+	// table[i] = i * q
+	var table [1 << scalarWindow]point
+
+	table[0] = *newIdentityPoint()
+	table[1] = *q
+
+	for i := 2; i < len(table); i++ {
+		table[i].Add(&table[i-1], q)
+	}
+
+	acc := newIdentityPoint()
+
+	var sel point
+
+	for i := 2*len(k) - 1; i >= 0; i-- {
+		for j := 0; j < scalarWindow; j++ {
+			acc.Double(acc)
+		}
+
+		w := (k[i/2] >> (scalarWindow * (i % 2))) & 0x0f //nolint:gomnd //window mask
+
+		sel = table[0]
+		for j := 1; j < len(table); j++ {
+			sel.selectPoint(&table[j], uint(subtle.ConstantTimeByteEq(w, uint8(j))))
+		}
+
+		acc.Add(acc, &sel)
+	}
+
+	return p.Set(acc)
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decaf448
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/cloudflare/circl/ecc/goldilocks"
+
+	"github.com/cymony/cryptomony/eccgroup/internal"
+	"github.com/cymony/cryptomony/utils"
+)
+
+// orderMinusTwo is the inversion exponent of the scalar field.
+var orderMinusTwo = func() goldilocks.Scalar {
+	var e goldilocks.Scalar
+
+	order := goldilocks.Curve{}.Order()
+	e.Sub(&order, &goldilocks.Scalar{2})
+
+	return e
+}()
+
+// Scalar represents the decaf448 group Scalar
+type Scalar struct {
+	s goldilocks.Scalar
+}
+
+func cvtScalar(s internal.Scalar) *Scalar {
+	sc, ok := s.(*Scalar)
+	if !ok {
+		panic(internal.ErrCastScalar)
+	}
+
+	return sc
+}
+
+func newScalar() internal.Scalar {
+	return &Scalar{}
+}
+
+// Zero sets the scalar to 0, and returns it.
+func (s *Scalar) Zero() internal.Scalar {
+	s.s = goldilocks.Scalar{}
+	return s
+}
+
+// One sets the scalar to 1, and returns it.
+func (s *Scalar) One() internal.Scalar {
+	s.s = goldilocks.Scalar{1}
+	return s
+}
+
+// Random sets the current scalar to a new random scalar and returns it.
+// The random source is crypto/rand, and this functions is guaranteed to return a non-zero scalar.
+func (s *Scalar) Random() internal.Scalar {
+	for {
+		s.s.FromBytes(utils.RandomBytes(scalarWide))
+
+		if !s.IsZero() {
+			return s
+		}
+	}
+}
+
+// Add sets the receiver to the sum of the input and the receiver, and returns the receiver.
+func (s *Scalar) Add(ss internal.Scalar) internal.Scalar {
+	if ss == nil {
+		return s
+	}
+
+	sc := cvtScalar(ss)
+	s.s.Add(&s.s, &sc.s)
+
+	return s
+}
+
+// Subtract subtracts the input from the receiver, and returns the receiver.
+func (s *Scalar) Subtract(ss internal.Scalar) internal.Scalar {
+	if ss == nil {
+		return s
+	}
+
+	sc := cvtScalar(ss)
+	s.s.Sub(&s.s, &sc.s)
+
+	return s
+}
+
+// Multiply multiplies the receiver with the input, and returns the receiver.
+// If s parameter is nil, then the receiver is set to 0
+func (s *Scalar) Multiply(ss internal.Scalar) internal.Scalar {
+	if ss == nil {
+		s.Zero()
+		return s
+	}
+
+	sc := cvtScalar(ss)
+	s.s.Mul(&s.s, &sc.s)
+
+	return s
+}
+
+// Invert sets the receiver to the scalar's modular inverse ( 1 / scalar ), and returns it.
+// The inverse is computed in constant time as s^(order-2), so the inverse of 0 is 0.
+func (s *Scalar) Invert() internal.Scalar {
+	acc := goldilocks.Scalar{1}
+
+	for i := 8*len(orderMinusTwo) - 1; i >= 0; i-- {
+		acc.Mul(&acc, &acc)
+
+		if (orderMinusTwo[i/8]>>(i%8))&1 == 1 {
+			acc.Mul(&acc, &s.s)
+		}
+	}
+
+	s.s = acc
+
+	return s
+}
+
+// Equal returns 1 if the scalars are equal, and 0 otherwise.
+func (s *Scalar) Equal(ss internal.Scalar) int {
+	if ss == nil {
+		return 0
+	}
+
+	sc := cvtScalar(ss)
+	a, b := s.s, sc.s
+	a.Red()
+	b.Red()
+
+	return subtle.ConstantTimeCompare(a[:], b[:])
+}
+
+// IsZero returns whether the scalar is 0.
+func (s *Scalar) IsZero() bool {
+	return s.Equal(newScalar()) == 1
+}
+
+// Set sets the receiver to the value of the argument scalar, and returns the receiver.
+// If s parameter is nil, then the receiver is set to 0
+func (s *Scalar) Set(ss internal.Scalar) internal.Scalar {
+	if ss == nil {
+		s.Zero()
+		return s
+	}
+
+	s.s = cvtScalar(ss).s
+
+	return s
+}
+
+// Copy returns a copy of the receiver.
+func (s *Scalar) Copy() internal.Scalar {
+	return &Scalar{s: s.s}
+}
+
+// Encode returns the 56 bytes little-endian encoding of the scalar.
+func (s *Scalar) Encode() []byte {
+	out := s.s
+	out.Red()
+
+	return out[:]
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+// The input must be the 56 bytes little-endian canonical encoding of a scalar.
+func (s *Scalar) Decode(in []byte) error {
+	if len(in) != canonicalSize {
+		return ErrInvalidScalar
+	}
+
+	var sc goldilocks.Scalar
+
+	copy(sc[:], in)
+	sc.Red()
+
+	if subtle.ConstantTimeCompare(sc[:], in) != 1 {
+		return ErrInvalidScalar
+	}
+
+	s.s = sc
+
+	return nil
+}
+
+// SetUniformBytes sets s to the little-endian integer x reduced modulo the group order.
+// It is used with 64 uniformly distributed bytes to obtain an unbiased scalar.
+func (s *Scalar) SetUniformBytes(x []byte) *Scalar {
+	s.s.FromBytes(x)
+	return s
+}
+
+// BigEndian returns the big-endian encoding of the scalar, with the length of Encode.
+func (s *Scalar) BigEndian() []byte {
+	out := s.Encode()
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return out
+}
+
+// SetBigEndian sets the receiver to the canonical big-endian encoding in the input, with the length of Encode,
+// and returns an error on failure.
+func (s *Scalar) SetBigEndian(in []byte) error {
+	le := make([]byte, len(in))
+	for i := range in {
+		le[i] = in[len(in)-1-i]
+	}
+
+	return s.Decode(le)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (s *Scalar) MarshalBinary() ([]byte, error) {
+	return s.Encode(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *Scalar) UnmarshalBinary(data []byte) error {
+	return s.Decode(data)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s *Scalar) MarshalText() (text []byte, err error) {
+	b := s.Encode()
+	return []byte(base64.StdEncoding.EncodeToString(b)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *Scalar) UnmarshalText(text []byte) error {
+	sb, err := base64.StdEncoding.DecodeString(string(text))
+	if err != nil {
+		return fmt.Errorf("decaf448: %w", err)
+	}
+
+	return s.Decode(sb)
+}
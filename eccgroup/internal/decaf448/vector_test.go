@@ -0,0 +1,154 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decaf448
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/cymony/cryptomony/internal/test"
+)
+
+func TestDecaf448BasepointRoundTrip(t *testing.T) {
+	decodedBasepoint := newElement()
+
+	err := decodedBasepoint.Decode(generatorEncoding)
+	test.CheckNoErr(t, err, "decode err")
+
+	basepoint := newElement().Base()
+	if decodedBasepoint.Equal(basepoint) != 1 {
+		t.Error("decode succeeded, but got wrong point")
+	}
+
+	if !bytes.Equal(generatorEncoding, decodedBasepoint.Encode()) {
+		t.Error("decode<>encode roundtrip produced different results")
+	}
+
+	if !bytes.Equal(generatorEncoding, basepoint.Encode()) {
+		t.Error("point encode produced different results")
+	}
+}
+
+// Test vectors from https://www.rfc-editor.org/rfc/rfc9496.html#name-multiples-of-the-generator-2
+func TestDecaf448SmallMultiplesTestVectors(t *testing.T) {
+	var testVectors = [16]string{
+		// This is the identity point
+		"0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		// This is the basepoint
+		"6666666666666666666666666666666666666666666666666666666633333333333333333333333333333333333333333333333333333333",
+		// These are small multiples of the basepoint
+		"c898eb4f87f97c564c6fd61fc7e49689314a1f818ec85eeb3bd5514ac816d38778f69ef347a89fca817e66defdedce178c7cc709b2116e75",
+		"a0c09bf2ba7208fda0f4bfe3d0f5b29a543012306d43831b5adc6fe7f8596fa308763db15468323b11cf6e4aeb8c18fe44678f44545a69bc",
+		"b46f1836aa287c0a5a5653f0ec5ef9e903f436e21c1570c29ad9e5f596da97eeaf17150ae30bcb3174d04bc2d712c8c7789d7cb4fda138f4",
+		"1c5bbecf4741dfaae79db72dface00eaaac502c2060934b6eaaeca6a20bd3da9e0be8777f7d02033d1b15884232281a41fc7f80eed04af5e",
+		"86ff0182d40f7f9edb7862515821bd67bfd6165a3c44de95d7df79b8779ccf6460e3c68b70c16aaa280f2d7b3f22d745b97a89906cfc476c",
+		"502bcb6842eb06f0e49032bae87c554c031d6d4d2d7694efbf9c468d48220c50f8ca28843364d70cee92d6fe246e61448f9db9808b3b2408",
+		"0c9810f1e2ebd389caa789374d78007974ef4d17227316f40e578b336827da3f6b482a4794eb6a3975b971b5e1388f52e91ea2f1bcb0f912",
+		"20d41d85a18d5657a29640321563bbd04c2ffbd0a37a7ba43a4f7d263ce26faf4e1f74f9f4b590c69229ae571fe37fa639b5b8eb48bd9a55",
+		"e6b4b8f408c7010d0601e7eda0c309a1a42720d6d06b5759fdc4e1efe22d076d6c44d42f508d67be462914d28b8edce32e7094305164af17",
+		"be88bbb86c59c13d8e9d09ab98105f69c2d1dd134dbcd3b0863658f53159db64c0e139d180f3c89b8296d0ae324419c06fa87fc7daaf34c1",
+		"a456f9369769e8f08902124a0314c7a06537a06e32411f4f93415950a17badfa7442b6217434a3a05ef45be5f10bd7b2ef8ea00c431edec5",
+		"186e452c4466aa4383b4c00210d52e7922dbf9771e8b47e229a9b7b73c8d10fd7ef0b6e41530f91f24a3ed9ab71fa38b98b2fe4746d51d68",
+		"4ae7fdcae9453f195a8ead5cbe1a7b9699673b52c40ab27927464887be53237f7f3a21b938d40d0ec9e15b1d5130b13ffed81373a53e2b43",
+		"841981c3bfeec3f60cfeca75d9d8dc17f46cf0106f2422b59aec580a58f342272e3a5e575a055ddb051390c54c24c6ecb1e0aceb075f6056",
+	}
+
+	basepointMultiple := newElement().Identity()
+	basepoint := newElement().Base()
+
+	for i := range testVectors {
+		encoding, err := hex.DecodeString(testVectors[i])
+		test.CheckNoErr(t, err, fmt.Sprintf("#%d: bad hex encoding in test vector", i))
+
+		decodedPoint := newElement()
+
+		err = decodedPoint.Decode(encoding)
+		test.CheckNoErr(t, err, fmt.Sprintf("#%d: could not decode test vector", i))
+
+		if !bytes.Equal(encoding, decodedPoint.Encode()) {
+			t.Errorf("#%d: decode<>encode roundtrip failed", i)
+		}
+
+		if basepointMultiple.Equal(decodedPoint) != 1 {
+			t.Errorf("decoded small multiple %d * B is not %d * B", i, i)
+		}
+
+		if !bytes.Equal(encoding, basepointMultiple.Encode()) {
+			t.Errorf("#%d: encoding computed value did not match", i)
+		}
+
+		// The scalar multiplication must agree with the repeated additions.
+		k := newScalar()
+		k.(*Scalar).s[0] = byte(i)
+
+		if newElement().Base().Multiply(k).Equal(decodedPoint) != 1 {
+			t.Errorf("#%d: scalar multiplication did not match", i)
+		}
+
+		basepointMultiple.Add(basepoint)
+	}
+}
+
+func TestDecaf448BadEncodingsTestVectors(t *testing.T) {
+	var testVectors = []string{
+		// These are all bad because they're non-canonical field encodings.
+		"fffffffffffffffffffffffffffffffffffffffffffffffffffffffffeffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		// These are all bad because they're negative field elements.
+		"0100000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		"fdfffffffffffffffffffffffffffffffffffffffffffffffffffffffeffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		// These are all bad because they give a nonsquare x^2.
+		"0400000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		"0a00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		"0e00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		// These are bad because of their length.
+		"",
+		"00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+	}
+
+	for i := range testVectors {
+		encoding, err := hex.DecodeString(testVectors[i])
+		test.CheckNoErr(t, err, fmt.Sprintf("#%d: bad hex encoding in test vector", i))
+
+		err = newElement().Decode(encoding)
+		test.CheckIsErr(t, err, fmt.Sprintf("#%d: expected error decoding bad encoding", i))
+	}
+}
+
+func TestDecaf448Scalar(t *testing.T) {
+	// The group order in little-endian, which is not a canonical scalar encoding.
+	order, err := hex.DecodeString("f34458ab92c27823558fc58d72c26c219036d6ae49db4ec4e923ca7cffffffffffffffffffffffffffffffffffffffffffffffffffffff3f")
+	test.CheckNoErr(t, err, "hex decode err")
+
+	err = newScalar().Decode(order)
+	test.CheckIsErr(t, err, "expected error decoding non-canonical scalar")
+
+	// order - 1 is the largest canonical scalar, and -1 in the scalar field.
+	order[0]--
+
+	s := newScalar()
+	err = s.Decode(order)
+	test.CheckNoErr(t, err, "decode err")
+
+	minusOne := newScalar().Subtract(newScalar().One())
+	if s.Equal(minusOne) != 1 {
+		test.Report(t, s, minusOne, "order - 1 is not -1")
+	}
+
+	for i := 0; i < 1<<5; i++ {
+		r := newScalar().Random()
+		inv := r.Copy().Invert()
+
+		if r.Multiply(inv).Equal(newScalar().One()) != 1 {
+			t.Errorf("#%d: r * r^-1 is not 1", i)
+		}
+	}
+
+	if !newScalar().Invert().IsZero() {
+		t.Error("inverse of zero is not zero")
+	}
+}
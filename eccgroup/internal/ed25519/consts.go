@@ -0,0 +1,64 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ed25519
+
+import (
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+const (
+	// H2CEdwards25519 represents the hash-to-curve string identifier for edwards25519.
+	// See https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#name-suites-for-curve25519-and-e
+	H2CEdwards25519 = "edwards25519_XMD:SHA-512_ELL2_RO_"
+
+	// E2CEdwards25519 represents the encode-to-curve string identifier for edwards25519.
+	E2CEdwards25519 = "edwards25519_XMD:SHA-512_ELL2_NU_"
+
+	// H2CCurve25519 represents the hash-to-curve string identifier for curve25519.
+	H2CCurve25519 = "curve25519_XMD:SHA-512_ELL2_RO_"
+
+	// E2CCurve25519 represents the encode-to-curve string identifier for curve25519.
+	E2CCurve25519 = "curve25519_XMD:SHA-512_ELL2_NU_"
+
+	canonicalSize = 32
+	uniformSize   = 64
+	secLength     = 48 // L = ceil((ceil(log2(p)) + k) / 8), with k = 128
+)
+
+var (
+	zero = new(field.Element)
+	one  = new(field.Element).One()
+
+	// montgomeryA is the coefficient J = 486662 of curve25519, v^2 = u^3 + J*u^2 + u.
+	montgomeryA = new(field.Element).Mult32(one, 486662) //nolint:gomnd //curve coefficient
+
+	// ell2Z is the Elligator 2 non-square constant Z = 2.
+	ell2Z = new(field.Element).Add(one, one)
+
+	// sqrtMinusA2 is sqrt(-486664) with sgn0 equals 0, used by the rational map between curve25519 and edwards25519.
+	sqrtMinusA2, _ = new(field.Element).SetBytes([]byte{
+		0x06, 0x7e, 0x45, 0xff, 0xaa, 0x04, 0x6e, 0xcc,
+		0x82, 0x1a, 0x7d, 0x4b, 0xd1, 0xd3, 0xa1, 0xc5,
+		0x7e, 0x4f, 0xfc, 0x03, 0xdc, 0x08, 0x7b, 0xd2,
+		0xbb, 0x06, 0xa0, 0x60, 0xf4, 0xed, 0x26, 0x0f,
+	})
+
+	// twoTo192 is 2^192, used to reduce 48 bytes hash_to_field outputs.
+	twoTo192, _ = new(field.Element).SetBytes([]byte{
+		0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0,
+		1, 0, 0, 0, 0, 0, 0, 0,
+	})
+
+	// orderMinusOne is the prime subgroup order minus one, used for subgroup membership checks.
+	orderMinusOne, _ = edwards25519.NewScalar().SetCanonicalBytes([]byte{
+		0xec, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58,
+		0xd6, 0x9c, 0xf7, 0xa2, 0xde, 0xf9, 0xde, 0x14,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+	})
+)
@@ -0,0 +1,373 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ed25519
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	"github.com/cymony/cryptomony/eccgroup/internal"
+)
+
+// Element represents a point of the prime-order subgroup of edwards25519. Elements of the curve25519 group are
+// kept in the birationally equivalent edwards25519 form, and only differ in their encoding.
+type Element struct {
+	e          *edwards25519.Point
+	montgomery bool
+	// base reports whether e is the generator, to use the precomputed generator table in Multiply.
+	base bool
+}
+
+func cvtEl(ee internal.Element) *Element {
+	if ee == nil {
+		panic(internal.ErrParamNilPoint)
+	}
+
+	ec, ok := ee.(*Element)
+	if !ok {
+		panic(internal.ErrCastElement)
+	}
+
+	return ec
+}
+
+func newElement(montgomery bool) *Element {
+	return &Element{e: edwards25519.NewIdentityPoint(), montgomery: montgomery}
+}
+
+// Base sets the element to the group's base point a.k.a. canonical generator.
+func (e *Element) Base() internal.Element {
+	e.e.Set(edwards25519.NewGeneratorPoint())
+	e.base = true
+
+	return e
+}
+
+// Identity sets the element to the point at infinity of the Group's underlying curve.
+func (e *Element) Identity() internal.Element {
+	e.e.Set(edwards25519.NewIdentityPoint())
+	e.base = false
+
+	return e
+}
+
+// Add sets the receiver to the sum of the input and the receiver, and returns the receiver.
+func (e *Element) Add(ee internal.Element) internal.Element {
+	if ee == nil {
+		return e
+	}
+
+	ec := cvtEl(ee)
+	e.e.Add(e.e, ec.e)
+	e.base = false
+
+	return e
+}
+
+// Double sets the receiver to its double, and returns it.
+func (e *Element) Double() internal.Element {
+	e.e.Add(e.e, e.e)
+	e.base = false
+
+	return e
+}
+
+// Negate sets the receiver to its negation, and returns it.
+func (e *Element) Negate() internal.Element {
+	e.e.Negate(e.e)
+	e.base = false
+
+	return e
+}
+
+// Subtract subtracts the input from the receiver, and returns the receiver.
+func (e *Element) Subtract(ee internal.Element) internal.Element {
+	if ee == nil {
+		return e
+	}
+
+	ec := cvtEl(ee)
+	e.e.Subtract(e.e, ec.e)
+	e.base = false
+
+	return e
+}
+
+// Multiply sets the receiver to the scalar multiplication of the receiver with the given Scalar, and returns it.
+// If s parameter is nil, then the receiver is not modified.
+// The generator is multiplied with the precomputed tables of the backend.
+func (e *Element) Multiply(s internal.Scalar) internal.Element {
+	if s == nil {
+		e.Identity()
+		return e
+	}
+
+	sc := cvtScalar(s)
+
+	if e.base {
+		e.e.ScalarBaseMult(sc.s)
+	} else {
+		e.e.ScalarMult(sc.s, e.e)
+	}
+
+	e.base = false
+
+	return e
+}
+
+// Equal returns 1 if e is equivalent to ee, and 0 otherwise.
+func (e *Element) Equal(ee internal.Element) int {
+	if ee == nil {
+		return 0
+	}
+
+	return e.e.Equal(cvtEl(ee).e)
+}
+
+// IsIdentity returns whether the Element is the point at infinity of the Group's underlying curve.
+func (e *Element) IsIdentity() bool {
+	return e.e.Equal(edwards25519.NewIdentityPoint()) == 1
+}
+
+// Set sets the receiver to ee if not nil; else the receiver not modified; returns the receiver.
+func (e *Element) Set(ee internal.Element) internal.Element {
+	if ee == nil {
+		e.Identity()
+		return e
+	}
+
+	ec := cvtEl(ee)
+	e.e.Set(ec.e)
+	e.base = ec.base
+
+	return e
+}
+
+// Copy returns a copy of the receiver.
+func (e *Element) Copy() internal.Element {
+	return &Element{e: edwards25519.NewIdentityPoint().Set(e.e), montgomery: e.montgomery, base: e.base}
+}
+
+// Encode returns the 32 bytes canonical encoding of the element.
+//
+// Elements of the edwards25519 group use the RFC 8032 point encoding. Elements of the curve25519 group are encoded
+// as the little-endian u-coordinate, with the sign of the v-coordinate in the most significant bit.
+// The identity element is encoded as u = 0.
+func (e *Element) Encode() []byte {
+	if !e.montgomery {
+		return e.e.Bytes()
+	}
+
+	X, Y, Z, _ := e.e.ExtendedCoordinates()
+
+	var num, den, inv, u, v field.Element
+
+	//nolint:gocritic // it is not commented code
+	// u = (1 + y) / (1 - y), v = c1 * u / x, computed with a single inversion
+	num.Add(Z, Y)
+	den.Subtract(Z, Y)
+	den.Multiply(&den, X)
+	inv.Invert(&den)
+
+	u.Multiply(&num, X)
+	u.Multiply(&u, &inv)
+
+	v.Multiply(&num, Z)
+	v.Multiply(&v, sqrtMinusA2)
+	v.Multiply(&v, &inv)
+
+	out := u.Bytes()
+	out[canonicalSize-1] |= byte(v.IsNegative() << 7) //nolint:gomnd //sign bit
+
+	return out
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+// The decoded point must be the canonical encoding of an element of the prime-order subgroup.
+func (e *Element) Decode(data []byte) error {
+	if len(data) != canonicalSize {
+		return ErrInvalidEncoding
+	}
+
+	p := edwards25519.NewIdentityPoint()
+
+	if e.montgomery {
+		if err := setMontgomeryBytes(p, data); err != nil {
+			return err
+		}
+	} else if _, err := p.SetBytes(data); err != nil {
+		return ErrInvalidEncoding
+	}
+
+	// (order - 1) * p + p is the identity if and only if p is in the prime-order subgroup.
+	check := edwards25519.NewIdentityPoint().ScalarMult(orderMinusOne, p)
+	if check.Add(check, p).Equal(edwards25519.NewIdentityPoint()) != 1 {
+		return ErrNotInSubgroup
+	}
+
+	ne := &Element{e: p, montgomery: e.montgomery}
+
+	// Reject non-canonical encodings of the coordinates.
+	if !bytes.Equal(ne.Encode(), data) {
+		return ErrInvalidEncoding
+	}
+
+	e.e.Set(p)
+	e.base = false
+
+	return nil
+}
+
+// setMontgomeryBytes sets p to the edwards25519 point corresponding to the curve25519 encoding in data.
+func setMontgomeryBytes(p *edwards25519.Point, data []byte) error {
+	if bytes.Equal(data, make([]byte, canonicalSize)) {
+		p.Set(edwards25519.NewIdentityPoint())
+		return nil
+	}
+
+	uBytes := make([]byte, canonicalSize)
+	copy(uBytes, data)
+	uBytes[canonicalSize-1] &= 0x7f         //nolint:gomnd //sign bit
+	sign := int(data[canonicalSize-1] >> 7) //nolint:gomnd //sign bit
+
+	u := new(field.Element)
+	if _, err := u.SetBytes(uBytes); err != nil {
+		return ErrInvalidEncoding
+	}
+
+	// v = sqrt(u^3 + J*u^2 + u), with the sign given by the encoding
+	var rhs, v, negV field.Element
+
+	montgomeryPolynomial(&rhs, u)
+
+	if _, wasSquare := v.SqrtRatio(&rhs, one); wasSquare == 0 {
+		return ErrInvalidEncoding
+	}
+
+	v.Select(negV.Negate(&v), &v, sign)
+
+	//nolint:gocritic // it is not commented code
+	// x = c1 * u / v, y = (u - 1) / (u + 1), in extended coordinates
+	var X, Y, Z, T, uPlusOne, uMinusOne field.Element
+
+	uPlusOne.Add(u, one)
+	uMinusOne.Subtract(u, one)
+
+	X.Multiply(sqrtMinusA2, u).Multiply(&X, &uPlusOne)
+	Y.Multiply(&uMinusOne, &v)
+	Z.Multiply(&v, &uPlusOne)
+	T.Multiply(sqrtMinusA2, u).Multiply(&T, &uMinusOne)
+
+	if Z.Equal(zero) == 1 {
+		return ErrInvalidEncoding
+	}
+
+	if _, err := p.SetExtendedCoordinates(&X, &Y, &Z, &T); err != nil {
+		return ErrInvalidEncoding
+	}
+
+	return nil
+}
+
+// montgomeryPolynomial sets z to u^3 + J*u^2 + u.
+func montgomeryPolynomial(z, u *field.Element) {
+	var t field.Element
+
+	t.Add(u, montgomeryA)
+	t.Multiply(&t, u)
+	t.Add(&t, one)
+	z.Multiply(&t, u)
+}
+
+// mapToCurveElligator2 maps the field element u to a point of curve25519 with the Elligator 2 method, and sets p to
+// the corresponding edwards25519 point using the rational map. Both steps run in constant time.
+// See https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#name-elligator-2-method
+func mapToCurveElligator2(p *edwards25519.Point, u *field.Element) {
+	var tv, x1, x2, gx1, gx2, y1, y2, negY1, s, t field.Element
+
+	// 1. x1 = -(J / K) * inv0(1 + Z * u^2)
+	tv.Square(u)
+	tv.Multiply(&tv, ell2Z)
+	tv.Add(&tv, one)
+	tv.Invert(&tv)
+	x1.Multiply(montgomeryA, &tv)
+	x1.Negate(&x1)
+
+	// 2. If x1 == 0, set x1 = -(J / K)
+	tv.Negate(montgomeryA)
+	x1.Select(&tv, &x1, x1.Equal(zero))
+
+	// 3. gx1 = x1^3 + (J / K) * x1^2 + x1 / K^2
+	montgomeryPolynomial(&gx1, &x1)
+
+	// 4. x2 = -x1 - (J / K)
+	x2.Negate(&x1)
+	x2.Subtract(&x2, montgomeryA)
+
+	// 5. gx2 = x2^3 + (J / K) * x2^2 + x2 / K^2
+	montgomeryPolynomial(&gx2, &x2)
+
+	// 6. If is_square(gx1), set x = x1, y = sqrt(gx1) with sgn0(y) == 1.
+	// 7. Else set x = x2, y = sqrt(gx2) with sgn0(y) == 0.
+	_, isSquare := y1.SqrtRatio(&gx1, one)
+	y2.SqrtRatio(&gx2, one)
+	negY1.Negate(&y1)
+
+	// 8. s = x * K, t = y * K
+	s.Select(&x1, &x2, isSquare)
+	t.Select(&negY1, &y2, isSquare)
+
+	// rational map: x = c1 * s / t, y = (s - 1) / (s + 1), in extended coordinates
+	var X, Y, Z, T, sPlusOne, sMinusOne field.Element
+
+	sPlusOne.Add(&s, one)
+	sMinusOne.Subtract(&s, one)
+
+	X.Multiply(sqrtMinusA2, &s).Multiply(&X, &sPlusOne)
+	Y.Multiply(&sMinusOne, &t)
+	Z.Multiply(&t, &sPlusOne)
+	T.Multiply(sqrtMinusA2, &s).Multiply(&T, &sMinusOne)
+
+	// The exceptional case t * (s + 1) == 0 is mapped to the identity point.
+	exceptional := Z.Equal(zero)
+	X.Select(zero, &X, exceptional)
+	Y.Select(one, &Y, exceptional)
+	Z.Select(one, &Z, exceptional)
+	T.Select(zero, &T, exceptional)
+
+	if _, err := p.SetExtendedCoordinates(&X, &Y, &Z, &T); err != nil {
+		panic("ed25519: internal error: elligator 2 generated invalid coordinates")
+	}
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (e *Element) MarshalBinary() ([]byte, error) {
+	return e.Encode(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (e *Element) UnmarshalBinary(data []byte) error {
+	return e.Decode(data)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (e *Element) MarshalText() (text []byte, err error) {
+	b := e.Encode()
+	return []byte(base64.StdEncoding.EncodeToString(b)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (e *Element) UnmarshalText(text []byte) error {
+	sb, err := base64.StdEncoding.DecodeString(string(text))
+	if err != nil {
+		return fmt.Errorf("ed25519: %w", err)
+	}
+
+	return e.Decode(sb)
+}
@@ -0,0 +1,15 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ed25519
+
+import "errors"
+
+var (
+	// ErrInvalidEncoding returns when passed unsuitable data for unmarshaling
+	ErrInvalidEncoding = errors.New("ed25519: invalid element encoding")
+
+	// ErrNotInSubgroup returns when the decoded point is not in the prime-order subgroup
+	ErrNotInSubgroup = errors.New("ed25519: point is not in the prime-order subgroup")
+)
@@ -0,0 +1,28 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ed25519
+
+import (
+	"github.com/cymony/cryptomony/eccgroup/internal"
+)
+
+// FixedBase is a precomputed table of multiples of an edwards25519 or curve25519 element.
+type FixedBase struct {
+	table      *internal.EdwardsFixedBaseTable
+	montgomery bool
+}
+
+// NewFixedBase returns a precomputed table of multiples of the element, for faster scalar multiplications.
+func (g *Group) NewFixedBase(e internal.Element) internal.FixedBase {
+	return &FixedBase{table: internal.NewEdwardsFixedBaseTable(cvtEl(e).e), montgomery: g.montgomery}
+}
+
+// Multiply returns the scalar multiplication of the precomputed element with the given Scalar, in constant time.
+func (f *FixedBase) Multiply(s internal.Scalar) internal.Element {
+	out := newElement(f.montgomery)
+	f.table.Multiply(out.e, cvtScalar(s).s.Bytes())
+
+	return out
+}
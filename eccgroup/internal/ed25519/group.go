@@ -0,0 +1,200 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ed25519 implements the prime-order subgroups of edwards25519 and curve25519 with Elligator 2 hash-to-curve
+// operations and backend "filippo.io/edwards25519"
+package ed25519
+
+import (
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	"github.com/cymony/cryptomony/eccgroup/internal"
+	"github.com/cymony/cryptomony/hash"
+	"github.com/cymony/cryptomony/msgexpand"
+)
+
+// Group represents the prime-order subgroup of edwards25519, or of curve25519 when montgomery is set.
+// It exposes a prime-order group API with hash-to-curve operations.
+type Group struct {
+	montgomery bool
+}
+
+// Edwards25519 returns a new instantiation of the edwards25519 Group.
+func Edwards25519() internal.Group {
+	return &Group{}
+}
+
+// Curve25519 returns a new instantiation of the curve25519 Group.
+func Curve25519() internal.Group {
+	return &Group{montgomery: true}
+}
+
+// NewScalar returns a new, empty, scalar.
+func (g *Group) NewScalar() internal.Scalar {
+	return newScalar()
+}
+
+// NewElement returns the identity element (point at infinity).
+func (g *Group) NewElement() internal.Element {
+	return newElement(g.montgomery)
+}
+
+// RandomScalar returns randomly generated scalar.
+func (g *Group) RandomScalar() internal.Scalar {
+	return g.NewScalar().Random()
+}
+
+// RandomElement returns randomly generated element.
+func (g *Group) RandomElement() internal.Element {
+	return g.NewElement().Base().Multiply(g.RandomScalar())
+}
+
+// Base returns the group's base point a.k.a. canonical generator.
+func (g *Group) Base() internal.Element {
+	return g.NewElement().Base()
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to a Scalar.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group) HashToScalar(input, dst []byte) internal.Scalar {
+	uniform := expand(input, dst, secLength)
+
+	// uniform is a big-endian integer, SetUniformBytes expects 64 little-endian bytes
+	wide := make([]byte, uniformSize)
+	for i := range uniform {
+		wide[i] = uniform[len(uniform)-1-i]
+	}
+
+	sc, err := cvtScalar(newScalar()).SetUniformBytes(wide)
+	if err != nil {
+		panic(err)
+	}
+
+	return sc
+}
+
+// HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group) HashToGroup(input, dst []byte) internal.Element {
+	u := hashToField(input, dst, 2) //nolint:gomnd //two field elements
+
+	q0, q1 := edwards25519.NewIdentityPoint(), edwards25519.NewIdentityPoint()
+	mapToCurveElligator2(q0, u[0])
+	mapToCurveElligator2(q1, u[1])
+
+	el := newElement(g.montgomery)
+	el.e.Add(q0, q1).MultByCofactor(el.e)
+
+	return el
+}
+
+// EncodeToGroup returns a non-uniform mapping of the arbitrary input to an Element in the Group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group) EncodeToGroup(input, dst []byte) internal.Element {
+	u := hashToField(input, dst, 1)
+
+	el := newElement(g.montgomery)
+	mapToCurveElligator2(el.e, u[0])
+	el.e.MultByCofactor(el.e)
+
+	return el
+}
+
+// MultiScalarMult returns the sum of the element-wise products of scalars and elements.
+// Small batches use Straus' method, large batches use Pippenger's method.
+// It runs in variable time and must only be used with public scalars.
+func (g *Group) MultiScalarMult(scalars []internal.Scalar, elements []internal.Element) internal.Element {
+	if len(scalars) != len(elements) {
+		panic(internal.ErrLengthMismatch)
+	}
+
+	out := newElement(g.montgomery)
+	points := make([]*edwards25519.Point, len(elements))
+
+	for i := range elements {
+		points[i] = cvtEl(elements[i]).e
+	}
+
+	if len(elements) < internal.StrausThreshold {
+		ss := make([]*edwards25519.Scalar, len(scalars))
+		for i := range scalars {
+			ss[i] = cvtScalar(scalars[i]).s
+		}
+
+		out.e.VarTimeMultiScalarMult(ss, points)
+
+		return out
+	}
+
+	ss := make([][]byte, len(scalars))
+	for i := range scalars {
+		ss[i] = cvtScalar(scalars[i]).s.Bytes()
+	}
+
+	internal.MultiScalarMult(out.e, edwards25519.NewIdentityPoint, ss, points)
+
+	return out
+}
+
+// Ciphersuite returns the hash-to-curve ciphersuite identifier.
+func (g *Group) Ciphersuite() string {
+	if g.montgomery {
+		return H2CCurve25519
+	}
+
+	return H2CEdwards25519
+}
+
+// ScalarLength returns the byte size of an encoded scalar.
+func (g *Group) ScalarLength() uint {
+	return canonicalSize
+}
+
+// ElementLength returns the byte size of an encoded element.
+func (g *Group) ElementLength() uint {
+	return canonicalSize
+}
+
+func expand(input, dst []byte, length int) []byte {
+	uniform, err := msgexpand.NewMessageExpandXMD(hash.SHA512).Expand(input, dst, length)
+	if err != nil {
+		panic(err)
+	}
+
+	return uniform
+}
+
+// hashToField implements hash_to_field for GF(2^255 - 19), reducing the uniform bytes in constant time.
+// See https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#name-hash_to_field-implementatio
+func hashToField(input, dst []byte, count int) []*field.Element {
+	uniform := expand(input, dst, count*secLength)
+	u := make([]*field.Element, count)
+
+	for i := range u {
+		// The big-endian integer x = x1 * 2^192 + x0 is reduced as x0 + x1 * 2^192, with 24 bytes halves.
+		tv := uniform[i*secLength : (i+1)*secLength]
+
+		var lo, hi [canonicalSize]byte
+
+		for j := 0; j < secLength/2; j++ {
+			lo[j] = tv[secLength-1-j]
+			hi[j] = tv[secLength/2-1-j]
+		}
+
+		x0, err := new(field.Element).SetBytes(lo[:])
+		if err != nil {
+			panic(err)
+		}
+
+		x1, err := new(field.Element).SetBytes(hi[:])
+		if err != nil {
+			panic(err)
+		}
+
+		u[i] = x0.Add(x0, x1.Multiply(x1, twoTo192))
+	}
+
+	return u
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ed25519
+
+import (
+	"testing"
+
+	"github.com/cymony/cryptomony/eccgroup/internal"
+	"github.com/cymony/cryptomony/internal/test"
+	"github.com/cymony/cryptomony/utils"
+)
+
+func TestEd25519(t *testing.T) {
+	for _, group := range []internal.Group{Edwards25519(), Curve25519()} {
+		g, ok := group.(*Group)
+		test.CheckOk(t, ok, "type assertion err")
+
+		t.Run(g.Ciphersuite()+"/Group/HashToScalarAndGroup", func(tt *testing.T) { testHashToXGroup(tt, g) })
+		t.Run(g.Ciphersuite()+"/Group/MultiScalarMult", func(tt *testing.T) { testMultiScalarMult(tt, g) })
+		t.Run(g.Ciphersuite()+"/Element/EncodeAndDecode", func(tt *testing.T) { testEncodeAndDecode(tt, g) })
+	}
+}
+
+func testHashToXGroup(t *testing.T, g *Group) {
+	t.Helper()
+
+	input := utils.RandomBytes(16)
+
+	err := test.CheckPanic(func() { g.HashToScalar(input, nil) })
+	test.CheckNoErr(t, err, "panic expected")
+
+	err = test.CheckPanic(func() { g.HashToGroup(input, []byte("shorter")) })
+	test.CheckNoErr(t, err, "panic expected")
+
+	dst := []byte("This is greater than recommended length")
+
+	sc := g.HashToScalar(input, dst)
+	if len(sc.Encode()) != int(g.ScalarLength()) {
+		test.Report(t, len(sc.Encode()), g.ScalarLength())
+	}
+
+	el := g.HashToGroup(input, dst)
+	if len(el.Encode()) != int(g.ElementLength()) {
+		test.Report(t, len(el.Encode()), g.ElementLength())
+	}
+
+	// The hashed elements are in the prime-order subgroup, so they decode.
+	for _, e := range []internal.Element{el, g.EncodeToGroup(input, dst)} {
+		err = g.NewElement().Decode(e.Encode())
+		test.CheckNoErr(t, err, "hashed element should decode")
+	}
+}
+
+func testMultiScalarMult(t *testing.T, g *Group) {
+	t.Helper()
+
+	for _, n := range []int{0, 1, 5, 40} {
+		scalars := make([]internal.Scalar, n)
+		elements := make([]internal.Element, n)
+		want := g.NewElement()
+
+		for i := 0; i < n; i++ {
+			scalars[i] = g.RandomScalar()
+			elements[i] = g.RandomElement()
+			want.Add(elements[i].Copy().Multiply(scalars[i]))
+		}
+
+		got := g.MultiScalarMult(scalars, elements)
+		if got.Equal(want) != 1 {
+			test.Report(t, got, want, "multi scalar multiplication mismatch")
+		}
+	}
+
+	err := test.CheckPanic(func() {
+		g.MultiScalarMult([]internal.Scalar{g.RandomScalar()}, nil)
+	})
+	test.CheckNoErr(t, err, "panic expected")
+}
+
+func testEncodeAndDecode(t *testing.T, g *Group) {
+	t.Helper()
+
+	for i := 0; i < 1<<6; i++ {
+		e := g.RandomElement()
+		d := g.NewElement()
+
+		err := d.Decode(e.Encode())
+		test.CheckNoErr(t, err, "decode err")
+
+		if d.Equal(e) != 1 {
+			test.Report(t, d, e, "decode<>encode roundtrip failed")
+		}
+
+		// The negation only differs in the sign bit.
+		n := e.Copy().Negate().Encode()
+		test.CheckOk(t, n[canonicalSize-1]^e.Encode()[canonicalSize-1] == 0x80, "negation should flip the sign bit")
+	}
+
+	err := g.NewElement().Decode(make([]byte, canonicalSize-1))
+	test.CheckIsErr(t, err, "expected error for short encoding")
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ed25519
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+
+	"filippo.io/edwards25519"
+
+	"github.com/cymony/cryptomony/internal/test"
+)
+
+const (
+	edwardsBaseEncoding        = "5866666666666666666666666666666666666666666666666666666666666666"
+	montgomeryBaseEncoding     = "0900000000000000000000000000000000000000000000000000000000000000"
+	edwardsIdentityEncoding    = "0100000000000000000000000000000000000000000000000000000000000000"
+	montgomeryIdentityEncoding = "0000000000000000000000000000000000000000000000000000000000000000"
+	edwardsOrderTwo            = "ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"
+)
+
+func TestBasepointRoundTrip(t *testing.T) {
+	for _, v := range []struct {
+		montgomery bool
+		encoding   string
+		identity   string
+	}{
+		{false, edwardsBaseEncoding, edwardsIdentityEncoding},
+		{true, montgomeryBaseEncoding, montgomeryIdentityEncoding},
+	} {
+		encoding, err := hex.DecodeString(v.encoding)
+		test.CheckNoErr(t, err, "bad hex encoding")
+
+		decodedBasepoint := newElement(v.montgomery)
+
+		err = decodedBasepoint.Decode(encoding)
+		test.CheckNoErr(t, err, "decode err")
+
+		basepoint := newElement(v.montgomery).Base()
+		if decodedBasepoint.Equal(basepoint) != 1 {
+			t.Error("decode succeeded, but got wrong point")
+		}
+
+		if !bytes.Equal(encoding, basepoint.Encode()) {
+			t.Error("point encode produced different results")
+		}
+
+		if hex.EncodeToString(newElement(v.montgomery).Identity().Encode()) != v.identity {
+			t.Error("identity encode produced different results")
+		}
+	}
+}
+
+func TestDecodeRejects(t *testing.T) {
+	torsion, err := hex.DecodeString(edwardsOrderTwo)
+	test.CheckNoErr(t, err, "bad hex encoding")
+
+	orderTwo, err := edwards25519.NewIdentityPoint().SetBytes(torsion)
+	test.CheckNoErr(t, err, "torsion point decode err")
+
+	// The generator plus a point of order two is on the curve, but not in the prime-order subgroup.
+	mixed := edwards25519.NewGeneratorPoint()
+	mixed.Add(mixed, orderTwo)
+
+	for _, v := range []struct {
+		name       string
+		montgomery bool
+		encoding   string
+		err        error
+	}{
+		{"edwards/order two", false, edwardsOrderTwo, ErrNotInSubgroup},
+		{"edwards/mixed order", false, hex.EncodeToString(mixed.Bytes()), ErrNotInSubgroup},
+		{"edwards/non-canonical identity", false, "eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f", ErrInvalidEncoding},
+		{"edwards/negative zero x", false, "0100000000000000000000000000000000000000000000000000000000000080", ErrInvalidEncoding},
+		{"montgomery/order four", true, "0100000000000000000000000000000000000000000000000000000000000000", ErrNotInSubgroup},
+		{"montgomery/mixed order", true, hex.EncodeToString((&Element{e: mixed, montgomery: true}).Encode()), ErrNotInSubgroup},
+		{"montgomery/non-canonical u", true, "f6ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f", ErrInvalidEncoding},
+		{"montgomery/not on curve", true, "0200000000000000000000000000000000000000000000000000000000000000", ErrInvalidEncoding},
+		{"montgomery/negative zero v", true, "0000000000000000000000000000000000000000000000000000000000000080", ErrInvalidEncoding},
+	} {
+		encoding, err := hex.DecodeString(v.encoding)
+		test.CheckNoErr(t, err, fmt.Sprintf("%s: bad hex encoding", v.name))
+
+		err = newElement(v.montgomery).Decode(encoding)
+		test.CheckOk(t, errors.Is(err, v.err), fmt.Sprintf("%s: got %v, want %v", v.name, err, v.err))
+	}
+}
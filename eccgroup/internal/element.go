@@ -60,3 +60,20 @@ type Element interface {
 	// TextUnmarshaler implementation.
 	encoding.TextUnmarshaler
 }
+
+// SEC1Element is implemented by elements of short Weierstrass curves, which have several SEC 1 encodings.
+type SEC1Element interface {
+	// EncodeUncompressed returns the SEC 1 uncompressed encoding of the element.
+	EncodeUncompressed() []byte
+
+	// EncodeX returns the x-coordinate of the element, and an error for the point at infinity.
+	EncodeX() ([]byte, error)
+
+	// DecodeAny sets the receiver to a decoding of the compressed, uncompressed, hybrid or infinity SEC 1 encoding
+	// in data, and returns an error on failure.
+	DecodeAny(data []byte) error
+
+	// DecodeX sets the receiver to the element with the given x-coordinate and an even y-coordinate, and returns an
+	// error on failure.
+	DecodeX(data []byte) error
+}
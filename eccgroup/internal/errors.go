@@ -21,4 +21,13 @@ var (
 
 	// ErrWrongField indicates an incompatible field has been encountered.
 	ErrWrongField = errors.New("incompatible field (different prime)")
+
+	// ErrLengthMismatch indicates that scalars and elements of a multi-scalar multiplication have different lengths.
+	ErrLengthMismatch = errors.New("mismatch lengths of scalars and elements")
+
+	// ErrUniformLength indicates that the input of a map to elements does not have the expected length.
+	ErrUniformLength = errors.New("invalid length of uniform bytes")
+
+	// ErrPreimageUnsupported indicates that the group can map bytes to elements, but can not invert the map.
+	ErrPreimageUnsupported = errors.New("element preimages are not supported by the group")
 )
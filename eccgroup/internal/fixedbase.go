@@ -0,0 +1,142 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"crypto/subtle"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+const fixedBaseWindow = 4
+
+// FixedBase interface represents a precomputed table of multiples of an element.
+type FixedBase interface {
+	// Multiply returns the scalar multiplication of the precomputed element with the given Scalar.
+	Multiply(s Scalar) Element
+}
+
+// FixedBasePoint is the generic constraint for curve points used by the fixed-base table.
+// It is satisfied by the nistec points.
+type FixedBasePoint[P any] interface {
+	MSMPoint[P]
+	// Select sets the receiver to p1 if cond == 1, and to p2 if cond == 0, and returns it.
+	Select(p1, p2 P, cond int) P
+}
+
+// FixedBaseTable holds the multiples j * 16^i * base, for all the 4-bit windows i of a scalar and 0 <= j < 16.
+type FixedBaseTable[P FixedBasePoint[P]] struct {
+	identity func() P
+	table    [][1 << fixedBaseWindow]P
+}
+
+// NewFixedBaseTable precomputes the table of base for scalars of scalarLength bytes.
+func NewFixedBaseTable[P FixedBasePoint[P]](identity func() P, base P, scalarLength int) *FixedBaseTable[P] {
+	t := &FixedBaseTable[P]{
+		identity: identity,
+		table:    make([][1 << fixedBaseWindow]P, windowCount(scalarLength)),
+	}
+
+	b := identity().Set(base)
+
+	for i := range t.table {
+		row := &t.table[i]
+		row[0] = identity()
+
+		for j := 1; j < len(row); j++ {
+			row[j] = identity().Add(row[j-1], b)
+		}
+
+		// b = 16^(i+1) * base
+		b = identity().Add(row[len(row)-1], b)
+	}
+
+	return t
+}
+
+// Multiply computes scalar * base, and writes the result in dst, which is returned. The scalar is a little-endian
+// byte encoding of at most scalarLength bytes. The table lookups and additions run in constant time.
+func (t *FixedBaseTable[P]) Multiply(dst P, scalar []byte) P {
+	acc, sel := t.identity(), t.identity()
+
+	for i := range t.table {
+		d := digit(scalar, i*fixedBaseWindow, fixedBaseWindow)
+
+		sel.Set(t.table[i][0])
+
+		for j := 1; j < len(t.table[i]); j++ {
+			sel.Select(t.table[i][j], sel, subtle.ConstantTimeEq(int32(d), int32(j)))
+		}
+
+		acc.Add(acc, sel)
+	}
+
+	return dst.Set(acc)
+}
+
+// EdwardsFixedBaseTable is the fixed-base table of an edwards25519 point. The multiples are kept in extended
+// coordinates, so that the constant-time lookups only operate on field elements.
+type EdwardsFixedBaseTable struct {
+	table [][1 << fixedBaseWindow][4]field.Element
+}
+
+// NewEdwardsFixedBaseTable precomputes the table of base for 32 bytes scalars.
+func NewEdwardsFixedBaseTable(base *edwards25519.Point) *EdwardsFixedBaseTable {
+	const scalarLength = 32
+
+	t := &EdwardsFixedBaseTable{table: make([][1 << fixedBaseWindow][4]field.Element, windowCount(scalarLength))}
+	b := edwards25519.NewIdentityPoint().Set(base)
+	p := edwards25519.NewIdentityPoint()
+
+	for i := range t.table {
+		p.Set(edwards25519.NewIdentityPoint())
+
+		for j := range t.table[i] {
+			X, Y, Z, T := p.ExtendedCoordinates()
+			t.table[i][j] = [4]field.Element{*X, *Y, *Z, *T}
+
+			p.Add(p, b)
+		}
+
+		// b = 16^(i+1) * base
+		b.Set(p)
+	}
+
+	return t
+}
+
+// Multiply computes scalar * base, and writes the result in dst, which is returned. The scalar is the 32 bytes
+// little-endian encoding of an edwards25519 scalar. The table lookups and additions run in constant time.
+func (t *EdwardsFixedBaseTable) Multiply(dst *edwards25519.Point, scalar []byte) *edwards25519.Point {
+	acc, sel := edwards25519.NewIdentityPoint(), edwards25519.NewIdentityPoint()
+
+	var c [4]field.Element
+
+	for i := range t.table {
+		d := digit(scalar, i*fixedBaseWindow, fixedBaseWindow)
+		c = t.table[i][0]
+
+		for j := 1; j < len(t.table[i]); j++ {
+			cond := subtle.ConstantTimeEq(int32(d), int32(j))
+
+			for k := range c {
+				c[k].Select(&t.table[i][j][k], &c[k], cond)
+			}
+		}
+
+		if _, err := sel.SetExtendedCoordinates(&c[0], &c[1], &c[2], &c[3]); err != nil {
+			panic(err)
+		}
+
+		acc.Add(acc, sel)
+	}
+
+	return dst.Set(acc)
+}
+
+func windowCount(scalarLength int) int {
+	return (scalarLength*8 + fixedBaseWindow - 1) / fixedBaseWindow
+}
@@ -5,6 +5,8 @@
 // Package internal wraps all nist and ristretto255 curves into interfaces
 package internal
 
+import "github.com/cymony/cryptomony/msgexpand"
+
 // Group interface represents the prime-order group
 type Group interface {
 	// NewScalar returns a new, empty, scalar.
@@ -34,6 +36,13 @@ type Group interface {
 	// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 	EncodeToGroup(input, dst []byte) Element
 
+	// MultiScalarMult returns the sum of the element-wise products of scalars and elements.
+	// It runs in variable time and must only be used with public scalars.
+	MultiScalarMult(scalars []Scalar, elements []Element) Element
+
+	// NewFixedBase returns a precomputed table of multiples of the element, for faster scalar multiplications.
+	NewFixedBase(e Element) FixedBase
+
 	// Ciphersuite returns the hash-to-curve ciphersuite identifier.
 	Ciphersuite() string
 
@@ -43,3 +52,34 @@ type Group interface {
 	// ElementLength returns the byte size of an encoded element.
 	ElementLength() uint
 }
+
+// Mapper is implemented by groups exposing the map from uniform bytes to elements used by HashToGroup,
+// and optionally its inverse.
+type Mapper interface {
+	// UniformLength returns the byte size of the uniform input of MapToElement.
+	UniformLength() uint
+
+	// MapToElement deterministically maps the uniform bytes to an element, as HashToGroup does with the expanded
+	// input. It returns an error if uniform is not UniformLength bytes long.
+	MapToElement(uniform []byte) (Element, error)
+
+	// Preimage returns uniformly random bytes that MapToElement maps to the element, or ErrPreimageUnsupported.
+	Preimage(e Element) ([]byte, error)
+}
+
+// Expandable is implemented by groups whose hash-to-curve operations can use any message expander, instead of the
+// one fixed by their ciphersuite.
+type Expandable interface {
+	// SuiteID returns the hash-to-curve suite identifier of the group with the expander identifier, e.g.
+	// XOF:SHAKE-256, or its encode-to-curve suite identifier if nonUniform is set.
+	SuiteID(expanderID string, nonUniform bool) string
+
+	// HashToScalarWith is HashToScalar with the expander.
+	HashToScalarWith(expander msgexpand.MessageExpand, input, dst []byte) Scalar
+
+	// HashToGroupWith is HashToGroup with the expander.
+	HashToGroupWith(expander msgexpand.MessageExpand, input, dst []byte) Element
+
+	// EncodeToGroupWith is EncodeToGroup with the expander.
+	EncodeToGroupWith(expander msgexpand.MessageExpand, input, dst []byte) Element
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import "math/bits"
+
+const (
+	// StrausThreshold is the batch size from which Pippenger's method is preferred over Straus' method.
+	StrausThreshold = 64
+
+	strausWindow = 4
+)
+
+// MSMPoint is the generic constraint for curve points used by the multi-scalar multiplication algorithms.
+// It is satisfied by edwards25519.Point and nistec points.
+type MSMPoint[P any] interface {
+	// Add sets the receiver to p1 + p2, and returns it. The points may overlap.
+	Add(p1, p2 P) P
+	// Set sets the receiver to p, and returns it.
+	Set(p P) P
+}
+
+// MultiScalarMult computes sum(scalars[i] * points[i]) using Straus' method for small batches
+// and Pippenger's bucket method for large ones. Scalars are little-endian byte encodings of the same length.
+// The result is written in dst, which is returned.
+//
+// The computation runs in variable time with respect to the scalars, it must only be used with public values.
+func MultiScalarMult[P MSMPoint[P]](dst P, identity func() P, scalars [][]byte, points []P) P {
+	if len(scalars) != len(points) {
+		panic(ErrLengthMismatch)
+	}
+
+	if len(points) < StrausThreshold {
+		return straus(dst, identity, scalars, points)
+	}
+
+	return pippenger(dst, identity, scalars, points)
+}
+
+// digit returns the window of width w starting at bit position pos of the little-endian scalar s.
+func digit(s []byte, pos, w int) int {
+	var d int
+
+	for i := 0; i < w; i++ {
+		bit := pos + i
+		if bit/8 >= len(s) {
+			break
+		}
+
+		d |= int(s[bit/8]>>(bit%8)&1) << i
+	}
+
+	return d
+}
+
+// straus implements interleaved fixed-window multi-scalar multiplication.
+func straus[P MSMPoint[P]](dst P, identity func() P, scalars [][]byte, points []P) P {
+	acc := identity()
+	if len(points) == 0 {
+		return dst.Set(acc)
+	}
+
+	// tables[i][j] = (j+1) * points[i]
+	tables := make([][]P, len(points))
+
+	for i, p := range points {
+		table := make([]P, 1<<strausWindow-1)
+		table[0] = identity().Set(p)
+
+		for j := 1; j < len(table); j++ {
+			table[j] = identity().Add(table[j-1], p)
+		}
+
+		tables[i] = table
+	}
+
+	windows := (len(scalars[0])*8 + strausWindow - 1) / strausWindow
+
+	for w := windows - 1; w >= 0; w-- {
+		if w != windows-1 {
+			for i := 0; i < strausWindow; i++ {
+				acc.Add(acc, acc)
+			}
+		}
+
+		for i := range points {
+			if d := digit(scalars[i], w*strausWindow, strausWindow); d != 0 {
+				acc.Add(acc, tables[i][d-1])
+			}
+		}
+	}
+
+	return dst.Set(acc)
+}
+
+// pippengerWindow returns the bucket window width for a batch of size n.
+func pippengerWindow(n int) int {
+	c := bits.Len(uint(n)) - 2
+	if c < strausWindow {
+		return strausWindow
+	}
+
+	return c
+}
+
+// pippenger implements the bucket method for multi-scalar multiplication.
+func pippenger[P MSMPoint[P]](dst P, identity func() P, scalars [][]byte, points []P) P {
+	c := pippengerWindow(len(points))
+	windows := (len(scalars[0])*8 + c - 1) / c
+
+	acc := identity()
+	buckets := make([]P, 1<<c-1)
+
+	for i := range buckets {
+		buckets[i] = identity()
+	}
+
+	id, running, windowSum := identity(), identity(), identity()
+
+	for w := windows - 1; w >= 0; w-- {
+		if w != windows-1 {
+			for i := 0; i < c; i++ {
+				acc.Add(acc, acc)
+			}
+		}
+
+		for i := range buckets {
+			buckets[i].Set(id)
+		}
+
+		for i := range points {
+			if d := digit(scalars[i], w*c, c); d != 0 {
+				buckets[d-1].Add(buckets[d-1], points[i])
+			}
+		}
+
+		// windowSum = sum((j+1) * buckets[j])
+		running.Set(id)
+		windowSum.Set(id)
+
+		for j := len(buckets) - 1; j >= 0; j-- {
+			running.Add(running, buckets[j])
+			windowSum.Add(windowSum, running)
+		}
+
+		acc.Add(acc, windowSum)
+	}
+
+	return dst.Set(acc)
+}
@@ -2,14 +2,15 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// Package nist implements nist's curves prime-order group
+// Package nist implements prime-order groups over nist's curves and secp256k1
 package nist
 
 import (
+	"bytes"
 	"math/big"
 
-	"github.com/cymony/cryptomony/h2f"
 	"github.com/cymony/cryptomony/hash"
+	"github.com/cymony/cryptomony/msgexpand"
 )
 
 func s2int(s string) *big.Int {
@@ -21,174 +22,390 @@ func s2int(s string) *big.Int {
 }
 
 type mapping struct {
-	z         *big.Int
+	z         limbs // Z in Montgomery representation
+	c1        limbs // (q - 3) / 4, used as public exponent
+	c2        limbs // sqrt(-Z) in Montgomery representation
 	secLength int
-	hash      hash.Hashing
+	expander  msgexpand.MessageExpand // expand_message_xmd with the hash function of the ciphersuite
+	iso       *isogeny                // nil when the SSWU mapping targets the curve itself
+}
+
+// isogeny is a rational map from the curve E' used by the SSWU mapping to the target curve E, for curves with
+// A = 0 or B = 0 where SSWU can not be applied directly.
+// See https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#name-simplified-swu-for-ab-0
+type isogeny struct {
+	a, b                   limbs   // coefficients of E' in Montgomery representation
+	xNum, xDen, yNum, yDen []limbs // coefficients of the map polynomials, in ascending degree order
 }
 
 type curve[point nistECGenericPoint[point]] struct {
-	field    *field
-	a, b     *big.Int
+	field    *montField
+	a, b     limbs // curve coefficients in Montgomery representation
 	NewPoint func() point
 	mapping
 }
 
 func (c *curve[point]) setMapping(h hash.Hashing, z string, secLength int) {
-	c.mapping.hash = h
+	c.mapping.expander = msgexpand.NewMessageExpandXMD(h)
 	c.mapping.secLength = secLength
-	c.mapping.z = s2int(z)
+	c.mapping.z = c.field.fromBig(s2int(z))
+
+	// c1 = (q - 3) / 4
+	c1 := new(big.Int).Sub(c.field.modulus, big.NewInt(3)) //nolint:gomnd //no need constant
+	c.mapping.c1 = bigToLimbs(c1.Rsh(c1, 2))
+
+	// c2 = sqrt(-Z), with sqrt(x) = x^((q + 1) / 4) = x^(c1 + 1)
+	var nZ limbs
+
+	c.field.neg(&nZ, &c.mapping.z)
+	c.field.exp(&c.mapping.c2, &nZ, &c.mapping.c1)
+	c.field.mul(&c.mapping.c2, &c.mapping.c2, &nZ)
+}
+
+// setIsogeny makes the SSWU mapping target the curve y^2 = x^3 + a*x + b, and sets the isogeny map to the curve
+// from the given polynomial coefficients in ascending degree order. The leading coefficients of the monic
+// denominators must be included.
+func (c *curve[point]) setIsogeny(a, b string, xNum, xDen, yNum, yDen []string) {
+	coefficients := func(s []string) []limbs {
+		l := make([]limbs, len(s))
+		for i := range s {
+			l[i] = c.field.fromBig(s2int(s[i]))
+		}
+
+		return l
+	}
+
+	c.mapping.iso = &isogeny{
+		a:    c.field.fromBig(s2int(a)),
+		b:    c.field.fromBig(s2int(b)),
+		xNum: coefficients(xNum),
+		xDen: coefficients(xDen),
+		yNum: coefficients(yNum),
+		yDen: coefficients(yDen),
+	}
 }
 
 func (c *curve[point]) setCurveParams(prime *big.Int, a, b string, newPoint func() point) {
-	c.field = newField(prime)
-	c.a = s2int(a)
-	c.b = s2int(b)
+	c.field = newMontField(prime)
+	c.a = c.field.fromBig(s2int(a))
+	c.b = c.field.fromBig(s2int(b))
 	c.NewPoint = newPoint
 }
 
-func (c *curve[point]) encodeToCurveXMD(input, dst []byte) point {
-	u, err := h2f.Hash2FieldXMD(c.hash, input, dst, 1, 1, c.secLength, c.field.primeNumber)
+// hashToField implements hash_to_field with the expander, reducing the uniform bytes in constant time.
+// See https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#name-hash_to_field-implementatio
+func hashToField(f *montField, expander msgexpand.MessageExpand, input, dst []byte, count, secLength int) []limbs {
+	uniform, err := expander.Expand(input, dst, count*secLength)
 	if err != nil {
 		panic(err)
 	}
 
-	q := c.map2curveSSWU(u[0])
+	u := make([]limbs, count)
+
+	for i := range u {
+		f.setWideBytes(&u[i], uniform[i*secLength:(i+1)*secLength])
+	}
 
-	return q
+	return u
 }
 
-func (c *curve[point]) hashToCurveXMD(input, dst []byte) point {
-	u, err := h2f.Hash2FieldXMD(c.hash, input, dst, 2, 1, c.secLength, c.field.primeNumber)
+func (c *curve[point]) encodeToCurve(expander msgexpand.MessageExpand, input, dst []byte) point {
+	u := hashToField(c.field, expander, input, dst, 1, c.secLength)
+
+	return c.map2curveSSWU(&u[0])
+}
+
+func (c *curve[point]) hashToCurve(expander msgexpand.MessageExpand, input, dst []byte) point {
+	uniform, err := expander.Expand(input, dst, 2*c.secLength) //nolint:gomnd //two field elements
 	if err != nil {
 		panic(err)
 	}
 
-	q0 := c.map2curveSSWU(u[0])
-	q1 := c.map2curveSSWU(u[1])
+	return c.mapToCurve(uniform)
+}
+
+// mapToCurve maps 2*secLength uniform bytes to a point, as hashToCurve does with the expanded message.
+func (c *curve[point]) mapToCurve(uniform []byte) point {
+	var u0, u1 limbs
+
+	c.field.setWideBytes(&u0, uniform[:c.secLength])
+	c.field.setWideBytes(&u1, uniform[c.secLength:])
+
+	q0 := c.map2curveSSWU(&u0)
+	q1 := c.map2curveSSWU(&u1)
 
 	return q0.Add(q0, q1)
 }
 
+// affine returns the affine coordinates of p, and false for the point at infinity.
+func (c *curve[point]) affine(p point) (x, y limbs, ok bool) {
+	enc := p.Bytes()
+	if len(enc) == 1 {
+		return x, y, false
+	}
+
+	byteLen := c.field.byteLen
+	c.field.setBytes(&x, enc[1:1+byteLen])
+	c.field.setBytes(&y, enc[1+byteLen:])
+
+	return x, y, true
+}
+
+// solveQuadratic returns the roots of T^2 + b*T + c in the field. It is not constant-time.
+func (c *curve[point]) solveQuadratic(b, cc *limbs) []limbs {
+	f := c.field
+
+	var disc, four, twoInv limbs
+
+	// disc = b^2 - 4*c
+	f.add(&four, &f.one, &f.one)
+	f.add(&four, &four, &four)
+	f.mul(&four, &four, cc)
+	f.square(&disc, b)
+	f.sub(&disc, &disc, &four)
+
+	isQR, sqrtDisc := c.sqrtRatio3mod4(&disc, &f.one)
+	if isQR == 0 {
+		return nil
+	}
+
+	f.add(&twoInv, &f.one, &f.one)
+	f.inv(&twoInv, &twoInv)
+
+	// T = (-b +- sqrt(disc)) / 2
+	roots := make([]limbs, 2) //nolint:gomnd //two roots
+	f.sub(&roots[0], &sqrtDisc, b)
+	f.mul(&roots[0], &roots[0], &twoInv)
+	f.neg(&roots[1], &sqrtDisc)
+	f.sub(&roots[1], &roots[1], b)
+	f.mul(&roots[1], &roots[1], &twoInv)
+
+	if f.isZero(&disc) == 1 {
+		return roots[:1]
+	}
+
+	return roots
+}
+
+// sswuPreimages returns all the field elements u such that map2curveSSWU(u) is q, for curves without isogeny.
+// With T = Z * u^2 and k = -A * x / B, the x-coordinate of q is x1 when T^2 + T + 1 / (1 - k) = 0,
+// and x2 = T * x1 when T^2 + (1 - k) * T + (1 - k) = 0. The candidates are checked with the forward map,
+// so that exceptional cases are discarded. It is not constant-time.
+func (c *curve[point]) sswuPreimages(q point) []limbs {
+	f := c.field
+
+	x, y, ok := c.affine(q)
+	if !ok {
+		return nil
+	}
+
+	var k, oneMinusK, invOneMinusK limbs
+
+	f.inv(&k, &c.b)
+	f.mul(&k, &k, &c.a)
+	f.mul(&k, &k, &x)
+	f.neg(&k, &k)
+	f.sub(&oneMinusK, &f.one, &k)
+	f.inv(&invOneMinusK, &oneMinusK)
+
+	ts := c.solveQuadratic(&f.one, &invOneMinusK)
+	ts = append(ts, c.solveQuadratic(&oneMinusK, &oneMinusK)...)
+
+	enc := q.Bytes()
+	preimages := make([]limbs, 0, len(ts))
+
+	for i := range ts {
+		// u = sqrt(T / Z), with the sign of y
+		isQR, u := c.sqrtRatio3mod4(&ts[i], &c.z)
+		if isQR == 0 {
+			continue
+		}
+
+		if c.sgn0(&u) != c.sgn0(&y) {
+			f.neg(&u, &u)
+		}
+
+		if !bytes.Equal(c.map2curveSSWU(&u).Bytes(), enc) {
+			continue
+		}
+
+		duplicate := false
+		for j := range preimages {
+			duplicate = duplicate || f.equal(&preimages[j], &u) == 1
+		}
+
+		if !duplicate {
+			preimages = append(preimages, u)
+		}
+	}
+
+	return preimages
+}
+
+// sgn0 returns the parity of the canonical integer represented by x.
+func (c *curve[point]) sgn0(x *limbs) uint64 {
+	var canonical limbs
+
+	c.field.fromMont(&canonical, x)
+
+	return canonical[0] & 1
+}
+
 // sqrtRatio3mod4 optimized sqrt_ratio function for q = 3 mod 4 curves.
 // See https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html Appendinx F.2.1.2.
-func (c *curve[point]) sqrtRatio3mod4(u, v *big.Int) (bool, *big.Int) {
-	//nolint:gocritic // it is not commented code
-	// c1 = (q-3) / 4
-	c1 := new(big.Int)
-	c1.Sub(c.field.primeNumber, big.NewInt(3)) //nolint:gomnd //no need constant
-	c1.Rsh(c1, 2)                              //nolint:gomnd //no need constant
+func (c *curve[point]) sqrtRatio3mod4(u, v *limbs) (isQR uint64, y limbs) {
+	f := c.field
 
-	// c2 = sqrt(-Z)
-	nZ := c.field.neg(new(big.Int), c.z)
-	c2 := c.field.sqrt(new(big.Int), nZ)
+	var tv1, tv2, tv3, y1, y2 limbs
 
 	// 1. tv1 = v^2
-	tv1 := c.field.square(new(big.Int), v)
+	f.square(&tv1, v)
 	// 2. tv2 = u * v
-	tv2 := c.field.mul(new(big.Int), u, v)
+	f.mul(&tv2, u, v)
 	// 3. tv1 = tv1 * tv2
-	tv1 = c.field.mul(tv1, tv1, tv2)
+	f.mul(&tv1, &tv1, &tv2)
 	// 4. y1 = tv1^c1
-	y1 := c.field.exponent(new(big.Int), tv1, c1)
+	f.exp(&y1, &tv1, &c.c1)
 	// 5. y1 = y1 * tv2
-	y1 = c.field.mul(y1, y1, tv2)
+	f.mul(&y1, &y1, &tv2)
 	// 6. y2 = y1 * c2
-	y2 := c.field.mul(new(big.Int), y1, c2)
+	f.mul(&y2, &y1, &c.c2)
 	// 7. tv3 = y1^2
-	tv3 := c.field.square(new(big.Int), y1)
+	f.square(&tv3, &y1)
 	// 8. tv3 = tv3 * v
-	tv3 = c.field.mul(tv3, tv3, v)
+	f.mul(&tv3, &tv3, v)
 	// 9. isQR = tv3 == u
-	isQR := tv3.Cmp(u) == 0
+	isQR = f.equal(&tv3, u)
 	// 10. y = CMOV(y2, y1, isQR)
-	y := c.field.cmov(new(big.Int), y2, y1, isQR)
+	f.selectLimbs(&y, &y1, &y2, isQR)
 	// 11. return (isQR, y)
 	return isQR, y
 }
 
-// map2curveSSWU implements simplied swu method.
+// map2curveSSWU implements simplied swu method in constant time.
 // See https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html Appendinx F.2.
-func (c *curve[point]) map2curveSSWU(u *big.Int) point {
+func (c *curve[point]) map2curveSSWU(u *limbs) point {
+	f := c.field
+
+	a, b := &c.a, &c.b
+	if c.iso != nil {
+		a, b = &c.iso.a, &c.iso.b
+	}
+
+	var tv1, tv2, tv3, tv4, tv5, tv6, x, y, ny limbs
+
 	// 1.  tv1 = u^2
-	tv1 := c.field.square(new(big.Int), u)
+	f.square(&tv1, u)
 	// 2.  tv1 = Z * tv1
-	tv1 = c.field.mul(tv1, c.z, tv1)
+	f.mul(&tv1, &c.z, &tv1)
 	// 3.  tv2 = tv1^2
-	tv2 := c.field.square(new(big.Int), tv1)
+	f.square(&tv2, &tv1)
 	// 4.  tv2 = tv2 + tv1
-	tv2 = c.field.add(tv2, tv2, tv1)
+	f.add(&tv2, &tv2, &tv1)
 	// 5.  tv3 = tv2 + 1
-	tv3 := c.field.add(new(big.Int), tv2, one)
+	f.add(&tv3, &tv2, &f.one)
 	// 6.  tv3 = B * tv3
-	tv3 = c.field.mul(tv3, c.b, tv3)
+	f.mul(&tv3, b, &tv3)
 	// 7.  tv4 = CMOV(Z, -tv2, tv2 != 0)
-	tv4 := c.field.cmov(new(big.Int), c.z, c.field.neg(new(big.Int), tv2), !c.field.isZero(tv2))
+	f.neg(&tv4, &tv2)
+	f.selectLimbs(&tv4, &c.z, &tv4, f.isZero(&tv2))
 	// 8.  tv4 = A * tv4
-	tv4 = c.field.mul(tv4, c.a, tv4)
+	f.mul(&tv4, a, &tv4)
 	// 9.  tv2 = tv3^2
-	tv2 = c.field.square(tv2, tv3)
+	f.square(&tv2, &tv3)
 	// 10. tv6 = tv4^2
-	tv6 := c.field.square(new(big.Int), tv4)
+	f.square(&tv6, &tv4)
 	// 11. tv5 = A * tv6
-	tv5 := c.field.mul(new(big.Int), c.a, tv6)
+	f.mul(&tv5, a, &tv6)
 	// 12. tv2 = tv2 + tv5
-	tv2 = c.field.add(tv2, tv2, tv5)
+	f.add(&tv2, &tv2, &tv5)
 	// 13. tv2 = tv2 * tv3
-	tv2 = c.field.mul(tv2, tv2, tv3)
+	f.mul(&tv2, &tv2, &tv3)
 	// 14. tv6 = tv6 * tv4
-	tv6 = c.field.mul(tv6, tv6, tv4)
+	f.mul(&tv6, &tv6, &tv4)
 	// 15. tv5 = B * tv6
-	tv5 = c.field.mul(tv5, c.b, tv6)
+	f.mul(&tv5, b, &tv6)
 	// 16. tv2 = tv2 + tv5
-	tv2 = c.field.add(tv2, tv2, tv5)
+	f.add(&tv2, &tv2, &tv5)
 	// 17.   x = tv1 * tv3
-	x := c.field.mul(new(big.Int), tv1, tv3)
+	f.mul(&x, &tv1, &tv3)
 	//nolint:gocritic // it is not commented code
 	// 18. (is_gx1_square, y1) = sqrt_ratio(tv2, tv6)
-	isGx1Square, y1 := c.sqrtRatio3mod4(tv2, tv6)
+	isGx1Square, y1 := c.sqrtRatio3mod4(&tv2, &tv6)
 	// 19.   y = tv1 * u
-	y := c.field.mul(new(big.Int), tv1, u)
+	f.mul(&y, &tv1, u)
 	// 20.   y = y * y1
-	y = c.field.mul(y, y, y1)
+	f.mul(&y, &y, &y1)
 	// 21.   x = CMOV(x, tv3, is_gx1_square)
-	x = c.field.cmov(x, x, tv3, isGx1Square)
+	f.selectLimbs(&x, &tv3, &x, isGx1Square)
 	// 22.   y = CMOV(y, y1, is_gx1_square)
-	y = c.field.cmov(y, y, y1, isGx1Square)
+	f.selectLimbs(&y, &y1, &y, isGx1Square)
 	// 23.  e1 = sgn0(u) == sgn0(y)
-	e1 := c.field.sgn0(u) == c.field.sgn0(y)
+	e1 := 1 ^ c.sgn0(u) ^ c.sgn0(&y)
 	// 24.   y = CMOV(-y, y, e1)
-	y = c.field.cmov(y, c.field.neg(new(big.Int), y), y, e1)
+	f.neg(&ny, &y)
+	f.selectLimbs(&y, &y, &ny, e1)
 	// 25.   x = x / tv4
-	tv4 = c.field.inv(tv4, tv4)
-	x = c.field.mul(x, x, tv4)
+	f.inv(&tv4, &tv4)
+	f.mul(&x, &x, &tv4)
 
-	return c.affineToPoint(x, y)
+	if c.iso != nil {
+		return c.isoMap(&x, &y)
+	}
+
+	return c.affineToPoint(&x, &y)
 }
 
-var (
-	decompressed256 = [65]byte{0x04}
-	decompressed384 = [97]byte{0x04}
-	decompressed521 = [133]byte{0x04}
-)
+// evalPolynomial sets z to the evaluation of the polynomial with the given coefficients at x, with Horner's method.
+func (c *curve[point]) evalPolynomial(z *limbs, coefficients []limbs, x *limbs) {
+	f := c.field
+
+	acc := coefficients[len(coefficients)-1]
+	for i := len(coefficients) - 2; i >= 0; i-- {
+		f.mul(&acc, &acc, x)
+		f.add(&acc, &acc, &coefficients[i])
+	}
 
-func (c *curve[point]) affineToPoint(pxc, pyc *big.Int) point {
-	var decompressed []byte
+	*z = acc
+}
+
+// isoMap maps the point (x, y) of E' to the target curve.
+// See https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#name-3-isogeny-map-for-secp256k1
+func (c *curve[point]) isoMap(x, y *limbs) point {
+	f := c.field
+
+	var xNum, xDen, yNum, yDen, isoX, isoY limbs
 
-	byteLen := (c.field.bitLen() + 7) / 8 //nolint:gomnd //no need constant
-	switch byteLen {
-	case 32: //nolint:gomnd //no need constant
-		decompressed = decompressed256[:]
-	case 48: //nolint:gomnd //no need constant
-		decompressed = decompressed384[:]
-	case 66: //nolint:gomnd //no need constant
-		decompressed = decompressed521[:]
-	default:
-		panic("invalid byte length")
+	c.evalPolynomial(&xNum, c.iso.xNum, x)
+	c.evalPolynomial(&xDen, c.iso.xDen, x)
+	c.evalPolynomial(&yNum, c.iso.yNum, x)
+	c.evalPolynomial(&yDen, c.iso.yDen, x)
+
+	// The exceptional points of the map, where a denominator vanishes, are sent to the identity.
+	// They are only reached with negligible probability, so the branch does not leak about honest inputs.
+	if f.isZero(&xDen)|f.isZero(&yDen) == 1 {
+		return c.NewPoint()
 	}
 
+	f.inv(&xDen, &xDen)
+	f.inv(&yDen, &yDen)
+
+	// x = x_num / x_den, y = y * y_num / y_den
+	f.mul(&isoX, &xNum, &xDen)
+	f.mul(&isoY, &yNum, &yDen)
+	f.mul(&isoY, &isoY, y)
+
+	return c.affineToPoint(&isoX, &isoY)
+}
+
+func (c *curve[point]) affineToPoint(x, y *limbs) point {
+	byteLen := c.field.byteLen
+
+	// uncompressed SEC 1 encoding: 0x04 || x || y
+	decompressed := make([]byte, 1+2*byteLen)
 	decompressed[0] = 0x04
-	pxc.FillBytes(decompressed[1 : 1+byteLen])
-	pyc.FillBytes(decompressed[1+byteLen:])
+	copy(decompressed[1:1+byteLen], c.field.bytes(x))
+	copy(decompressed[1+byteLen:], c.field.bytes(y))
 
 	p, err := c.NewPoint().SetBytes(decompressed)
 	if err != nil {
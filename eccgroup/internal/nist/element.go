@@ -14,8 +14,13 @@ import (
 
 // Element implements the Element interface for group elements over NIST curves.
 type Element[Point nistECGenericPoint[Point]] struct {
-	p   Point
-	new func() Point
+	p     Point
+	new   func() Point
+	field *montField // scalar field of the curve
+	// base reports whether p is the generator, to use the precomputed generator table in Multiply.
+	base bool
+	// scalar holds the scalar encoding of MultiplyInto, which escapes to the heap through the generic point methods.
+	scalar [8 * maxLimbs]byte
 }
 
 func checkElement[Point nistECGenericPoint[Point]](element internal.Element) *Element[Point] {
@@ -34,12 +39,16 @@ func checkElement[Point nistECGenericPoint[Point]](element internal.Element) *El
 // Base sets the element to the group's base point a.k.a. canonical generator.
 func (e *Element[Point]) Base() internal.Element {
 	e.p.SetGenerator()
+	e.base = true
+
 	return e
 }
 
 // Identity sets the element to the point at infinity of the Group's underlying curve.
 func (e *Element[Point]) Identity() internal.Element {
 	e.p = e.new()
+	e.base = false
+
 	return e
 }
 
@@ -47,6 +56,7 @@ func (e *Element[Point]) Identity() internal.Element {
 func (e *Element[Point]) Add(element internal.Element) internal.Element {
 	ec := checkElement[Point](element)
 	e.p.Add(e.p, ec.p)
+	e.base = false
 
 	return e
 }
@@ -54,6 +64,8 @@ func (e *Element[Point]) Add(element internal.Element) internal.Element {
 // Double sets the receiver to its double, and returns it.
 func (e *Element[Point]) Double() internal.Element {
 	e.p.Double(e.p)
+	e.base = false
+
 	return e
 }
 
@@ -79,6 +91,8 @@ func (e *Element[P]) Negate() internal.Element {
 		panic(err)
 	}
 
+	e.base = false
+
 	return e
 }
 
@@ -92,16 +106,24 @@ func (e *Element[P]) Subtract(element internal.Element) internal.Element {
 	}
 
 	e.p.Add(e.p, p)
+	e.base = false
 
 	return e
 }
 
 // Multiply sets the receiver to the scalar multiplication of the receiver with the given Scalar, and returns it.
+// The generator is multiplied with the precomputed tables of the backend.
 func (e *Element[P]) Multiply(scalar internal.Scalar) internal.Element {
-	if _, err := e.p.ScalarMult(e.p, scalar.Encode()); err != nil {
+	if e.base {
+		if _, err := e.p.ScalarBaseMult(scalar.Encode()); err != nil {
+			panic(err)
+		}
+	} else if _, err := e.p.ScalarMult(e.p, scalar.Encode()); err != nil {
 		panic(err)
 	}
 
+	e.base = false
+
 	return e
 }
 
@@ -138,6 +160,7 @@ func (e *Element[P]) Set(element internal.Element) internal.Element {
 	}
 
 	e.p = p
+	e.base = ec.base
 
 	return e
 }
@@ -145,8 +168,10 @@ func (e *Element[P]) Set(element internal.Element) internal.Element {
 // Copy returns a copy of the receiver.
 func (e *Element[P]) Copy() internal.Element {
 	return &Element[P]{
-		p:   e.new().Set(e.p),
-		new: e.new,
+		p:     e.new().Set(e.p),
+		new:   e.new,
+		field: e.field,
+		base:  e.base,
 	}
 }
 
@@ -161,9 +186,57 @@ func (e *Element[P]) Decode(data []byte) error {
 		return fmt.Errorf("nist element Decode: %w", err)
 	}
 
+	e.base = false
+
 	return nil
 }
 
+// EncodeUncompressed returns the SEC 1 uncompressed encoding 0x04 || x || y of the element, or the single
+// 0x00 byte for the point at infinity.
+func (e *Element[P]) EncodeUncompressed() []byte {
+	return e.p.Bytes()
+}
+
+// EncodeX returns the x-coordinate of the element, and an error for the point at infinity.
+// The encoding identifies the element up to its sign, see DecodeX.
+func (e *Element[P]) EncodeX() ([]byte, error) {
+	x, err := e.p.BytesX()
+	if err != nil {
+		return nil, fmt.Errorf("nist element EncodeX: %w", err)
+	}
+
+	return x, nil
+}
+
+// DecodeAny sets the receiver to a decoding of the compressed, uncompressed, hybrid or infinity SEC 1 encoding in
+// data, and returns an error on failure. The decoded point is always checked to be on the curve.
+func (e *Element[P]) DecodeAny(data []byte) error {
+	if len(data) > 0 && (data[0] == 0x06 || data[0] == 0x07) {
+		// hybrid encodings are uncompressed encodings whose prefix also carries the parity of y
+		if len(data)%2 != 1 || data[len(data)-1]&1 != data[0]&1 {
+			return fmt.Errorf("nist element DecodeAny: %w", ErrInvalidSEC1Encoding)
+		}
+
+		uncompressed := make([]byte, len(data))
+		copy(uncompressed, data)
+		uncompressed[0] = 0x04
+
+		data = uncompressed
+	}
+
+	return e.Decode(data)
+}
+
+// DecodeX sets the receiver to the element with the given x-coordinate and an even y-coordinate, and returns an
+// error on failure.
+func (e *Element[P]) DecodeX(data []byte) error {
+	compressed := make([]byte, 1+len(data))
+	compressed[0] = 0x02
+	copy(compressed[1:], data)
+
+	return e.Decode(compressed)
+}
+
 // MarshalBinary returns the compressed byte encoding of the element.
 func (e *Element[P]) MarshalBinary() ([]byte, error) {
 	return e.Encode(), nil
@@ -0,0 +1,24 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nist
+
+import "errors"
+
+var (
+	// ErrInvalidSecp256k1Encoding returns when passed unsuitable data for secp256k1 point decoding
+	ErrInvalidSecp256k1Encoding = errors.New("invalid secp256k1 point encoding")
+
+	// ErrSecp256k1NotOnCurve returns when the decoded coordinates are not a point of secp256k1
+	ErrSecp256k1NotOnCurve = errors.New("secp256k1 point not on curve")
+
+	// ErrSecp256k1Infinity returns when the x-coordinate of the secp256k1 point at infinity is requested
+	ErrSecp256k1Infinity = errors.New("secp256k1 point is the point at infinity")
+
+	// ErrInvalidSEC1Encoding returns when passed data is not a compressed, uncompressed, hybrid or infinity encoding
+	ErrInvalidSEC1Encoding = errors.New("invalid SEC 1 point encoding")
+
+	// ErrInvalidSecp256k1Scalar returns when the scalar passed to secp256k1 scalar multiplication is not 32 bytes long
+	ErrInvalidSecp256k1Scalar = errors.New("invalid secp256k1 scalar length")
+)
@@ -0,0 +1,43 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nist
+
+import (
+	"github.com/cymony/cryptomony/eccgroup/internal"
+)
+
+// FixedBase is a precomputed table of multiples of an element of a NIST group.
+type FixedBase[P nistECGenericPoint[P]] struct {
+	g     Group[P]
+	table *internal.FixedBaseTable[P]
+}
+
+// NewFixedBase returns a precomputed table of multiples of the element, for faster scalar multiplications.
+func (g Group[P]) NewFixedBase(element internal.Element) internal.FixedBase { //nolint:gocritic //it is dynamic type
+	return &FixedBase[P]{
+		g:     g,
+		table: internal.NewFixedBaseTable(g.curve.NewPoint, checkElement[P](element).p, g.scalarField.byteLen),
+	}
+}
+
+// Multiply returns the scalar multiplication of the precomputed element with the given Scalar, in constant time.
+func (f *FixedBase[P]) Multiply(scalar internal.Scalar) internal.Element {
+	sc, ok := scalar.(*Scalar)
+	if !ok {
+		panic(internal.ErrCastScalar)
+	}
+
+	return f.g.newPoint(f.table.Multiply(f.g.curve.NewPoint(), reverse(sc.Encode())))
+}
+
+// reverse returns a copy of b in reverse order, to convert between big-endian and little-endian encodings.
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[i] = b[len(b)-1-i]
+	}
+
+	return out
+}
@@ -11,8 +11,9 @@ import (
 	"filippo.io/nistec"
 
 	"github.com/cymony/cryptomony/eccgroup/internal"
-	"github.com/cymony/cryptomony/h2f"
 	"github.com/cymony/cryptomony/hash"
+	"github.com/cymony/cryptomony/msgexpand"
+	"github.com/cymony/cryptomony/utils"
 )
 
 const (
@@ -33,6 +34,12 @@ const (
 
 	// E2CP521 represents the encode-to-curve string identifier for P521.
 	E2CP521 = "P521_XMD:SHA-512_SSWU_NU_"
+
+	// H2CSecp256k1 represents the hash-to-curve string identifier for secp256k1.
+	H2CSecp256k1 = "secp256k1_XMD:SHA-256_SSWU_RO_"
+
+	// E2CSecp256k1 represents the encode-to-curve string identifier for secp256k1.
+	E2CSecp256k1 = "secp256k1_XMD:SHA-256_SSWU_NU_"
 )
 
 // P256 returns the single instantiation of the P256 Group.
@@ -53,25 +60,33 @@ func P521() internal.Group {
 	return &p521
 }
 
+// Secp256k1 returns the single instantiation of the secp256k1 Group.
+func Secp256k1() internal.Group {
+	initOnceSecp256k1.Do(initSecp256k1)
+	return &secp256k1
+}
+
 // Group represents the prime-order group over the P256 curve.
 // It exposes a prime-order group API with hash-to-curve operations.
 type Group[Point nistECGenericPoint[Point]] struct {
+	name        string
 	h2c         string
 	e2c         string
-	scalarField field
+	scalarField *montField
 	curve       curve[Point]
 }
 
 // NewScalar returns a new, empty, scalar.
 func (g Group[P]) NewScalar() internal.Scalar { //nolint:gocritic //it is dynamic type
-	return newScalar(&g.scalarField)
+	return newScalar(g.scalarField)
 }
 
 // NewElement returns the identity element (point at infinity).
 func (g Group[P]) NewElement() internal.Element { //nolint:gocritic //it is dynamic type
 	return &Element[P]{
-		p:   g.curve.NewPoint(),
-		new: g.curve.NewPoint,
+		p:     g.curve.NewPoint(),
+		new:   g.curve.NewPoint,
+		field: g.scalarField,
 	}
 }
 
@@ -87,55 +102,144 @@ func (g Group[Point]) RandomElement() internal.Element { //nolint:gocritic //it
 
 // Base returns the group's base point a.k.a. canonical generator.
 func (g Group[P]) Base() internal.Element { //nolint:gocritic //it is dynamic type
-	b := g.curve.NewPoint()
-	b.SetGenerator()
-
-	return g.newPoint(b)
+	return g.NewElement().Base()
 }
 
 func (g Group[P]) newPoint(p P) *Element[P] { //nolint:gocritic //it is dynamic type
 	return &Element[P]{
-		p:   p,
-		new: g.curve.NewPoint,
+		p:     p,
+		new:   g.curve.NewPoint,
+		field: g.scalarField,
 	}
 }
 
 // HashToScalar returns a safe mapping of the arbitrary input to a Scalar.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func (g Group[P]) HashToScalar(input, dst []byte) internal.Scalar { //nolint:gocritic //it is dynamic type
-	ss, err := h2f.Hash2FieldXMD(g.curve.hash, input, dst, 1, 1, g.curve.secLength, g.scalarField.primeNumber)
-	if err != nil {
-		panic(err)
-	}
+	return g.HashToScalarWith(g.curve.expander, input, dst)
+}
 
-	s := ss[0]
+// HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g Group[P]) HashToGroup(input, dst []byte) internal.Element { //nolint:gocritic //it is dynamic type
+	return g.HashToGroupWith(g.curve.expander, input, dst)
+}
 
-	// If necessary, build a buffer of right size, so it gets correctly interpreted.
-	bytes := s.Bytes()
+// EncodeToGroup returns a non-uniform mapping of the arbitrary input to an Element in the Group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g Group[P]) EncodeToGroup(input, dst []byte) internal.Element { //nolint:gocritic //it is dynamic type
+	return g.EncodeToGroupWith(g.curve.expander, input, dst)
+}
 
-	length := int(g.ScalarLength())
-	if l := length - len(bytes); l > 0 {
-		buf := make([]byte, l, length)
-		buf = append(buf, bytes...)
-		bytes = buf
+// SuiteID returns the hash-to-curve suite identifier of the group with the expander identifier, e.g.
+// P256_XOF:SHAKE-256_SSWU_RO_, or its encode-to-curve suite identifier if nonUniform is set.
+func (g Group[P]) SuiteID(expanderID string, nonUniform bool) string { //nolint:gocritic //it is dynamic type
+	if nonUniform {
+		return g.name + "_" + expanderID + "_SSWU_NU_"
 	}
 
-	res := &Scalar{field: &g.scalarField}
-	res.s.SetBytes(bytes)
+	return g.name + "_" + expanderID + "_SSWU_RO_"
+}
+
+// HashToScalarWith is HashToScalar with the expander.
+func (g Group[P]) HashToScalarWith(expander msgexpand.MessageExpand, input, dst []byte) internal.Scalar { //nolint:gocritic //it is dynamic type
+	u := hashToField(g.scalarField, expander, input, dst, 1, g.curve.secLength)
+
+	res := newScalar(g.scalarField)
+	res.s = u[0]
 
 	return res
 }
 
-// HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
-// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
-func (g Group[P]) HashToGroup(input, dst []byte) internal.Element { //nolint:gocritic //it is dynamic type
-	return g.newPoint(g.curve.hashToCurveXMD(input, dst))
+// HashToGroupWith is HashToGroup with the expander.
+func (g Group[P]) HashToGroupWith(expander msgexpand.MessageExpand, input, dst []byte) internal.Element { //nolint:gocritic //it is dynamic type
+	return g.newPoint(g.curve.hashToCurve(expander, input, dst))
 }
 
-// EncodeToGroup returns a non-uniform mapping of the arbitrary input to an Element in the Group.
-// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
-func (g Group[P]) EncodeToGroup(input, dst []byte) internal.Element { //nolint:gocritic //it is dynamic type
-	return g.newPoint(g.curve.encodeToCurveXMD(input, dst))
+// EncodeToGroupWith is EncodeToGroup with the expander.
+func (g Group[P]) EncodeToGroupWith(expander msgexpand.MessageExpand, input, dst []byte) internal.Element { //nolint:gocritic //it is dynamic type
+	return g.newPoint(g.curve.encodeToCurve(expander, input, dst))
+}
+
+// UniformLength returns the byte size of the uniform input of MapToElement.
+func (g Group[P]) UniformLength() uint { //nolint:gocritic //it is dynamic type
+	return uint(2 * g.curve.secLength) //nolint:gomnd //two field elements
+}
+
+// MapToElement deterministically maps the uniform bytes to an element, as HashToGroup does with the expanded input.
+// The input is split in two halves reduced to field elements, which are mapped with the simplified SWU method and
+// added.
+func (g Group[P]) MapToElement(uniform []byte) (internal.Element, error) { //nolint:gocritic //it is dynamic type
+	if len(uniform) != 2*g.curve.secLength {
+		return nil, internal.ErrUniformLength
+	}
+
+	return g.newPoint(g.curve.mapToCurve(uniform)), nil
+}
+
+// Preimage returns uniformly random bytes that MapToElement maps to the element, with the Elligator Squared method.
+// It runs in variable time, and is not supported by secp256k1 whose map goes through an isogeny.
+// See https://eprint.iacr.org/2014/043
+func (g Group[P]) Preimage(e internal.Element) ([]byte, error) { //nolint:gocritic //it is dynamic type
+	if g.curve.iso != nil {
+		return nil, internal.ErrPreimageUnsupported
+	}
+
+	// maxPreimages bounds the number of field elements the simplified SWU method maps to a single point.
+	const maxPreimages = 4
+
+	target := checkElement[P](e)
+	secLength := g.curve.secLength
+
+	for {
+		// Pick the first half at random, and look for the preimages of the rest under a single map.
+		r0 := utils.RandomBytes(secLength)
+
+		var u0 limbs
+
+		g.curve.field.setWideBytes(&u0, r0)
+
+		rest := g.newPoint(g.curve.NewPoint().Set(target.p))
+		rest.Subtract(g.newPoint(g.curve.map2curveSSWU(&u0)))
+
+		preimages := g.curve.sswuPreimages(rest.p)
+
+		// Accepting with probability proportional to the number of preimages makes the output uniform.
+		j := int(utils.RandomBytes(1)[0] % maxPreimages)
+		if j >= len(preimages) {
+			continue
+		}
+
+		return append(r0, g.curve.field.wideBytes(&preimages[j], secLength)...), nil
+	}
+}
+
+// MultiScalarMult returns the sum of the element-wise products of scalars and elements.
+// Small batches use Straus' method, large batches use Pippenger's method.
+// It runs in variable time and must only be used with public scalars.
+func (g Group[P]) MultiScalarMult(scalars []internal.Scalar, elements []internal.Element) internal.Element { //nolint:gocritic //it is dynamic type
+	if len(scalars) != len(elements) {
+		panic(internal.ErrLengthMismatch)
+	}
+
+	points := make([]P, len(elements))
+	for i := range elements {
+		points[i] = checkElement[P](elements[i]).p
+	}
+
+	ss := make([][]byte, len(scalars))
+
+	for i := range scalars {
+		sc, ok := scalars[i].(*Scalar)
+		if !ok {
+			panic(internal.ErrCastScalar)
+		}
+
+		// scalars are encoded in big-endian, multi-scalar multiplication expects little-endian
+		ss[i] = reverse(sc.Encode())
+	}
+
+	return g.newPoint(internal.MultiScalarMult(g.curve.NewPoint(), g.curve.NewPoint, ss, points))
 }
 
 // Ciphersuite returns the hash-to-curve ciphersuite identifier.
@@ -145,29 +249,30 @@ func (g Group[P]) Ciphersuite() string { //nolint:gocritic //it is dynamic type
 
 // ScalarLength returns the byte size of an encoded element.
 func (g Group[P]) ScalarLength() uint { //nolint:gocritic //it is dynamic type
-	byteLen := (g.scalarField.bitLen() + 7) / 8
-	return uint(byteLen)
+	return uint(g.scalarField.byteLen)
 }
 
 // ElementLength returns the byte size of an encoded element.
 func (g Group[P]) ElementLength() uint { //nolint:gocritic //it is dynamic type
-	byteLen := (g.curve.field.bitLen() + 7) / 8
-	return uint(1 + byteLen)
+	return uint(1 + g.curve.field.byteLen)
 }
 
 var (
-	initOnceP256 sync.Once
-	initOnceP384 sync.Once
-	initOnceP521 sync.Once
-
-	p256 Group[*nistec.P256Point]
-	p384 Group[*nistec.P384Point]
-	p521 Group[*nistec.P521Point]
+	initOnceP256      sync.Once
+	initOnceP384      sync.Once
+	initOnceP521      sync.Once
+	initOnceSecp256k1 sync.Once
+
+	p256      Group[*nistec.P256Point]
+	p384      Group[*nistec.P384Point]
+	p521      Group[*nistec.P521Point]
+	secp256k1 Group[*secp256k1Point]
 )
 
 func initP256() {
 	primeP256, _ := new(big.Int).SetString("115792089210356248762697446949407573530"+
 		"086143415290314195533631308867097853951", 10)
+	p256.name = "P256"
 	p256.h2c = H2CP256
 	p256.e2c = E2CP256
 	p256.curve.setCurveParams(
@@ -183,6 +288,7 @@ func initP256() {
 func initP384() {
 	primeP384, _ := new(big.Int).SetString("3940200619639447921227904010014361380507973927046544666794"+
 		"8293404245721771496870329047266088258938001861606973112319", 10)
+	p384.name = "P384"
 	p384.h2c = H2CP384
 	p384.e2c = E2CP384
 	p384.curve.setCurveParams(
@@ -201,6 +307,7 @@ func initP521() {
 	primeP521, _ := new(big.Int).SetString("6864797660130609714981900799081393217269435300143305"+
 		"4093944634591855431833976560521225596406614545549772"+
 		"96311391480858037121987999716643812574028291115057151", 10)
+	p521.name = "P521"
 	p521.h2c = H2CP521
 	p521.e2c = E2CP521
 	p521.curve.setCurveParams(
@@ -217,6 +324,44 @@ func initP521() {
 	)
 }
 
+func initSecp256k1() {
+	secp256k1.name = "secp256k1"
+	secp256k1.h2c = H2CSecp256k1
+	secp256k1.e2c = E2CSecp256k1
+	secp256k1.curve.setCurveParams(s2int(secp256k1Prime), "0", "7", newSecp256k1Point)
+	secp256k1.curve.setMapping(hash.SHA256, "-11", 48)
+	// secp256k1 has A = 0, so the SSWU mapping targets an isogenous curve followed by the 3-isogeny map.
+	// See https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#name-3-isogeny-map-for-secp256k1
+	secp256k1.curve.setIsogeny(
+		"0x3f8731abdd661adca08a5558f0f5d272e953d363cb6f0e5d405447c01a444533",
+		"1771",
+		[]string{
+			"0x8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa8c7",
+			"0x07d3d4c80bc321d5b9f315cea7fd44c5d595d2fc0bf63b92dfff1044f17c6581",
+			"0x534c328d23f234e6e2a413deca25caece4506144037c40314ecbd0b53d9dd262",
+			"0x8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa88c",
+		},
+		[]string{
+			"0xd35771193d94918a9ca34ccbb7b640dd86cd409542f8487d9fe6b745781eb49b",
+			"0xedadc6f64383dc1df7c4b2d51b54225406d36b641f5e41bbc52a56612a8c6d14",
+			"1",
+		},
+		[]string{
+			"0x4bda12f684bda12f684bda12f684bda12f684bda12f684bda12f684b8e38e23c",
+			"0xc75e0c32d5cb7c0fa9d0a54b12a0a6d5647ab046d686da6fdffc90fc201d71a3",
+			"0x29a6194691f91a73715209ef6512e576722830a201be2018a765e85a9ecee931",
+			"0x2f684bda12f684bda12f684bda12f684bda12f684bda12f684bda12f38e38d84",
+		},
+		[]string{
+			"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffff93b",
+			"0x7a06534bb8bdb49fd5e9e6632722c2989467c1bfc8e8d978dfb425d2685c2573",
+			"0x6484aa716545ca2cf3a70c3fa8fe337e0a3d21162f0d6299a7bf8192bfd2a76f",
+			"1",
+		},
+	)
+	secp256k1.setScalarField(secp256k1Order)
+}
+
 func (g *Group[Point]) setScalarField(order string) {
-	g.scalarField = *newField(s2int(order))
+	g.scalarField = newMontField(s2int(order))
 }
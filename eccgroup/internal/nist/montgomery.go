@@ -0,0 +1,365 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nist
+
+import (
+	"crypto/rand"
+	"math/big"
+	"math/bits"
+
+	"github.com/cymony/cryptomony/utils"
+)
+
+// maxLimbs is the number of 64-bit limbs required by the largest supported modulus (521 bits).
+const maxLimbs = 9
+
+var (
+	one = big.NewInt(1)
+	two = big.NewInt(2) //nolint:gomnd //no need constant
+)
+
+// limbs is a fixed-width little-endian multi-precision integer.
+type limbs [maxLimbs]uint64
+
+// montField implements constant-time arithmetic modulo an odd prime, with elements kept in
+// Montgomery representation x*R mod p where R = 2^(64*n). The running time of every operation
+// only depends on the modulus, never on the values of the operands.
+type montField struct {
+	modulus *big.Int
+	p       limbs  // p
+	pMinus2 limbs  // p-2, the inversion exponent
+	rr      limbs  // R^2 mod p
+	one     limbs  // R mod p, i.e. 1 in Montgomery representation
+	pInv    uint64 // -p^-1 mod 2^64
+	n       int    // number of limbs in use
+	bitLen  int
+	byteLen int
+}
+
+func newMontField(modulus *big.Int) *montField {
+	n := (modulus.BitLen() + 63) / 64 //nolint:gomnd //limb size
+	if n > maxLimbs || modulus.Bit(0) == 0 {
+		panic("invalid montgomery modulus")
+	}
+
+	f := &montField{
+		modulus: modulus,
+		n:       n,
+		bitLen:  modulus.BitLen(),
+		byteLen: (modulus.BitLen() + 7) / 8, //nolint:gomnd //byte size
+	}
+
+	r := new(big.Int).Lsh(one, uint(64*n)) //nolint:gomnd //limb size
+	f.p = bigToLimbs(modulus)
+	f.pMinus2 = bigToLimbs(new(big.Int).Sub(modulus, two))
+	f.one = bigToLimbs(new(big.Int).Mod(r, modulus))
+	f.rr = bigToLimbs(new(big.Int).Mod(new(big.Int).Mul(r, r), modulus))
+
+	// Newton iteration, each step doubles the number of correct low bits of p^-1 mod 2^64.
+	inv := f.p[0]
+	for i := 0; i < 5; i++ {
+		inv *= 2 - f.p[0]*inv
+	}
+
+	f.pInv = -inv
+
+	return f
+}
+
+// bigToLimbs converts a non-negative integer of at most 64*maxLimbs bits to limbs. It is not constant-time.
+func bigToLimbs(x *big.Int) limbs {
+	return limbsFromBytes(x.FillBytes(make([]byte, 8*maxLimbs))) //nolint:gomnd //limb size
+}
+
+// limbsFromBytes decodes the big-endian byte string b of at most 8*maxLimbs bytes.
+func limbsFromBytes(b []byte) limbs {
+	var l limbs
+
+	for i := 0; i < len(b); i++ {
+		l[i/8] |= uint64(b[len(b)-1-i]) << (8 * (i % 8)) //nolint:gomnd //byte size
+	}
+
+	return l
+}
+
+// fromBig returns the Montgomery representation of x mod p. It is not constant-time and
+// must only be used for public constants.
+func (f *montField) fromBig(x *big.Int) limbs {
+	var z limbs
+
+	l := bigToLimbs(new(big.Int).Mod(x, f.modulus))
+	f.toMont(&z, &l)
+
+	return z
+}
+
+// fillBytes writes the big-endian encoding of the first len(b) bytes of l into b, and returns b.
+func (l *limbs) fillBytes(b []byte) []byte {
+	for i := 0; i < len(b); i++ {
+		b[len(b)-1-i] = byte(l[i/8] >> (8 * (i % 8))) //nolint:gomnd //byte size
+	}
+
+	return b
+}
+
+// selectLimbs sets z to a if cond == 1, and to b if cond == 0.
+func (f *montField) selectLimbs(z, a, b *limbs, cond uint64) {
+	mask := -cond
+
+	for i := 0; i < f.n; i++ {
+		z[i] = b[i] ^ (mask & (a[i] ^ b[i]))
+	}
+}
+
+// isReduced returns 1 if x < p, and 0 otherwise.
+func (f *montField) isReduced(x *limbs) uint64 {
+	var borrow uint64
+
+	for i := 0; i < f.n; i++ {
+		_, borrow = bits.Sub64(x[i], f.p[i], borrow)
+	}
+
+	for i := f.n; i < maxLimbs; i++ {
+		_, borrow = bits.Sub64(x[i], 0, borrow)
+	}
+
+	return borrow
+}
+
+// equal returns 1 if x == y, and 0 otherwise.
+func (f *montField) equal(x, y *limbs) uint64 {
+	var acc uint64
+
+	for i := 0; i < f.n; i++ {
+		acc |= x[i] ^ y[i]
+	}
+
+	return 1 ^ ((acc | -acc) >> 63) //nolint:gomnd //sign bit
+}
+
+// isZero returns 1 if x == 0, and 0 otherwise.
+func (f *montField) isZero(x *limbs) uint64 {
+	return f.equal(x, &limbs{})
+}
+
+// add sets z to x + y mod p.
+func (f *montField) add(z, x, y *limbs) {
+	var sum, red limbs
+
+	var carry, borrow uint64
+
+	for i := 0; i < f.n; i++ {
+		sum[i], carry = bits.Add64(x[i], y[i], carry)
+	}
+
+	for i := 0; i < f.n; i++ {
+		red[i], borrow = bits.Sub64(sum[i], f.p[i], borrow)
+	}
+
+	// keep the sum only if it did not overflow and is below p
+	f.selectLimbs(z, &sum, &red, borrow&^carry)
+}
+
+// sub sets z to x - y mod p.
+func (f *montField) sub(z, x, y *limbs) {
+	var diff, corr limbs
+
+	var borrow, carry uint64
+
+	for i := 0; i < f.n; i++ {
+		diff[i], borrow = bits.Sub64(x[i], y[i], borrow)
+	}
+
+	for i := 0; i < f.n; i++ {
+		corr[i], carry = bits.Add64(diff[i], f.p[i], carry)
+	}
+
+	f.selectLimbs(z, &corr, &diff, borrow)
+}
+
+// neg sets z to -x mod p.
+func (f *montField) neg(z, x *limbs) {
+	f.sub(z, &limbs{}, x)
+}
+
+// mul sets z to x * y * R^-1 mod p, using the coarsely integrated operand scanning (CIOS) method.
+func (f *montField) mul(z, x, y *limbs) {
+	var t [maxLimbs + 2]uint64
+
+	n := f.n
+
+	for i := 0; i < n; i++ {
+		var c, cc, hi, lo uint64
+
+		for j := 0; j < n; j++ {
+			hi, lo = bits.Mul64(x[j], y[i])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j], c = lo, hi
+		}
+
+		t[n], cc = bits.Add64(t[n], c, 0)
+		t[n+1] = cc
+
+		m := t[0] * f.pInv
+		hi, lo = bits.Mul64(m, f.p[0])
+		_, cc = bits.Add64(lo, t[0], 0)
+		c = hi + cc
+
+		for j := 1; j < n; j++ {
+			hi, lo = bits.Mul64(m, f.p[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j-1], c = lo, hi
+		}
+
+		t[n-1], cc = bits.Add64(t[n], c, 0)
+		t[n] = t[n+1] + cc
+	}
+
+	// t < 2p, a single conditional subtraction is enough
+	var res, red limbs
+
+	var borrow uint64
+
+	copy(res[:n], t[:n])
+
+	for i := 0; i < n; i++ {
+		red[i], borrow = bits.Sub64(t[i], f.p[i], borrow)
+	}
+
+	_, borrow = bits.Sub64(t[n], 0, borrow)
+
+	f.selectLimbs(z, &res, &red, borrow)
+}
+
+// square sets z to x^2 * R^-1 mod p.
+func (f *montField) square(z, x *limbs) {
+	f.mul(z, x, x)
+}
+
+// exp sets z to x^e in Montgomery representation. The exponent e must be public,
+// the running time is independent of x only.
+func (f *montField) exp(z, x, e *limbs) {
+	acc, base := f.one, *x
+
+	for i := 64*f.n - 1; i >= 0; i-- {
+		f.square(&acc, &acc)
+
+		if (e[i/64]>>(i%64))&1 == 1 {
+			f.mul(&acc, &acc, &base)
+		}
+	}
+
+	*z = acc
+}
+
+// inv sets z to x^(p-2) = 1/x mod p, and 0 if x is 0.
+func (f *montField) inv(z, x *limbs) {
+	f.exp(z, x, &f.pMinus2)
+}
+
+// toMont sets z to the Montgomery representation of x, which must be reduced.
+func (f *montField) toMont(z, x *limbs) {
+	f.mul(z, x, &f.rr)
+}
+
+// fromMont sets z to the canonical integer represented by x.
+func (f *montField) fromMont(z, x *limbs) {
+	f.mul(z, x, &limbs{1})
+}
+
+// bytes returns the fixed-length big-endian encoding of the canonical integer represented by x.
+func (f *montField) bytes(x *limbs) []byte {
+	return f.putBytes(make([]byte, f.byteLen), x)
+}
+
+// putBytes writes the fixed-length big-endian encoding of the canonical integer represented by x into the first
+// byteLen bytes of b, and returns them.
+func (f *montField) putBytes(b []byte, x *limbs) []byte {
+	var c limbs
+
+	f.fromMont(&c, x)
+
+	return c.fillBytes(b[:f.byteLen])
+}
+
+// setBytes sets z to the Montgomery representation of the big-endian integer b, which must be exactly
+// byteLen long, and returns 1. If b does not encode an integer below p, z is unchanged and 0 is returned.
+func (f *montField) setBytes(z *limbs, b []byte) uint64 {
+	if len(b) != f.byteLen {
+		return 0
+	}
+
+	l := limbsFromBytes(b)
+	ok := f.isReduced(&l)
+
+	var m limbs
+
+	f.toMont(&m, &l)
+	f.selectLimbs(z, &m, z, ok)
+
+	return ok
+}
+
+// setWideBytes sets z to the Montgomery representation of the big-endian integer b reduced modulo p.
+// The input b must be at most twice as long as the limb width, as produced by hash_to_field.
+func (f *montField) setWideBytes(z *limbs, b []byte) {
+	width := 8 * f.n //nolint:gomnd //limb size
+	if len(b) > 2*width {
+		panic("wide input too long")
+	}
+
+	split := 0
+	if len(b) > width {
+		split = len(b) - width
+	}
+
+	// b = hi * R + lo, so that b*R = lo*R + hi*R*R (mod p); Montgomery multiplication by R^2 accepts
+	// unreduced left operands, reducing both halves in constant time.
+	lo, hi := limbsFromBytes(b[split:]), limbsFromBytes(b[:split])
+
+	var tLo, tHi limbs
+
+	f.mul(&tLo, &lo, &f.rr)
+	f.mul(&tHi, &hi, &f.rr)
+	f.mul(&tHi, &tHi, &f.rr)
+	f.add(z, &tLo, &tHi)
+}
+
+// wideBytes returns a uniformly random big-endian integer of the given byte length, among the ones congruent to
+// x modulo p. It inverts setWideBytes without the bias of a fixed representative, and is not constant-time.
+func (f *montField) wideBytes(x *limbs, length int) []byte {
+	u := new(big.Int).SetBytes(f.bytes(x))
+
+	// count = floor((2^(8*length) - 1 - u) / p) + 1 is the number of k such that u + k*p fits in length bytes
+	count := new(big.Int).Lsh(one, uint(8*length)) //nolint:gomnd //byte size
+	count.Sub(count, u).Sub(count, one).Div(count, f.modulus).Add(count, one)
+
+	k, err := rand.Int(rand.Reader, count)
+	if err != nil {
+		panic(err)
+	}
+
+	return k.Mul(k, f.modulus).Add(k, u).FillBytes(make([]byte, length))
+}
+
+// random sets z to a uniformly random element of the field, using rejection sampling.
+func (f *montField) random(z *limbs) {
+	excess := uint(8*f.byteLen - f.bitLen) //nolint:gomnd //byte size
+
+	for {
+		b := utils.RandomBytes(f.byteLen)
+		b[0] &= 0xff >> excess
+
+		if f.setBytes(z, b) == 1 {
+			return
+		}
+	}
+}
@@ -14,6 +14,9 @@ type nistECGenericPoint[point any] interface {
 	// BytesCompressed returns the compressed or infinity encoding of p, as specified in SEC 1, Version 2.0, Section 2.3.3.
 	// Note that the encoding of the point at infinity is shorter than all other encodings.
 	BytesCompressed() []byte
+	// BytesX returns the encoding of the x-coordinate of p, as specified in SEC 1, Version 2.0, Section 2.3.5.
+	// It returns an error if p is the point at infinity.
+	BytesX() ([]byte, error)
 	// Double sets q = p + p, and returns q. The points may overlap.
 	Double(p point) point
 	// ScalarBaseMult sets p = scalar * B, where B is the canonical generator, and returns p.
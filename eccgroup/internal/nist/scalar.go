@@ -8,27 +8,21 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
-	"math/big"
 
 	"github.com/cymony/cryptomony/eccgroup/internal"
 )
 
-var (
-	errParamNegScalar    = errors.New("negative scalar")
-	errParamScalarTooBig = errors.New("scalar too big")
-)
+var errParamScalarTooBig = errors.New("scalar too big")
 
 // Scalar implements the Scalar interface for group scalars.
+// The value is kept in Montgomery representation and all arithmetic is constant-time.
 type Scalar struct {
-	field *field
-	s     big.Int
+	field *montField
+	s     limbs
 }
 
-func newScalar(field *field) *Scalar {
-	s := &Scalar{field: field}
-	s.s.Set(zero)
-
-	return s
+func newScalar(field *montField) *Scalar {
+	return &Scalar{field: field}
 }
 
 func (s *Scalar) assert(scalar internal.Scalar) *Scalar {
@@ -37,7 +31,7 @@ func (s *Scalar) assert(scalar internal.Scalar) *Scalar {
 		panic(internal.ErrCastScalar)
 	}
 
-	if !s.field.isEqualPrime(_sc.field) {
+	if s.field != _sc.field && s.field.modulus.Cmp(_sc.field.modulus) != 0 {
 		panic(internal.ErrWrongField)
 	}
 
@@ -46,13 +40,13 @@ func (s *Scalar) assert(scalar internal.Scalar) *Scalar {
 
 // Zero sets the scalar to 0, and returns it.
 func (s *Scalar) Zero() internal.Scalar {
-	s.s.Set(zero)
+	s.s = limbs{}
 	return s
 }
 
 // One sets the scalar to 1, and returns it.
 func (s *Scalar) One() internal.Scalar {
-	s.s.Set(one)
+	s.s = s.field.one
 	return s
 }
 
@@ -92,7 +86,8 @@ func (s *Scalar) Subtract(scalar internal.Scalar) internal.Scalar {
 	return s
 }
 
-// Multiply returns the multiplication of the scalars, and does not change the receiver.
+// Multiply multiplies the receiver with the input, and returns the receiver.
+// If s parameter is nil, then the receiver is set to 0
 func (s *Scalar) Multiply(scalar internal.Scalar) internal.Scalar {
 	if scalar == nil {
 		return s.Zero()
@@ -118,73 +113,76 @@ func (s *Scalar) Equal(scalar internal.Scalar) int {
 
 	sc := s.assert(scalar)
 
-	switch s.s.Cmp(&sc.s) {
-	case 0:
-		return 1
-	default:
-		return 0
-	}
+	return int(s.field.equal(&s.s, &sc.s))
 }
 
 // IsZero returns whether the scalar is 0.
 func (s *Scalar) IsZero() bool {
-	return s.field.areEqual(&s.s, zero)
-}
-
-func (s *Scalar) set(scalar *Scalar) *Scalar {
-	*s = *scalar
-	return s
+	return s.field.isZero(&s.s) == 1
 }
 
 // Set sets the receiver to the value of the argument scalar, and returns the receiver.
+// If s parameter is nil, then the receiver is set to 0
 func (s *Scalar) Set(scalar internal.Scalar) internal.Scalar {
 	if scalar == nil {
-		return s.set(nil)
+		return s.Zero()
 	}
 
 	ec := s.assert(scalar)
-	s.s.Set(&ec.s)
+	s.s = ec.s
 
 	return s
 }
 
 // Copy returns a copy of the Scalar.
 func (s *Scalar) Copy() internal.Scalar {
-	cpy := &Scalar{field: s.field}
-	cpy.s.Set(&s.s)
-
-	return cpy
+	return &Scalar{field: s.field, s: s.s}
 }
 
 // Encode returns the compressed byte encoding of the scalar.
 func (s *Scalar) Encode() []byte {
-	byteLen := (s.field.bitLen() + 7) / 8
-	scalar := make([]byte, byteLen)
-
-	return s.s.FillBytes(scalar)
+	return s.field.bytes(&s.s)
 }
 
 // Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+// Inputs longer than the scalar length are accepted as long as the extra leading bytes are zero.
 func (s *Scalar) Decode(data []byte) error {
 	if len(data) == 0 {
 		return internal.ErrParamNilScalar
 	}
 
-	// warning - SetBytes interprets the input as a non-signed integer, so this will always be false
-	tmp := new(big.Int).SetBytes(data)
-	if tmp.Sign() < 0 {
-		return errParamNegScalar
+	if l := len(data) - s.field.byteLen; l > 0 {
+		for _, b := range data[:l] {
+			if b != 0 {
+				return errParamScalarTooBig
+			}
+		}
+
+		data = data[l:]
 	}
 
-	if s.field.order().Cmp(tmp) <= 0 {
-		return errParamScalarTooBig
+	if l := s.field.byteLen - len(data); l > 0 {
+		data = append(make([]byte, l, s.field.byteLen), data...)
 	}
 
-	s.s.Set(tmp)
+	if s.field.setBytes(&s.s, data) != 1 {
+		return errParamScalarTooBig
+	}
 
 	return nil
 }
 
+// BigEndian returns the big-endian encoding of the scalar, with the length of Encode.
+func (s *Scalar) BigEndian() []byte {
+	return s.Encode()
+}
+
+// SetBigEndian sets the receiver to the canonical big-endian encoding in the input, with the length of Encode,
+// and returns an error on failure.
+func (s *Scalar) SetBigEndian(in []byte) error {
+	return s.Decode(in)
+}
+
 // MarshalBinary returns the compressed byte encoding of the scalar.
 func (s *Scalar) MarshalBinary() ([]byte, error) {
 	return s.Encode(), nil
@@ -0,0 +1,202 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nist
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"filippo.io/nistec"
+
+	"github.com/cymony/cryptomony/eccgroup/internal"
+	"github.com/cymony/cryptomony/internal/test"
+)
+
+func allScalarFields() map[string]*montField {
+	return map[string]*montField{
+		"P256": P256().(*Group[*nistec.P256Point]).scalarField,
+		"P384": P384().(*Group[*nistec.P384Point]).scalarField,
+		"P521": P521().(*Group[*nistec.P521Point]).scalarField,
+
+		"secp256k1": Secp256k1().(*Group[*secp256k1Point]).scalarField,
+	}
+}
+
+// differentialInputs returns edge cases and random integers below the modulus.
+func differentialInputs(t *testing.T, modulus *big.Int, count int) []*big.Int {
+	t.Helper()
+
+	inputs := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(2),
+		new(big.Int).Sub(modulus, big.NewInt(1)),
+		new(big.Int).Sub(modulus, big.NewInt(2)),
+		new(big.Int).Rsh(modulus, 1),
+	}
+
+	for i := 0; i < count; i++ {
+		r, err := rand.Int(rand.Reader, modulus)
+		test.CheckNoErr(t, err, "rand.Int err")
+
+		inputs = append(inputs, r)
+	}
+
+	return inputs
+}
+
+func scalarFromBig(f *montField, x *big.Int) *Scalar {
+	s := newScalar(f)
+	if err := s.Decode(x.FillBytes(make([]byte, f.byteLen))); err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+func checkScalarBig(t *testing.T, f *montField, got internal.Scalar, want *big.Int, inputs ...interface{}) {
+	t.Helper()
+
+	if w := want.FillBytes(make([]byte, f.byteLen)); !bytes.Equal(got.Encode(), w) {
+		test.Report(t, got.Encode(), w, inputs...)
+	}
+}
+
+func TestScalarDifferential(t *testing.T) {
+	const testTimes = 1 << 5
+
+	for name, f := range allScalarFields() {
+		f := f
+		p := f.modulus
+		inputs := differentialInputs(t, p, testTimes)
+
+		t.Run(name+"/Add", func(t *testing.T) {
+			for _, x := range inputs {
+				for _, y := range inputs {
+					got := scalarFromBig(f, x).Add(scalarFromBig(f, y))
+					want := new(big.Int).Add(x, y)
+					checkScalarBig(t, f, got, want.Mod(want, p), x, y)
+				}
+			}
+		})
+
+		t.Run(name+"/Subtract", func(t *testing.T) {
+			for _, x := range inputs {
+				for _, y := range inputs {
+					got := scalarFromBig(f, x).Subtract(scalarFromBig(f, y))
+					want := new(big.Int).Sub(x, y)
+					checkScalarBig(t, f, got, want.Mod(want, p), x, y)
+				}
+			}
+		})
+
+		t.Run(name+"/Multiply", func(t *testing.T) {
+			for _, x := range inputs {
+				for _, y := range inputs {
+					got := scalarFromBig(f, x).Multiply(scalarFromBig(f, y))
+					want := new(big.Int).Mul(x, y)
+					checkScalarBig(t, f, got, want.Mod(want, p), x, y)
+				}
+			}
+		})
+
+		t.Run(name+"/Invert", func(t *testing.T) {
+			for _, x := range inputs {
+				got := scalarFromBig(f, x).Invert()
+				want := new(big.Int).Exp(x, new(big.Int).Sub(p, big.NewInt(2)), p)
+				checkScalarBig(t, f, got, want, x)
+			}
+		})
+
+		t.Run(name+"/EqualAndIsZero", func(t *testing.T) {
+			for _, x := range inputs {
+				for _, y := range inputs {
+					got := scalarFromBig(f, x).Equal(scalarFromBig(f, y))
+					if want := x.Cmp(y) == 0; want != (got == 1) {
+						test.Report(t, got, want, x, y)
+					}
+				}
+
+				if got, want := scalarFromBig(f, x).IsZero(), x.Sign() == 0; got != want {
+					test.Report(t, got, want, x)
+				}
+			}
+		})
+
+		t.Run(name+"/EncodeAndDecode", func(t *testing.T) {
+			for _, x := range inputs {
+				s := scalarFromBig(f, x)
+				checkScalarBig(t, f, s, x, x)
+
+				// leading zeros and short encodings are accepted
+				padded := append([]byte{0, 0}, s.Encode()...)
+				test.CheckNoErr(t, newScalar(f).Decode(padded), "decode padded err")
+				test.CheckNoErr(t, newScalar(f).Decode([]byte{1}), "decode short err")
+			}
+
+			for _, x := range []*big.Int{p, new(big.Int).Add(p, big.NewInt(1)), new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(8*f.byteLen)), big.NewInt(1))} {
+				err := newScalar(f).Decode(x.FillBytes(make([]byte, f.byteLen)))
+				test.CheckIsErr(t, err, "decode of unreduced scalar must fail")
+			}
+
+			err := newScalar(f).Decode(append([]byte{1}, make([]byte, f.byteLen)...))
+			test.CheckIsErr(t, err, "decode of long scalar must fail")
+
+			err = newScalar(f).Decode(nil)
+			test.CheckIsErr(t, err, "decode of empty scalar must fail")
+		})
+
+		t.Run(name+"/WideReduction", func(t *testing.T) {
+			for _, l := range []int{f.byteLen, f.byteLen + 16, 16 * f.n} {
+				for i := 0; i < testTimes; i++ {
+					b := make([]byte, l)
+					_, err := rand.Read(b)
+					test.CheckNoErr(t, err, "rand.Read err")
+
+					s := newScalar(f)
+					f.setWideBytes(&s.s, b)
+
+					want := new(big.Int).SetBytes(b)
+					checkScalarBig(t, f, s, want.Mod(want, p), b)
+				}
+			}
+
+			err := test.CheckPanic(func() { f.setWideBytes(&limbs{}, make([]byte, 16*f.n+1)) })
+			test.CheckNoErr(t, err, "too long wide input must panic")
+		})
+
+		t.Run(name+"/Random", func(t *testing.T) {
+			for i := 0; i < testTimes; i++ {
+				s := newScalar(f).Random()
+				x := new(big.Int).SetBytes(s.Encode())
+
+				if x.Sign() == 0 || x.Cmp(p) >= 0 {
+					test.Report(t, x, "0 < x < p")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkScalarField(b *testing.B) {
+	for name, f := range allScalarFields() {
+		x := newScalar(f).Random()
+		y := newScalar(f).Random()
+
+		b.Run(name+"/Multiply", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				x.Multiply(y)
+			}
+		})
+
+		b.Run(name+"/Invert", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				x.Invert()
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nist
+
+import (
+	"crypto/subtle"
+	"math/big"
+	"sync"
+
+	"github.com/cymony/cryptomony/eccgroup/internal"
+)
+
+const (
+	secp256k1Prime = "0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"
+	secp256k1Order = "0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"
+	secp256k1Gx    = "0x79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+	secp256k1Gy    = "0x483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"
+
+	secp256k1ScalarWindow = 4
+)
+
+var secp256k1Field = newMontField(s2int(secp256k1Prime))
+
+var (
+	secp256k1B                   = secp256k1Field.fromBig(big.NewInt(7))     //nolint:gomnd //curve coefficient
+	secp256k1B3                  = secp256k1Field.fromBig(big.NewInt(3 * 7)) //nolint:gomnd //curve coefficient
+	secp256k1SqrtExp             = bigToLimbs(new(big.Int).Rsh(new(big.Int).Add(s2int(secp256k1Prime), one), 2))
+	secp256k1GenX, secp256k1GenY = secp256k1Field.fromBig(s2int(secp256k1Gx)), secp256k1Field.fromBig(s2int(secp256k1Gy))
+)
+
+// secp256k1Point is a point of the curve y^2 = x^3 + 7 in projective coordinates (X:Y:Z), with x = X/Z and y = Y/Z.
+// It implements the same API as the nistec points, so that it can be used with the generic Group, and all of its
+// operations except the encoding of the point at infinity are constant-time.
+type secp256k1Point struct {
+	x, y, z limbs // coordinates in Montgomery representation
+}
+
+// newSecp256k1Point returns a new secp256k1Point representing the point at infinity.
+func newSecp256k1Point() *secp256k1Point {
+	return &secp256k1Point{y: secp256k1Field.one}
+}
+
+// SetGenerator sets p to the canonical generator and returns p.
+func (p *secp256k1Point) SetGenerator() *secp256k1Point {
+	p.x, p.y, p.z = secp256k1GenX, secp256k1GenY, secp256k1Field.one
+	return p
+}
+
+// Set sets p = q and returns p.
+func (p *secp256k1Point) Set(q *secp256k1Point) *secp256k1Point {
+	*p = *q
+	return p
+}
+
+// Select sets p to p1 if cond == 1, and to p2 if cond == 0.
+func (p *secp256k1Point) Select(p1, p2 *secp256k1Point, cond int) *secp256k1Point {
+	f := secp256k1Field
+	c := uint64(cond)
+
+	f.selectLimbs(&p.x, &p1.x, &p2.x, c)
+	f.selectLimbs(&p.y, &p1.y, &p2.y, c)
+	f.selectLimbs(&p.z, &p1.z, &p2.z, c)
+
+	return p
+}
+
+// Add sets p = p1 + p2, and returns p. The points may overlap.
+// It uses the complete addition formula for a = 0 curves from https://eprint.iacr.org/2015/1060 (Algorithm 7).
+func (p *secp256k1Point) Add(p1, p2 *secp256k1Point) *secp256k1Point {
+	f := secp256k1Field
+
+	var t0, t1, t2, t3, t4, x3, y3, z3 limbs
+
+	f.mul(&t0, &p1.x, &p2.x)      // t0 := X1 * X2
+	f.mul(&t1, &p1.y, &p2.y)      // t1 := Y1 * Y2
+	f.mul(&t2, &p1.z, &p2.z)      // t2 := Z1 * Z2
+	f.add(&t3, &p1.x, &p1.y)      // t3 := X1 + Y1
+	f.add(&t4, &p2.x, &p2.y)      // t4 := X2 + Y2
+	f.mul(&t3, &t3, &t4)          // t3 := t3 * t4
+	f.add(&t4, &t0, &t1)          // t4 := t0 + t1
+	f.sub(&t3, &t3, &t4)          // t3 := t3 - t4
+	f.add(&t4, &p1.y, &p1.z)      // t4 := Y1 + Z1
+	f.add(&x3, &p2.y, &p2.z)      // X3 := Y2 + Z2
+	f.mul(&t4, &t4, &x3)          // t4 := t4 * X3
+	f.add(&x3, &t1, &t2)          // X3 := t1 + t2
+	f.sub(&t4, &t4, &x3)          // t4 := t4 - X3
+	f.add(&x3, &p1.x, &p1.z)      // X3 := X1 + Z1
+	f.add(&y3, &p2.x, &p2.z)      // Y3 := X2 + Z2
+	f.mul(&x3, &x3, &y3)          // X3 := X3 * Y3
+	f.add(&y3, &t0, &t2)          // Y3 := t0 + t2
+	f.sub(&y3, &x3, &y3)          // Y3 := X3 - Y3
+	f.add(&x3, &t0, &t0)          // X3 := t0 + t0
+	f.add(&t0, &x3, &t0)          // t0 := X3 + t0
+	f.mul(&t2, &secp256k1B3, &t2) // t2 := b3 * t2
+	f.add(&z3, &t1, &t2)          // Z3 := t1 + t2
+	f.sub(&t1, &t1, &t2)          // t1 := t1 - t2
+	f.mul(&y3, &secp256k1B3, &y3) // Y3 := b3 * Y3
+	f.mul(&x3, &t4, &y3)          // X3 := t4 * Y3
+	f.mul(&t2, &t3, &t1)          // t2 := t3 * t1
+	f.sub(&x3, &t2, &x3)          // X3 := t2 - X3
+	f.mul(&y3, &y3, &t0)          // Y3 := Y3 * t0
+	f.mul(&t1, &t1, &z3)          // t1 := t1 * Z3
+	f.add(&y3, &t1, &y3)          // Y3 := t1 + Y3
+	f.mul(&t0, &t0, &t3)          // t0 := t0 * t3
+	f.mul(&z3, &z3, &t4)          // Z3 := Z3 * t4
+	f.add(&z3, &z3, &t0)          // Z3 := Z3 + t0
+
+	p.x, p.y, p.z = x3, y3, z3
+
+	return p
+}
+
+// Double sets p = q + q, and returns p. The points may overlap.
+// It uses the doubling formula for a = 0 curves from https://eprint.iacr.org/2015/1060 (Algorithm 9).
+func (p *secp256k1Point) Double(q *secp256k1Point) *secp256k1Point {
+	f := secp256k1Field
+
+	var t0, t1, t2, x3, y3, z3 limbs
+
+	f.square(&t0, &q.y)           // t0 := Y * Y
+	f.add(&z3, &t0, &t0)          // Z3 := t0 + t0
+	f.add(&z3, &z3, &z3)          // Z3 := Z3 + Z3
+	f.add(&z3, &z3, &z3)          // Z3 := Z3 + Z3
+	f.mul(&t1, &q.y, &q.z)        // t1 := Y * Z
+	f.square(&t2, &q.z)           // t2 := Z * Z
+	f.mul(&t2, &secp256k1B3, &t2) // t2 := b3 * t2
+	f.mul(&x3, &t2, &z3)          // X3 := t2 * Z3
+	f.add(&y3, &t0, &t2)          // Y3 := t0 + t2
+	f.mul(&z3, &t1, &z3)          // Z3 := t1 * Z3
+	f.add(&t1, &t2, &t2)          // t1 := t2 + t2
+	f.add(&t2, &t1, &t2)          // t2 := t1 + t2
+	f.sub(&t0, &t0, &t2)          // t0 := t0 - t2
+	f.mul(&y3, &t0, &y3)          // Y3 := t0 * Y3
+	f.add(&y3, &x3, &y3)          // Y3 := X3 + Y3
+	f.mul(&t1, &q.x, &q.y)        // t1 := X * Y
+	f.mul(&x3, &t0, &t1)          // X3 := t0 * t1
+	f.add(&x3, &x3, &x3)          // X3 := X3 + X3
+
+	p.x, p.y, p.z = x3, y3, z3
+
+	return p
+}
+
+// ScalarMult sets p = scalar * q, and returns p. The scalar must be the 32 bytes big-endian encoding of an integer.
+// It uses a fixed 4-bit window with a constant-time table lookup.
+func (p *secp256k1Point) ScalarMult(q *secp256k1Point, scalar []byte) (*secp256k1Point, error) {
+	if len(scalar) != secp256k1Field.byteLen {
+		return nil, ErrInvalidSecp256k1Scalar
+	}
+
+	// table[i] = i * q
+	var table [1 << secp256k1ScalarWindow]secp256k1Point
+
+	table[0] = *newSecp256k1Point()
+	table[1] = *q
+
+	for i := 2; i < len(table); i++ {
+		table[i].Add(&table[i-1], q)
+	}
+
+	acc, sel := newSecp256k1Point(), &secp256k1Point{}
+
+	for _, b := range scalar {
+		for _, w := range [2]byte{b >> secp256k1ScalarWindow, b & 0x0f} { //nolint:gomnd //window mask
+			for j := 0; j < secp256k1ScalarWindow; j++ {
+				acc.Double(acc)
+			}
+
+			sel.Set(&table[0])
+
+			for j := 1; j < len(table); j++ {
+				sel.Select(&table[j], sel, subtle.ConstantTimeByteEq(w, uint8(j)))
+			}
+
+			acc.Add(acc, sel)
+		}
+	}
+
+	return p.Set(acc), nil
+}
+
+var (
+	secp256k1GeneratorTableOnce sync.Once
+	secp256k1GeneratorTable     *internal.FixedBaseTable[*secp256k1Point]
+)
+
+// ScalarBaseMult sets p = scalar * B, where B is the canonical generator, and returns p.
+// It uses a lazily precomputed table of multiples of the generator.
+func (p *secp256k1Point) ScalarBaseMult(scalar []byte) (*secp256k1Point, error) {
+	if len(scalar) != secp256k1Field.byteLen {
+		return nil, ErrInvalidSecp256k1Scalar
+	}
+
+	secp256k1GeneratorTableOnce.Do(func() {
+		secp256k1GeneratorTable = internal.NewFixedBaseTable(newSecp256k1Point, newSecp256k1Point().SetGenerator(), secp256k1Field.byteLen)
+	})
+
+	return secp256k1GeneratorTable.Multiply(p, reverse(scalar)), nil
+}
+
+// affine returns the affine coordinates of p in Montgomery representation, and 1 if p is the point at infinity.
+func (p *secp256k1Point) affine() (x, y limbs, isInfinity uint64) {
+	f := secp256k1Field
+
+	var zInv limbs
+
+	f.inv(&zInv, &p.z)
+	f.mul(&x, &p.x, &zInv)
+	f.mul(&y, &p.y, &zInv)
+
+	return x, y, f.isZero(&p.z)
+}
+
+// Bytes returns the uncompressed or infinity encoding of p, as specified in SEC 1, Version 2.0, Section 2.3.3.
+// Note that the encoding of the point at infinity is shorter than all other encodings.
+func (p *secp256k1Point) Bytes() []byte {
+	f := secp256k1Field
+
+	x, y, isInfinity := p.affine()
+	if isInfinity == 1 {
+		return []byte{0}
+	}
+
+	out := make([]byte, 1+2*f.byteLen)
+	out[0] = 0x04
+
+	copy(out[1:], f.bytes(&x))
+	copy(out[1+f.byteLen:], f.bytes(&y))
+
+	return out
+}
+
+// BytesCompressed returns the compressed or infinity encoding of p, as specified in SEC 1, Version 2.0, Section 2.3.3.
+// Note that the encoding of the point at infinity is shorter than all other encodings.
+func (p *secp256k1Point) BytesCompressed() []byte {
+	f := secp256k1Field
+
+	x, y, isInfinity := p.affine()
+	if isInfinity == 1 {
+		return []byte{0}
+	}
+
+	var canonicalY limbs
+
+	f.fromMont(&canonicalY, &y)
+
+	out := make([]byte, 1+f.byteLen)
+	out[0] = 0x02 | byte(canonicalY[0]&1)
+
+	copy(out[1:], f.bytes(&x))
+
+	return out
+}
+
+// BytesX returns the encoding of the x-coordinate of p, as specified in SEC 1, Version 2.0, Section 2.3.5,
+// or an error if p is the point at infinity.
+func (p *secp256k1Point) BytesX() ([]byte, error) {
+	x, _, isInfinity := p.affine()
+	if isInfinity == 1 {
+		return nil, ErrSecp256k1Infinity
+	}
+
+	return secp256k1Field.bytes(&x), nil
+}
+
+// SetBytes sets p to the compressed, uncompressed, or infinity value encoded in b, as specified in SEC 1, Version 2.0, Section 2.3.4.
+// If the point is not on the curve, it returns nil and an error, and the receiver is unchanged. Otherwise, it returns p.
+func (p *secp256k1Point) SetBytes(b []byte) (*secp256k1Point, error) {
+	f := secp256k1Field
+
+	var x, y, rhs, y2 limbs
+
+	switch {
+	case len(b) == 1 && b[0] == 0:
+		return p.Set(newSecp256k1Point()), nil
+	case len(b) == 1+2*f.byteLen && b[0] == 0x04:
+		if f.setBytes(&x, b[1:1+f.byteLen]) != 1 || f.setBytes(&y, b[1+f.byteLen:]) != 1 {
+			return nil, ErrInvalidSecp256k1Encoding
+		}
+
+		secp256k1Polynomial(&rhs, &x)
+		f.square(&y2, &y)
+
+		if f.equal(&rhs, &y2) != 1 {
+			return nil, ErrSecp256k1NotOnCurve
+		}
+	case len(b) == 1+f.byteLen && (b[0] == 0x02 || b[0] == 0x03):
+		if f.setBytes(&x, b[1:]) != 1 {
+			return nil, ErrInvalidSecp256k1Encoding
+		}
+
+		// y = sqrt(x^3 + 7) = (x^3 + 7)^((p + 1) / 4), since p = 3 mod 4
+		secp256k1Polynomial(&rhs, &x)
+		f.exp(&y, &rhs, &secp256k1SqrtExp)
+		f.square(&y2, &y)
+
+		if f.equal(&rhs, &y2) != 1 {
+			return nil, ErrSecp256k1NotOnCurve
+		}
+
+		// select the root with the parity given by the prefix
+		var canonicalY, negY limbs
+
+		f.fromMont(&canonicalY, &y)
+		f.neg(&negY, &y)
+		f.selectLimbs(&y, &negY, &y, (canonicalY[0]&1)^uint64(b[0]&1))
+	default:
+		return nil, ErrInvalidSecp256k1Encoding
+	}
+
+	p.x, p.y, p.z = x, y, f.one
+
+	return p, nil
+}
+
+// secp256k1Polynomial sets y2 to x^3 + 7.
+func secp256k1Polynomial(y2, x *limbs) {
+	f := secp256k1Field
+
+	f.square(y2, x)
+	f.mul(y2, y2, x)
+	f.add(y2, y2, &secp256k1B)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nist
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/cymony/cryptomony/internal/test"
+)
+
+func TestSecp256k1Point(t *testing.T) {
+	// Uncompressed encodings of G, 2G and 3G.
+	multiples := []string{
+		"0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" +
+			"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8",
+		"04c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5" +
+			"1ae168fea63dc339a3c58419466ceaeef7f632653266d0e1236431a950cfe52a",
+		"04f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9" +
+			"388f7b0f632de8140fe337e62a37f3566500a99934c2231b6cb9fd7584b8e672",
+	}
+
+	t.Run("SmallMultiples", func(t *testing.T) {
+		g := newSecp256k1Point().SetGenerator()
+		acc := newSecp256k1Point()
+
+		for i, m := range multiples {
+			want, err := hex.DecodeString(m)
+			test.CheckNoErr(t, err, "hex decode err")
+
+			acc.Add(acc, g)
+			if !bytes.Equal(acc.Bytes(), want) {
+				test.Report(t, acc.Bytes(), want, i)
+			}
+
+			scalar := make([]byte, 32)
+			scalar[31] = byte(i + 1)
+
+			got, err := newSecp256k1Point().ScalarBaseMult(scalar)
+			test.CheckNoErr(t, err, "scalar base mult err")
+
+			if !bytes.Equal(got.Bytes(), want) {
+				test.Report(t, got.Bytes(), want, i)
+			}
+
+			decoded, err := newSecp256k1Point().SetBytes(got.BytesCompressed())
+			test.CheckNoErr(t, err, "set bytes compressed err")
+
+			if !bytes.Equal(decoded.Bytes(), want) {
+				test.Report(t, decoded.Bytes(), want, i)
+			}
+		}
+
+		if d := newSecp256k1Point().Double(g); !bytes.Equal(d.Bytes(), newSecp256k1Point().Add(g, g).Bytes()) {
+			test.Report(t, d.Bytes(), newSecp256k1Point().Add(g, g).Bytes(), "double is not add")
+		}
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		order := s2int(secp256k1Order).FillBytes(make([]byte, 32))
+
+		p, err := newSecp256k1Point().ScalarBaseMult(order)
+		test.CheckNoErr(t, err, "scalar base mult err")
+
+		if !bytes.Equal(p.Bytes(), []byte{0}) {
+			test.Report(t, p.Bytes(), []byte{0}, "order * G is not the identity")
+		}
+
+		if !bytes.Equal(p.BytesCompressed(), []byte{0}) {
+			test.Report(t, p.BytesCompressed(), []byte{0}, "order * G is not the identity")
+		}
+
+		_, err = p.ScalarBaseMult(order[1:])
+		test.CheckIsErr(t, err, "short scalar must fail")
+	})
+
+	t.Run("BadEncodings", func(t *testing.T) {
+		good, err := hex.DecodeString(multiples[0])
+		test.CheckNoErr(t, err, "hex decode err")
+
+		notOnCurve := append([]byte(nil), good...)
+		notOnCurve[64] ^= 1
+
+		nonCanonical := append([]byte(nil), good...)
+		copy(nonCanonical[1:33], s2int(secp256k1Prime).FillBytes(make([]byte, 32)))
+
+		// x = 5 gives x^3 + 7 = 132, which is not a square modulo p.
+		nonSquare := make([]byte, 33)
+		nonSquare[0], nonSquare[32] = 0x02, 5
+
+		for i, b := range [][]byte{nil, {0x04}, good[:33], notOnCurve, nonCanonical, nonSquare, append([]byte{0x05}, good[1:33]...)} {
+			_, err := newSecp256k1Point().SetBytes(b)
+			test.CheckIsErr(t, err, fmt.Sprintf("#%d: bad encoding must fail", i))
+		}
+	})
+}
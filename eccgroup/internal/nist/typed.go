@@ -0,0 +1,142 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nist
+
+import (
+	"filippo.io/nistec"
+
+	"github.com/cymony/cryptomony/eccgroup/internal"
+)
+
+// P256Element is the element type of the P256 group.
+type P256Element = Element[*nistec.P256Point]
+
+// P384Element is the element type of the P384 group.
+type P384Element = Element[*nistec.P384Point]
+
+// P521Element is the element type of the P521 group.
+type P521Element = Element[*nistec.P521Point]
+
+// Secp256k1Element is the element type of the secp256k1 group.
+type Secp256k1Element = Element[*secp256k1Point]
+
+// The methods below write their result to a destination parameter instead of the receiver. They take and return
+// the concrete types of the package, so that they do not go through the internal interfaces.
+
+// sameField returns the scalar field shared by the receiver and the scalars, and panics if they differ.
+func (s *Scalar) sameField(scalars ...*Scalar) *montField {
+	for _, sc := range scalars {
+		checkField(s.field, sc.field)
+	}
+
+	return s.field
+}
+
+// checkField panics if the fields differ.
+func checkField(f, g *montField) {
+	if f != g && f.modulus.Cmp(g.modulus) != 0 {
+		panic(internal.ErrWrongField)
+	}
+}
+
+// AddInto sets dst to the sum of the receiver and ss, and returns dst.
+func (s *Scalar) AddInto(dst, ss *Scalar) *Scalar {
+	s.sameField(dst, ss).add(&dst.s, &s.s, &ss.s)
+	return dst
+}
+
+// SubtractInto sets dst to the receiver minus ss, and returns dst.
+func (s *Scalar) SubtractInto(dst, ss *Scalar) *Scalar {
+	s.sameField(dst, ss).sub(&dst.s, &s.s, &ss.s)
+	return dst
+}
+
+// MultiplyInto sets dst to the product of the receiver and ss, and returns dst.
+func (s *Scalar) MultiplyInto(dst, ss *Scalar) *Scalar {
+	s.sameField(dst, ss).mul(&dst.s, &s.s, &ss.s)
+	return dst
+}
+
+// InvertInto sets dst to the modular inverse of the receiver, and returns dst.
+func (s *Scalar) InvertInto(dst *Scalar) *Scalar {
+	s.sameField(dst).inv(&dst.s, &s.s)
+	return dst
+}
+
+// CopyInto sets dst to the receiver, and returns dst.
+func (s *Scalar) CopyInto(dst *Scalar) *Scalar {
+	s.sameField(dst)
+	dst.s = s.s
+
+	return dst
+}
+
+// AddInto sets dst to the sum of the receiver and ee, and returns dst.
+func (e *Element[P]) AddInto(dst, ee *Element[P]) *Element[P] {
+	dst.p.Add(e.p, ee.p)
+	dst.base = false
+
+	return dst
+}
+
+// SubtractInto sets dst to the receiver minus ee, and returns dst.
+func (e *Element[P]) SubtractInto(dst, ee *Element[P]) *Element[P] {
+	p, err := e.new().SetBytes(ee.negateSmall())
+	if err != nil {
+		panic(err)
+	}
+
+	dst.p.Add(e.p, p)
+	dst.base = false
+
+	return dst
+}
+
+// DoubleInto sets dst to the double of the receiver, and returns dst.
+func (e *Element[P]) DoubleInto(dst *Element[P]) *Element[P] {
+	dst.p.Double(e.p)
+	dst.base = false
+
+	return dst
+}
+
+// NegateInto sets dst to the negation of the receiver, and returns dst.
+func (e *Element[P]) NegateInto(dst *Element[P]) *Element[P] {
+	if _, err := dst.p.SetBytes(e.negateSmall()); err != nil {
+		panic(err)
+	}
+
+	dst.base = false
+
+	return dst
+}
+
+// MultiplyInto sets dst to the scalar multiplication of the receiver with s, and returns dst. It panics if s is not a
+// scalar of the curve. The generator is multiplied with the precomputed tables of the backend.
+func (e *Element[P]) MultiplyInto(dst *Element[P], s *Scalar) *Element[P] {
+	checkField(e.field, s.field)
+
+	k := s.field.putBytes(dst.scalar[:], &s.s)
+
+	if e.base {
+		if _, err := dst.p.ScalarBaseMult(k); err != nil {
+			panic(err)
+		}
+	} else if _, err := dst.p.ScalarMult(e.p, k); err != nil {
+		panic(err)
+	}
+
+	dst.base = false
+
+	return dst
+}
+
+// CopyInto sets dst to the receiver, and returns dst.
+func (e *Element[P]) CopyInto(dst *Element[P]) *Element[P] {
+	dst.p.Set(e.p)
+	dst.base = e.base
+
+	return dst
+}
@@ -18,6 +18,8 @@ import (
 // Element represents Ristretto point
 type Element struct {
 	e *edwards25519.Point
+	// base reports whether e is the generator, to use the precomputed generator table in Multiply.
+	base bool
 }
 
 func cvtEl(ee internal.Element) *Element {
@@ -40,12 +42,16 @@ func newElement() internal.Element {
 // Base sets the element to the group's base point a.k.a. canonical generator.
 func (e *Element) Base() internal.Element {
 	e.e.Set(edwards25519.NewGeneratorPoint())
+	e.base = true
+
 	return e
 }
 
 // Identity sets the element to the point at infinity of the Group's underlying curve.
 func (e *Element) Identity() internal.Element {
 	e.e.Set(edwards25519.NewIdentityPoint())
+	e.base = false
+
 	return e
 }
 
@@ -57,6 +63,7 @@ func (e *Element) Add(ee internal.Element) internal.Element {
 
 	ec := cvtEl(ee)
 	e.e.Add(e.e, ec.e)
+	e.base = false
 
 	return e
 }
@@ -64,12 +71,16 @@ func (e *Element) Add(ee internal.Element) internal.Element {
 // Double sets the receiver to its double, and returns it.
 func (e *Element) Double() internal.Element {
 	e.e.Add(e.e, e.e)
+	e.base = false
+
 	return e
 }
 
 // Negate sets the receiver to its negation, and returns it.
 func (e *Element) Negate() internal.Element {
 	e.e.Negate(e.e)
+	e.base = false
+
 	return e
 }
 
@@ -81,12 +92,14 @@ func (e *Element) Subtract(ee internal.Element) internal.Element {
 
 	ec := cvtEl(ee)
 	e.e.Subtract(e.e, ec.e)
+	e.base = false
 
 	return e
 }
 
 // Multiply sets the receiver to the scalar multiplication of the receiver with the given Scalar, and returns it.
-// If s parameter is nil, then the receiver is not modified
+// If s parameter is nil, then the receiver is not modified.
+// The generator is multiplied with the precomputed tables of the backend.
 func (e *Element) Multiply(s internal.Scalar) internal.Element {
 	if s == nil {
 		e.Identity()
@@ -94,7 +107,14 @@ func (e *Element) Multiply(s internal.Scalar) internal.Element {
 	}
 
 	sc := cvtScalar(s)
-	e.e.ScalarMult(sc.s, e.e)
+
+	if e.base {
+		e.e.ScalarBaseMult(sc.s)
+	} else {
+		e.e.ScalarMult(sc.s, e.e)
+	}
+
+	e.base = false
 
 	return e
 }
@@ -145,6 +165,8 @@ func (e *Element) Set(ee internal.Element) internal.Element {
 		panic(err)
 	}
 
+	e.base = ec.base
+
 	return e
 }
 
@@ -156,6 +178,8 @@ func (e *Element) Copy() internal.Element {
 		panic(err)
 	}
 
+	ne.base = e.base
+
 	return ne
 }
 
@@ -326,6 +350,8 @@ func (e *Element) SetCanonicalBytes(in []byte) (*Element, error) {
 		panic("ristretto255: internal error: DECODE generated invalid coordinates")
 	}
 
+	e.base = false
+
 	return e, nil
 }
 
@@ -436,6 +462,123 @@ func mapToPoint(out *edwards25519.Point, t *field.Element) {
 	}
 }
 
+// mapPreimages returns the non-negative field elements t such that mapToPoint(t) is equivalent to q.
+//
+// MAP(t) only depends on r = SQRT_M1 * t^2, and the y-coordinate of its output is (1 - s^2) / (1 + s^2), where
+// s^2 = u / v when u / v is square, and s^2 = r * u / v otherwise. For each of the four representatives of q,
+// the candidates for r are the roots of the resulting quadratic equations, which are checked with the forward map.
+// It is not constant-time.
+func mapPreimages(q *edwards25519.Point) []*field.Element {
+	X, Y, Z, _ := q.ExtendedCoordinates()
+
+	var zInv, x, y field.Element
+
+	zInv.Invert(Z)
+	x.Multiply(X, &zInv)
+	y.Multiply(Y, &zInv)
+
+	// The representatives q + T for T in the 4-torsion have y-coordinates y, -y, SQRT_M1 * x and -SQRT_M1 * x.
+	ys := make([]field.Element, 4) //nolint:gomnd //four representatives
+	ys[0].Set(&y)
+	ys[1].Negate(&y)
+	ys[2].Multiply(sqrtM1, &x)
+	ys[3].Negate(&ys[2])
+
+	target := &Element{e: q}
+	preimages := make([]*field.Element, 0, len(ys))
+
+	var dSQPlusOne field.Element
+
+	dSQPlusOne.Square(d)
+	dSQPlusOne.Add(&dSQPlusOne, one)
+
+	for i := range ys {
+		// s^2 = (1 - y) / (1 + y)
+		var num, den, s2, a, b, c field.Element
+
+		num.Subtract(one, &ys[i])
+		den.Add(one, &ys[i])
+
+		if den.Equal(zero) == 1 {
+			continue
+		}
+
+		s2.Multiply(&num, den.Invert(&den))
+
+		// With v = -(d*r^2 + (1 + d^2)*r + d) and u = (1 - d^2)*(r + 1), the square case s^2 * v = u gives
+		// a*r^2 + b*r + c = 0, and the non-square case s^2 * v = r * u gives c*r^2 + b*r + a = 0.
+		a.Multiply(&s2, d)
+		b.Multiply(&s2, &dSQPlusOne)
+		b.Add(&b, oneMinusDSQ)
+		c.Add(&a, oneMinusDSQ)
+
+		candidates := append(solveQuadratic(&a, &b, &c), solveQuadratic(&c, &b, &a)...)
+
+		for _, r := range candidates {
+			// t = sqrt(r / SQRT_M1)
+			t := new(field.Element)
+			if _, wasSquare := t.SqrtRatio(r, sqrtM1); wasSquare == 0 {
+				continue
+			}
+
+			p := &Element{e: edwards25519.NewIdentityPoint()}
+			mapToPoint(p.e, t)
+
+			if p.Equal(target) == 0 {
+				continue
+			}
+
+			duplicate := false
+			for _, pre := range preimages {
+				duplicate = duplicate || pre.Equal(t) == 1
+			}
+
+			if !duplicate {
+				preimages = append(preimages, t)
+			}
+		}
+	}
+
+	return preimages
+}
+
+// solveQuadratic returns the roots of a*r^2 + b*r + c = 0, with a non-zero. It is not constant-time.
+func solveQuadratic(a, b, c *field.Element) []*field.Element {
+	if a.Equal(zero) == 1 {
+		return nil
+	}
+
+	// disc = b^2 - 4*a*c
+	var disc, ac, sq, den field.Element
+
+	ac.Multiply(a, c)
+	ac.Add(&ac, &ac)
+	ac.Add(&ac, &ac)
+	disc.Square(b)
+	disc.Subtract(&disc, &ac)
+
+	if _, wasSquare := sq.SqrtRatio(&disc, one); wasSquare == 0 {
+		return nil
+	}
+
+	// r = (-b +- sqrt(disc)) / (2*a)
+	den.Add(a, a)
+	den.Invert(&den)
+
+	r0, r1 := new(field.Element), new(field.Element)
+	r0.Subtract(&sq, b)
+	r0.Multiply(r0, &den)
+	r1.Negate(&sq)
+	r1.Subtract(r1, b)
+	r1.Multiply(r1, &den)
+
+	if disc.Equal(zero) == 1 {
+		return []*field.Element{r0}
+	}
+
+	return []*field.Element{r0, r1}
+}
+
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
 func (e *Element) MarshalBinary() ([]byte, error) {
 	return e.Encode(), nil
@@ -0,0 +1,29 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package r255
+
+import (
+	"filippo.io/edwards25519"
+
+	"github.com/cymony/cryptomony/eccgroup/internal"
+)
+
+// FixedBase is a precomputed table of multiples of a Ristretto255 element.
+type FixedBase struct {
+	table *internal.EdwardsFixedBaseTable
+}
+
+// NewFixedBase returns a precomputed table of multiples of the element, for faster scalar multiplications.
+func (g *Group) NewFixedBase(e internal.Element) internal.FixedBase {
+	return &FixedBase{table: internal.NewEdwardsFixedBaseTable(cvtEl(e).e)}
+}
+
+// Multiply returns the scalar multiplication of the precomputed element with the given Scalar, in constant time.
+func (f *FixedBase) Multiply(s internal.Scalar) internal.Element {
+	out := &Element{e: edwards25519.NewIdentityPoint()}
+	f.table.Multiply(out.e, cvtScalar(s).s.Bytes())
+
+	return out
+}
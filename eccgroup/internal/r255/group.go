@@ -6,9 +6,13 @@
 package r255
 
 import (
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
 	"github.com/cymony/cryptomony/eccgroup/internal"
 	"github.com/cymony/cryptomony/hash"
 	"github.com/cymony/cryptomony/msgexpand"
+	"github.com/cymony/cryptomony/utils"
 )
 
 // Group represents the Ristretto255 group. It exposes a prime-order group API with hash-to-curve operations.
@@ -47,9 +51,30 @@ func (g *Group) Base() internal.Element {
 // HashToScalar returns a safe mapping of the arbitrary input to a Scalar.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func (g *Group) HashToScalar(input, dst []byte) internal.Scalar {
-	xmd := msgexpand.NewMessageExpandXMD(hash.SHA512)
+	return g.HashToScalarWith(msgexpand.NewMessageExpandXMD(hash.SHA512), input, dst)
+}
+
+// HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group) HashToGroup(input, dst []byte) internal.Element {
+	return g.HashToGroupWith(msgexpand.NewMessageExpandXMD(hash.SHA512), input, dst)
+}
+
+// EncodeToGroup returns a non-uniform mapping of the arbitrary input to an Element in the Group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group) EncodeToGroup(input, dst []byte) internal.Element {
+	return g.HashToGroup(input, dst)
+}
+
+// SuiteID returns the hash-to-curve suite identifier of the group with the expander identifier, e.g.
+// ristretto255_XOF:SHAKE-256_R255MAP_RO_. Ristretto255 has no encode-to-curve suite, so nonUniform is ignored.
+func (g *Group) SuiteID(expanderID string, nonUniform bool) string {
+	return "ristretto255_" + expanderID + "_R255MAP_RO_"
+}
 
-	uniform, err := xmd.Expand(input, dst, uniformSize)
+// HashToScalarWith is HashToScalar with the expander.
+func (g *Group) HashToScalarWith(expander msgexpand.MessageExpand, input, dst []byte) internal.Scalar {
+	uniform, err := expander.Expand(input, dst, uniformSize)
 	if err != nil {
 		panic(err)
 	}
@@ -62,12 +87,9 @@ func (g *Group) HashToScalar(input, dst []byte) internal.Scalar {
 	return sc
 }
 
-// HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
-// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
-func (g *Group) HashToGroup(input, dst []byte) internal.Element {
-	xmd := msgexpand.NewMessageExpandXMD(hash.SHA512)
-
-	uniform, err := xmd.Expand(input, dst, uniformSize)
+// HashToGroupWith is HashToGroup with the expander.
+func (g *Group) HashToGroupWith(expander msgexpand.MessageExpand, input, dst []byte) internal.Element {
+	uniform, err := expander.Expand(input, dst, uniformSize)
 	if err != nil {
 		panic(err)
 	}
@@ -80,10 +102,104 @@ func (g *Group) HashToGroup(input, dst []byte) internal.Element {
 	return el
 }
 
-// EncodeToGroup returns a non-uniform mapping of the arbitrary input to an Element in the Group.
-// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
-func (g *Group) EncodeToGroup(input, dst []byte) internal.Element {
-	return g.HashToGroup(input, dst)
+// EncodeToGroupWith is EncodeToGroup with the expander.
+func (g *Group) EncodeToGroupWith(expander msgexpand.MessageExpand, input, dst []byte) internal.Element {
+	return g.HashToGroupWith(expander, input, dst)
+}
+
+// UniformLength returns the byte size of the uniform input of MapToElement.
+func (g *Group) UniformLength() uint {
+	return uniformSize
+}
+
+// MapToElement deterministically maps the 64 uniform bytes to an element with the one-way map of ristretto255,
+// as HashToGroup does with the expanded input.
+func (g *Group) MapToElement(uniform []byte) (internal.Element, error) {
+	if len(uniform) != uniformSize {
+		return nil, internal.ErrUniformLength
+	}
+
+	return cvtEl(newElement()).SetUniformBytes(uniform)
+}
+
+// Preimage returns 64 uniformly random bytes that MapToElement maps to the element, with the Elligator Squared
+// method. It runs in variable time.
+// See https://eprint.iacr.org/2014/043
+func (g *Group) Preimage(e internal.Element) ([]byte, error) {
+	// maxPreimages bounds the number of non-negative field elements MAP sends to a single element.
+	const maxPreimages = 8
+
+	target := cvtEl(e)
+	half := uniformSize / 2 //nolint:gomnd //two field elements
+
+	for {
+		// Pick the first half at random, and look for the preimages of the rest under a single MAP.
+		r0 := utils.RandomBytes(half)
+
+		t0, err := new(field.Element).SetBytes(r0)
+		if err != nil {
+			return nil, err
+		}
+
+		rest := edwards25519.NewIdentityPoint()
+		mapToPoint(rest, t0)
+		rest.Subtract(target.e, rest)
+
+		preimages := mapPreimages(rest)
+
+		// Accepting with probability proportional to the number of preimages makes the output uniform. Both t and -t
+		// are preimages, and the most significant bit is ignored by the field decoding, so both are picked at random.
+		random := utils.RandomBytes(1)[0]
+
+		j := int(random % maxPreimages)
+		if j >= len(preimages) {
+			continue
+		}
+
+		t1 := new(field.Element).Set(preimages[j])
+		t1.Select(new(field.Element).Negate(t1), t1, int(random>>3)&1)
+
+		r1 := t1.Bytes()
+		r1[half-1] |= random & 0x80 //nolint:gomnd //most significant bit
+
+		return append(r0, r1...), nil
+	}
+}
+
+// MultiScalarMult returns the sum of the element-wise products of scalars and elements.
+// Small batches use Straus' method, large batches use Pippenger's method.
+// It runs in variable time and must only be used with public scalars.
+func (g *Group) MultiScalarMult(scalars []internal.Scalar, elements []internal.Element) internal.Element {
+	if len(scalars) != len(elements) {
+		panic(internal.ErrLengthMismatch)
+	}
+
+	out := &Element{e: edwards25519.NewIdentityPoint()}
+	points := make([]*edwards25519.Point, len(elements))
+
+	for i := range elements {
+		points[i] = cvtEl(elements[i]).e
+	}
+
+	if len(elements) < internal.StrausThreshold {
+		ss := make([]*edwards25519.Scalar, len(scalars))
+		for i := range scalars {
+			ss[i] = cvtScalar(scalars[i]).s
+		}
+
+		out.e.VarTimeMultiScalarMult(ss, points)
+
+		return out
+	}
+
+	ss := make([][]byte, len(scalars))
+	for i := range scalars {
+		ss[i] = cvtScalar(scalars[i]).s.Bytes()
+	}
+
+	internal.MultiScalarMult(out.e, edwards25519.NewIdentityPoint, ss, points)
+
+	return out
 }
 
 // Ciphersuite returns the hash-to-curve ciphersuite identifier.
@@ -92,7 +92,7 @@ func (s *Scalar) Subtract(ss internal.Scalar) internal.Scalar {
 }
 
 // Multiply multiplies the receiver with the input, and returns the receiver.
-// If s parameter is nil, then the receiver is not modified
+// If s parameter is nil, then the receiver is set to 0
 func (s *Scalar) Multiply(ss internal.Scalar) internal.Scalar {
 	if ss == nil {
 		s.Zero()
@@ -128,6 +128,7 @@ func (s *Scalar) IsZero() bool {
 }
 
 // Set sets the receiver to the value of the argument scalar, and returns the receiver.
+// If s parameter is nil, then the receiver is set to 0
 func (s *Scalar) Set(ss internal.Scalar) internal.Scalar {
 	if ss == nil {
 		s.Zero()
@@ -181,6 +182,27 @@ func (s *Scalar) SetCanonicalBytes(x []byte) (*Scalar, error) {
 	return s, nil
 }
 
+// BigEndian returns the big-endian encoding of the scalar, with the length of Encode.
+func (s *Scalar) BigEndian() []byte {
+	out := s.Encode()
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return out
+}
+
+// SetBigEndian sets the receiver to the canonical big-endian encoding in the input, with the length of Encode,
+// and returns an error on failure.
+func (s *Scalar) SetBigEndian(in []byte) error {
+	le := make([]byte, len(in))
+	for i := range in {
+		le[i] = in[len(in)-1-i]
+	}
+
+	return s.Decode(le)
+}
+
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
 func (s *Scalar) MarshalBinary() ([]byte, error) {
 	return s.Encode(), nil
@@ -0,0 +1,92 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package r255
+
+// The methods below write their result to a destination parameter instead of the receiver. They take and return
+// the concrete types of the package, so that they neither allocate nor go through the internal interfaces.
+
+// AddInto sets dst to the sum of the receiver and ss, and returns dst.
+func (s *Scalar) AddInto(dst, ss *Scalar) *Scalar {
+	dst.s.Add(s.s, ss.s)
+	return dst
+}
+
+// SubtractInto sets dst to the receiver minus ss, and returns dst.
+func (s *Scalar) SubtractInto(dst, ss *Scalar) *Scalar {
+	dst.s.Subtract(s.s, ss.s)
+	return dst
+}
+
+// MultiplyInto sets dst to the product of the receiver and ss, and returns dst.
+func (s *Scalar) MultiplyInto(dst, ss *Scalar) *Scalar {
+	dst.s.Multiply(s.s, ss.s)
+	return dst
+}
+
+// InvertInto sets dst to the modular inverse of the receiver, and returns dst.
+func (s *Scalar) InvertInto(dst *Scalar) *Scalar {
+	dst.s.Invert(s.s)
+	return dst
+}
+
+// CopyInto sets dst to the receiver, and returns dst.
+func (s *Scalar) CopyInto(dst *Scalar) *Scalar {
+	dst.s.Set(s.s)
+	return dst
+}
+
+// AddInto sets dst to the sum of the receiver and ee, and returns dst.
+func (e *Element) AddInto(dst, ee *Element) *Element {
+	dst.e.Add(e.e, ee.e)
+	dst.base = false
+
+	return dst
+}
+
+// SubtractInto sets dst to the receiver minus ee, and returns dst.
+func (e *Element) SubtractInto(dst, ee *Element) *Element {
+	dst.e.Subtract(e.e, ee.e)
+	dst.base = false
+
+	return dst
+}
+
+// DoubleInto sets dst to the double of the receiver, and returns dst.
+func (e *Element) DoubleInto(dst *Element) *Element {
+	dst.e.Add(e.e, e.e)
+	dst.base = false
+
+	return dst
+}
+
+// NegateInto sets dst to the negation of the receiver, and returns dst.
+func (e *Element) NegateInto(dst *Element) *Element {
+	dst.e.Negate(e.e)
+	dst.base = false
+
+	return dst
+}
+
+// MultiplyInto sets dst to the scalar multiplication of the receiver with s, and returns dst.
+// The generator is multiplied with the precomputed tables of the backend.
+func (e *Element) MultiplyInto(dst *Element, s *Scalar) *Element {
+	if e.base {
+		dst.e.ScalarBaseMult(s.s)
+	} else {
+		dst.e.ScalarMult(s.s, e.e)
+	}
+
+	dst.base = false
+
+	return dst
+}
+
+// CopyInto sets dst to the receiver, and returns dst.
+func (e *Element) CopyInto(dst *Element) *Element {
+	dst.e.Set(e.e)
+	dst.base = e.base
+
+	return dst
+}
@@ -25,7 +25,7 @@ type Scalar interface {
 	Subtract(s Scalar) Scalar
 
 	// Multiply multiplies the receiver with the input, and returns the receiver.
-	// If s parameter is nil, then the receiver is not modified
+	// If s parameter is nil, then the receiver is set to 0
 	Multiply(s Scalar) Scalar
 
 	// Invert sets the receiver to the scalar's modular inverse ( 1 / scalar ), and returns it.
@@ -38,7 +38,7 @@ type Scalar interface {
 	IsZero() bool
 
 	// Set sets the receiver to the value of the argument scalar, and returns the receiver.
-	// If s parameter is nil, then the receiver is not modified
+	// If s parameter is nil, then the receiver is set to 0
 	Set(s Scalar) Scalar
 
 	// Copy returns a copy of the receiver.
@@ -50,6 +50,13 @@ type Scalar interface {
 	// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
 	Decode(in []byte) error
 
+	// BigEndian returns the big-endian encoding of the scalar, with the length of Encode.
+	BigEndian() []byte
+
+	// SetBigEndian sets the receiver to the canonical big-endian encoding in the input, with the length of Encode,
+	// and returns an error on failure.
+	SetBigEndian(in []byte) error
+
 	// BinaryMarshaler returns a byte representation of the element.
 	encoding.BinaryMarshaler
 
@@ -0,0 +1,122 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eccgroup
+
+import (
+	"errors"
+
+	"github.com/cymony/cryptomony/eccgroup/internal/bls12381"
+)
+
+var errNilPairingInput = errors.New("pairing input is nil")
+
+// GT represents an element of the target group of the BLS12-381 pairing, written multiplicatively.
+type GT struct {
+	gt *bls12381.GT
+}
+
+// NewGT returns the identity element of GT.
+func NewGT() *GT {
+	return &GT{bls12381.NewGT()}
+}
+
+// Pair returns the pairing of the BLS12381G1Sha256 element p and the BLS12381G2Sha256 element q into GT.
+// It returns an error if the elements do not belong to these groups.
+func Pair(p, q *Element) (*GT, error) {
+	if p == nil || q == nil {
+		return nil, errNilPairingInput
+	}
+
+	gt, err := bls12381.Pair(p.Element, q.Element)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GT{gt}, nil
+}
+
+// Identity sets the receiver to the identity element of GT, and returns the receiver.
+func (t *GT) Identity() *GT {
+	t.gt.Identity()
+	return t
+}
+
+// Multiply sets the receiver to the product of the receiver and the input, and returns the receiver.
+func (t *GT) Multiply(u *GT) *GT {
+	if u == nil {
+		return t
+	}
+
+	t.gt.Multiply(u.gt)
+
+	return t
+}
+
+// Invert sets the receiver to its inverse, and returns it.
+func (t *GT) Invert() *GT {
+	t.gt.Invert()
+	return t
+}
+
+// Exponentiate sets the receiver to its power with the given Scalar, and returns it.
+// If the scalar is nil, the receiver is set to the identity element.
+func (t *GT) Exponentiate(scalar *Scalar) *GT {
+	if scalar == nil {
+		return t.Identity()
+	}
+
+	t.gt.Exponentiate(scalar.Scalar)
+
+	return t
+}
+
+// Equal returns 1 if the receiver and input element are equal, and 0 otherwise.
+func (t *GT) Equal(u *GT) int {
+	if u == nil {
+		return 0
+	}
+
+	return t.gt.Equal(u.gt)
+}
+
+// IsIdentity returns whether the receiver is the identity element of GT.
+func (t *GT) IsIdentity() bool {
+	return t.gt.IsIdentity()
+}
+
+// Copy returns a copy of the receiver.
+func (t *GT) Copy() *GT {
+	return &GT{t.gt.Copy()}
+}
+
+// Encode returns the byte encoding of the element.
+func (t *GT) Encode() []byte {
+	return t.gt.Encode()
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+func (t *GT) Decode(data []byte) error {
+	return t.gt.Decode(data)
+}
+
+// MarshalBinary returns a byte representation of the element.
+func (t *GT) MarshalBinary() ([]byte, error) {
+	return t.gt.MarshalBinary()
+}
+
+// UnmarshalBinary sets the receiver to the decoding of the byte representation of the element.
+func (t *GT) UnmarshalBinary(data []byte) error {
+	return t.gt.UnmarshalBinary(data)
+}
+
+// MarshalText returns a base64 standard string encoding of the element.
+func (t *GT) MarshalText() ([]byte, error) {
+	return t.gt.MarshalText()
+}
+
+// UnmarshalText sets the receiver to the decoding of the base64 standard string encoding of the element.
+func (t *GT) UnmarshalText(text []byte) error {
+	return t.gt.UnmarshalText(text)
+}
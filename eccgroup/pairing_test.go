@@ -0,0 +1,121 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eccgroup
+
+import (
+	"testing"
+
+	"github.com/cymony/cryptomony/internal/test"
+)
+
+func TestPairing(t *testing.T) {
+	t.Run("Bilinearity", func(tt *testing.T) { testPairingBilinearity(tt) })
+	t.Run("Identity", func(tt *testing.T) { testPairingIdentity(tt) })
+	t.Run("InvalidInput", func(tt *testing.T) { testPairingInvalidInput(tt) })
+	t.Run("GT/EncodeAndDecode", func(tt *testing.T) { testGTEncodeAndDecode(tt) })
+}
+
+func testPairingBilinearity(t *testing.T) {
+	t.Helper()
+
+	a, b := BLS12381G1Sha256.RandomScalar(), BLS12381G2Sha256.RandomScalar()
+	p, q := BLS12381G1Sha256.Base(), BLS12381G2Sha256.Base()
+
+	base, err := Pair(p, q)
+	test.CheckNoErr(t, err, "pair err")
+	test.CheckOk(t, !base.IsIdentity(), "pairing of the generators should not be the identity")
+
+	// e(a * P, b * Q) == e(P, Q)^(a * b)
+	got, err := Pair(p.Copy().Multiply(a), q.Copy().Multiply(b))
+	test.CheckNoErr(t, err, "pair err")
+
+	want := base.Copy().Exponentiate(a.Copy().Multiply(b))
+	if got.Equal(want) != 1 {
+		test.Report(t, got, want, "e(aP, bQ) != e(P, Q)^ab")
+	}
+
+	// e(P1 + P2, Q) == e(P1, Q) * e(P2, Q)
+	p1, p2 := BLS12381G1Sha256.RandomElement(), BLS12381G1Sha256.RandomElement()
+
+	got, err = Pair(p1.Copy().Add(p2), q)
+	test.CheckNoErr(t, err, "pair err")
+
+	e1, err := Pair(p1, q)
+	test.CheckNoErr(t, err, "pair err")
+
+	e2, err := Pair(p2, q)
+	test.CheckNoErr(t, err, "pair err")
+
+	if got.Equal(e1.Multiply(e2)) != 1 {
+		test.Report(t, got, e1, "e(P1 + P2, Q) != e(P1, Q) * e(P2, Q)")
+	}
+
+	// e(-P, Q) == e(P, Q)^-1
+	got, err = Pair(p.Copy().Negate(), q)
+	test.CheckNoErr(t, err, "pair err")
+	test.CheckOk(t, got.Multiply(base).IsIdentity(), "e(-P, Q) * e(P, Q) should be the identity")
+}
+
+func testPairingIdentity(t *testing.T) {
+	t.Helper()
+
+	got, err := Pair(BLS12381G1Sha256.NewElement(), BLS12381G2Sha256.Base())
+	test.CheckNoErr(t, err, "pair err")
+	test.CheckOk(t, got.IsIdentity(), "pairing with the identity should be the identity")
+
+	got, err = Pair(BLS12381G1Sha256.Base(), BLS12381G2Sha256.NewElement())
+	test.CheckNoErr(t, err, "pair err")
+	test.CheckOk(t, got.IsIdentity(), "pairing with the identity should be the identity")
+
+	test.CheckOk(t, NewGT().Exponentiate(nil).IsIdentity(), "exponentiation with nil should be the identity")
+}
+
+func testPairingInvalidInput(t *testing.T) {
+	t.Helper()
+
+	_, err := Pair(BLS12381G2Sha256.Base(), BLS12381G1Sha256.Base())
+	test.CheckIsErr(t, err, "expected error for swapped groups")
+
+	_, err = Pair(P256Sha256.Base(), BLS12381G2Sha256.Base())
+	test.CheckIsErr(t, err, "expected error for non pairing group")
+
+	_, err = Pair(nil, BLS12381G2Sha256.Base())
+	test.CheckIsErr(t, err, "expected error for nil input")
+}
+
+func testGTEncodeAndDecode(t *testing.T) {
+	t.Helper()
+
+	e, err := Pair(BLS12381G1Sha256.RandomElement(), BLS12381G2Sha256.RandomElement())
+	test.CheckNoErr(t, err, "pair err")
+
+	d := NewGT()
+	err = d.Decode(e.Encode())
+	test.CheckNoErr(t, err, "decode err")
+
+	if d.Equal(e) != 1 {
+		test.Report(t, d, e, "decode<>encode roundtrip failed")
+	}
+
+	text, err := e.MarshalText()
+	test.CheckNoErr(t, err, "marshal text err")
+
+	err = d.Identity().UnmarshalText(text)
+	test.CheckNoErr(t, err, "unmarshal text err")
+
+	if d.Equal(e) != 1 {
+		test.Report(t, d, e, "text roundtrip failed")
+	}
+
+	// A value of Fp12 out of the target group must be rejected.
+	enc := e.Encode()
+	enc[len(enc)-1] ^= 1
+
+	err = d.Decode(enc)
+	test.CheckIsErr(t, err, "expected error for value out of GT")
+
+	err = d.Decode(enc[1:])
+	test.CheckIsErr(t, err, "expected error for short encoding")
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eccgroup
+
+import (
+	"errors"
+	"io"
+	"math/big"
+)
+
+// maxRandomTries bounds the rejection sampling of RandomScalarFrom. Each candidate is accepted with probability
+// higher than 1/2, so that the bound is only reached by broken readers.
+const maxRandomTries = 128
+
+var errRandomSampling = errors.New("the random reader failed to produce a valid scalar")
+
+// RandomScalarFrom returns a non-zero scalar uniformly sampled from the bytes of the reader, or from crypto/rand if
+// it is nil. Given the same reader output, the same scalar is returned, which makes the scalar reproducible with a
+// deterministic random bit generator.
+func (g Group) RandomScalarFrom(r io.Reader) (*Scalar, error) {
+	if r == nil {
+		return g.RandomScalar(), nil
+	}
+
+	// candidates are rejected if they are not lower than the order, of which -1 has the bit length
+	bitLen := g.NewScalar().Zero().Subtract(g.NewScalar().One()).BigInt().BitLen()
+	buf := make([]byte, g.ScalarLength())
+	excess := uint(8*len(buf) - bitLen)
+
+	for try := 0; try < maxRandomTries; try++ {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+
+		buf[0] &= 0xFF >> excess
+
+		s, err := g.NewScalar().SetBigInt(new(big.Int).SetBytes(buf))
+		if err == nil && !s.IsZero() {
+			return s, nil
+		}
+	}
+
+	return nil, errRandomSampling
+}
+
+// RandomElementFrom returns the base point multiplied by a scalar of RandomScalarFrom.
+func (g Group) RandomElementFrom(r io.Reader) (*Element, error) {
+	s, err := g.RandomScalarFrom(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.Base().Multiply(s), nil
+}
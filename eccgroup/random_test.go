@@ -0,0 +1,50 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eccgroup
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cymony/cryptomony/drbg"
+	"github.com/cymony/cryptomony/hash"
+	"github.com/cymony/cryptomony/internal/test"
+)
+
+func testRandomFrom(t *testing.T, testTimes int, g Group) {
+	newReader := func() *drbg.HMAC {
+		d, err := drbg.NewHMAC(hash.SHA256, bytes.Repeat([]byte{0x07}, 32), []byte(g.String()), nil)
+		test.CheckNoErr(t, err, "drbg instantiation failed")
+
+		return d
+	}
+
+	r1, r2 := newReader(), newReader()
+
+	for i := 0; i < testTimes; i++ {
+		s1, err := g.RandomScalarFrom(r1)
+		test.CheckNoErr(t, err, "RandomScalarFrom failed")
+		s2, err := g.RandomScalarFrom(r2)
+		test.CheckNoErr(t, err, "RandomScalarFrom failed")
+		test.CheckOk(t, !s1.IsZero(), "random scalar should not be zero")
+		test.CheckOk(t, s1.Equal(s2) == 1, "same reader output should give the same scalar")
+
+		e1, err := g.RandomElementFrom(r1)
+		test.CheckNoErr(t, err, "RandomElementFrom failed")
+		e2, err := g.RandomElementFrom(r2)
+		test.CheckNoErr(t, err, "RandomElementFrom failed")
+		test.CheckOk(t, e1.Equal(e2) == 1, "same reader output should give the same element")
+	}
+
+	s, err := g.RandomScalarFrom(nil)
+	test.CheckNoErr(t, err, "nil reader should use crypto/rand")
+	test.CheckOk(t, !s.IsZero(), "random scalar should not be zero")
+
+	_, err = g.RandomScalarFrom(bytes.NewReader(nil))
+	test.CheckIsErr(t, err, "empty reader should fail")
+
+	_, err = g.RandomScalarFrom(bytes.NewReader(make([]byte, maxRandomTries*int(g.ScalarLength()))))
+	test.CheckIsErr(t, err, "zero scalars should be rejected")
+}
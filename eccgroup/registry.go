@@ -0,0 +1,147 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eccgroup
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cymony/cryptomony/eccgroup/internal"
+	"github.com/cymony/cryptomony/eccgroup/internal/bls12381"
+	"github.com/cymony/cryptomony/eccgroup/internal/decaf448"
+	"github.com/cymony/cryptomony/eccgroup/internal/ed25519"
+	"github.com/cymony/cryptomony/eccgroup/internal/nist"
+	"github.com/cymony/cryptomony/eccgroup/internal/r255"
+)
+
+// GroupImpl is the interface implemented by prime-order groups, to make them available with Register.
+// The scalars and elements it returns must only be combined with scalars and elements of the same implementation.
+type GroupImpl = internal.Group
+
+// ScalarImpl is the interface implemented by the scalars of a GroupImpl.
+type ScalarImpl = internal.Scalar
+
+// ElementImpl is the interface implemented by the elements of a GroupImpl.
+type ElementImpl = internal.Element
+
+// FixedBaseImpl is the interface implemented by the precomputed tables returned by GroupImpl.NewFixedBase.
+type FixedBaseImpl = internal.FixedBase
+
+// firstRegisteredID is the identifier of the first registered group. Identifiers below it are reserved for the groups
+// of this package, so that adding groups does not change the identifiers of registered groups.
+const firstRegisteredID Group = 0x80
+
+// builtinCiphersuites are the ciphersuite identifiers of the groups of this package, indexed by Group - 1, so that
+// they are known without initializing the groups.
+var builtinCiphersuites = [...]string{
+	Ristretto255Sha512 - 1: r255.H2C,
+	P256Sha256 - 1:         nist.H2CP256,
+	P384Sha384 - 1:         nist.H2CP384,
+	P521Sha512 - 1:         nist.H2CP521,
+	Decaf448Shake256 - 1:   decaf448.H2C,
+	Secp256k1Sha256 - 1:    nist.H2CSecp256k1,
+	Edwards25519Sha512 - 1: ed25519.H2CEdwards25519,
+	Curve25519Sha512 - 1:   ed25519.H2CCurve25519,
+	BLS12381G1Sha256 - 1:   bls12381.H2CG1,
+	BLS12381G2Sha256 - 1:   bls12381.H2CG2,
+}
+
+// builtinCiphersuites has an identifier for every group of this package.
+var _ [maxID - 1]string = builtinCiphersuites
+
+var (
+	// registryMu serializes the registrations, and registry is the copy-on-write list of the registered groups, so
+	// that the group operations read it without locking.
+	registryMu sync.Mutex
+	registry   atomic.Pointer[[]internal.Group]
+
+	errNilGroupImpl      = errors.New("nil group implementation")
+	errEmptyCiphersuite  = errors.New("empty ciphersuite identifier")
+	errDuplicateSuite    = errors.New("a group with the same ciphersuite identifier is already available")
+	errRegistryExhausted = errors.New("no group identifier left to register")
+)
+
+// Register makes the group implementation available under a new Group identifier, and returns the identifier.
+// The returned Group can be used wherever the groups of this package are, e.g. in dleq.Configuration, or to build
+// oprf and opaque suites.
+//
+// The ciphersuite identifier of the implementation must be unique, as protocols use it for domain separation.
+// Identifiers are assigned in registration order, so they are only stable across runs if registrations are too.
+func Register(impl GroupImpl) (Group, error) {
+	if impl == nil {
+		return 0, errNilGroupImpl
+	}
+
+	suite := impl.Ciphersuite()
+	if suite == "" {
+		return 0, errEmptyCiphersuite
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := GroupByCiphersuite(suite); ok {
+		return 0, errDuplicateSuite
+	}
+
+	groups := registeredGroups()
+	if len(groups) > int(^Group(0)-firstRegisteredID) {
+		return 0, errRegistryExhausted
+	}
+
+	// the registered groups are never modified, so that they can be read while registering
+	next := make([]internal.Group, len(groups), len(groups)+1)
+	copy(next, groups)
+	next = append(next, impl)
+	registry.Store(&next)
+
+	return firstRegisteredID + Group(len(groups)), nil
+}
+
+// registeredGroups returns the registered groups, in registration order.
+func registeredGroups() []internal.Group {
+	if groups := registry.Load(); groups != nil {
+		return *groups
+	}
+
+	return nil
+}
+
+// GroupByCiphersuite returns the available Group with the given ciphersuite identifier, as returned by Group.String,
+// and whether there is one.
+func GroupByCiphersuite(suite string) (Group, bool) {
+	for i, s := range builtinCiphersuites {
+		if s == suite {
+			return Group(i + 1), true
+		}
+	}
+
+	for i, r := range registeredGroups() {
+		if r.Ciphersuite() == suite {
+			return firstRegisteredID + Group(i), true
+		}
+	}
+
+	return 0, false
+}
+
+// registered returns the registered implementation of the group, and whether there is one.
+func (g Group) registered() (internal.Group, bool) {
+	if g < firstRegisteredID {
+		return nil, false
+	}
+
+	if groups, i := registeredGroups(), int(g-firstRegisteredID); i < len(groups) {
+		return groups[i], true
+	}
+
+	return nil, false
+}
+
+// Impl returns the implementation of the group, e.g. to wrap it in another GroupImpl before registering it.
+func (g Group) Impl() GroupImpl {
+	return g.get()
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eccgroup
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/cymony/cryptomony/internal/test"
+)
+
+// renamedGroup is a group implementation registered under another ciphersuite identifier.
+type renamedGroup struct {
+	GroupImpl
+	suite string
+}
+
+func (r *renamedGroup) Ciphersuite() string {
+	return r.suite
+}
+
+func TestRegister(t *testing.T) {
+	const testTimes = 1 << 5
+
+	g, err := Register(&renamedGroup{GroupImpl: Ristretto255Sha512.Impl(), suite: "registered-ristretto255"})
+	test.CheckNoErr(t, err, "registration failed")
+	test.CheckOk(t, g >= firstRegisteredID, "registered identifier in the reserved range")
+	test.CheckOk(t, g.Available(), "registered group should be available")
+	test.CheckOk(t, g.String() == "registered-ristretto255", "wrong ciphersuite identifier")
+	test.CheckOk(t, !(g + 1).Available(), "next identifier should not be available")
+
+	t.Run("Scalar/Add", func(tt *testing.T) { testAddScalar(tt, testTimes, g) })
+	t.Run("Scalar/BatchInvert", func(tt *testing.T) { testBatchInvertScalar(tt, testTimes, g) })
+	t.Run("Element/AddAndDouble", func(tt *testing.T) { testAddAndDouble(tt, testTimes, g) })
+	t.Run("Element/Multiply", func(tt *testing.T) { testMultiply(tt, testTimes, g) })
+	t.Run("Element/EncodeAndDecode", func(tt *testing.T) { testEncodeAndDecode(tt, testTimes, g) })
+	t.Run("Group/MultiScalarMult", func(tt *testing.T) { testMultiScalarMult(tt, testTimes, g) })
+	t.Run("Group/FixedBase", func(tt *testing.T) { testFixedBase(tt, testTimes, g) })
+
+	_, err = Register(nil)
+	test.CheckIsErr(t, err, "nil implementation should be rejected")
+
+	_, err = Register(&renamedGroup{GroupImpl: P256Sha256.Impl()})
+	test.CheckIsErr(t, err, "empty ciphersuite should be rejected")
+
+	_, err = Register(P256Sha256.Impl())
+	test.CheckIsErr(t, err, "built-in ciphersuite should be rejected")
+
+	_, err = Register(&renamedGroup{GroupImpl: P256Sha256.Impl(), suite: "registered-ristretto255"})
+	test.CheckIsErr(t, err, "duplicate ciphersuite should be rejected")
+
+	err = test.CheckPanic(func() {
+		(g + 1).NewScalar()
+	})
+	test.CheckNoErr(t, err, "panic expected")
+}
+
+func TestRegisterConcurrent(t *testing.T) {
+	g, err := Register(&renamedGroup{GroupImpl: P256Sha256.Impl(), suite: "concurrent-p256"})
+	test.CheckNoErr(t, err, "registration failed")
+
+	// registered groups are used while others are registered
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(2) //nolint:gomnd //two goroutines
+
+		go func(i int) {
+			defer wg.Done()
+
+			_, err := Register(&renamedGroup{GroupImpl: P256Sha256.Impl(), suite: "concurrent-p256-" + strconv.Itoa(i)})
+			test.CheckNoErr(t, err, "registration failed")
+		}(i)
+
+		go func() {
+			defer wg.Done()
+
+			s := g.RandomScalar()
+			test.CheckOk(t, g.Base().Multiply(s).Equal(g.NewElement().Base().Multiply(s)) == 1, "wrong multiplication")
+		}()
+	}
+
+	wg.Wait()
+
+	for i := 0; i < 8; i++ {
+		r, ok := GroupByCiphersuite("concurrent-p256-" + strconv.Itoa(i))
+		test.CheckOk(t, ok && r.String() == "concurrent-p256-"+strconv.Itoa(i), "registered group not found")
+	}
+
+	// the built-in groups are found by their ciphersuite identifiers
+	for id := Group(1); id < maxID; id++ {
+		r, ok := GroupByCiphersuite(id.String())
+		test.CheckOk(t, ok && r == id, "built-in group not found: "+id.String())
+	}
+}
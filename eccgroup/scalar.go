@@ -5,16 +5,26 @@
 package eccgroup
 
 import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+
 	"github.com/cymony/cryptomony/eccgroup/internal"
 )
 
+var (
+	errScalarRange    = errors.New("integer out of the scalar range")
+	errScalarOverflow = errors.New("scalar overflows uint64")
+)
+
 // Scalar represents a scalar in the prime-order group.
 type Scalar struct {
 	internal.Scalar
+	g Group
 }
 
-func newScalar(s internal.Scalar) *Scalar {
-	return &Scalar{s}
+func newScalar(g Group, s internal.Scalar) *Scalar {
+	return &Scalar{s, g}
 }
 
 // Zero sets the scalar to 0, and returns it.
@@ -97,7 +107,7 @@ func (s *Scalar) Set(scalar *Scalar) *Scalar {
 
 // Copy returns a copy of the receiver.
 func (s *Scalar) Copy() *Scalar {
-	return &Scalar{s.Scalar.Copy()}
+	return &Scalar{s.Scalar.Copy(), s.g}
 }
 
 // Encode returns the compressed byte encoding of the scalar.
@@ -110,6 +120,136 @@ func (s *Scalar) Decode(in []byte) error {
 	return s.Scalar.Decode(in)
 }
 
+// Pow sets the receiver to the scalar raised to the power of the exponent, and returns it.
+// A negative exponent raises the scalar's inverse. The computation runs in variable time with respect to the
+// exponent, which must therefore be public.
+func (s *Scalar) Pow(exponent *big.Int) *Scalar {
+	base := s.Copy()
+	if exponent.Sign() < 0 {
+		base.Invert()
+	}
+
+	e := new(big.Int).Abs(exponent)
+
+	s.One()
+
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		s.Multiply(s)
+
+		if e.Bit(i) == 1 {
+			s.Multiply(base)
+		}
+	}
+
+	return s
+}
+
+// BigInt returns the scalar as a non-negative integer lower than the group order.
+func (s *Scalar) BigInt() *big.Int {
+	return new(big.Int).SetBytes(s.Scalar.BigEndian())
+}
+
+// SetBigInt sets the receiver to the integer, and returns an error if it is negative or not lower than the group order.
+func (s *Scalar) SetBigInt(i *big.Int) (*Scalar, error) {
+	buf := make([]byte, len(s.Scalar.Encode()))
+
+	if i.Sign() < 0 || i.BitLen() > 8*len(buf) {
+		return nil, errScalarRange
+	}
+
+	if err := s.Scalar.SetBigEndian(i.FillBytes(buf)); err != nil {
+		return nil, errScalarRange
+	}
+
+	return s, nil
+}
+
+// Uint64 returns the scalar as an uint64, and returns an error if it does not fit.
+func (s *Scalar) Uint64() (uint64, error) {
+	i := s.BigInt()
+	if !i.IsUint64() {
+		return 0, errScalarOverflow
+	}
+
+	return i.Uint64(), nil
+}
+
+// SetUint64 sets the receiver to the integer, and returns the receiver.
+func (s *Scalar) SetUint64(i uint64) *Scalar {
+	buf := make([]byte, len(s.Scalar.Encode()))
+	binary.BigEndian.PutUint64(buf[len(buf)-8:], i)
+
+	// all group orders are larger than 2^64
+	if err := s.Scalar.SetBigEndian(buf); err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// Compare compares the integer values of the scalars in constant time, and returns -1 if s < scalar,
+// 0 if s == scalar, and +1 if s > scalar. It panics if the scalars belong to different groups.
+func (s *Scalar) Compare(scalar *Scalar) int {
+	if s.g != scalar.g {
+		panic(internal.ErrCastScalar)
+	}
+
+	x, y := s.Scalar.BigEndian(), scalar.Scalar.BigEndian()
+	if len(x) != len(y) {
+		panic(internal.ErrCastScalar)
+	}
+
+	// gt and lt are set by the most significant differing byte, and kept afterwards.
+	var gt, lt int
+
+	for i := range x {
+		a, b := int32(x[i]), int32(y[i])
+		done := gt | lt
+		gt |= ^done & int((b-a)>>31&1)
+		lt |= ^done & int((a-b)>>31&1)
+	}
+
+	return gt - lt
+}
+
+// Less returns 1 if the integer value of the receiver is lower than the input's, and 0 otherwise, in constant time.
+func (s *Scalar) Less(scalar *Scalar) int {
+	return (s.Compare(scalar) >> 1) & 1
+}
+
+// BatchInvert sets every scalar to its modular inverse, with a single inversion for the whole batch
+// (Montgomery's trick). Zero scalars are left unchanged, and leak their position through timing.
+func BatchInvert(scalars []*Scalar) {
+	if len(scalars) == 0 {
+		return
+	}
+
+	// products[i] is the product of the non-zero scalars before i
+	products := make([]*Scalar, len(scalars))
+	acc := scalars[0].Copy().One()
+
+	for i, sc := range scalars {
+		products[i] = acc.Copy()
+
+		if !sc.IsZero() {
+			acc.Multiply(sc)
+		}
+	}
+
+	acc.Invert()
+
+	for i := len(scalars) - 1; i >= 0; i-- {
+		if scalars[i].IsZero() {
+			continue
+		}
+
+		// inv = acc * products[i], and acc becomes the inverse of the product of the scalars before i
+		inv := products[i].Multiply(acc)
+		acc.Multiply(scalars[i])
+		scalars[i].Set(inv)
+	}
+}
+
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
 func (s *Scalar) MarshalBinary() ([]byte, error) {
 	return s.Scalar.MarshalBinary()
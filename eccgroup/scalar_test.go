@@ -5,6 +5,7 @@
 package eccgroup
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/cymony/cryptomony/internal/test"
@@ -343,3 +344,150 @@ func BenchmarkScalar(b *testing.B) {
 		})
 	}
 }
+
+func testBatchInvertScalar(t *testing.T, testTimes int, g Group) {
+	t.Helper()
+
+	BatchInvert(nil)
+
+	scalars := make([]*Scalar, testTimes)
+	want := make([]*Scalar, testTimes)
+
+	for i := range scalars {
+		if i%7 == 3 {
+			scalars[i] = g.NewScalar().Zero()
+		} else {
+			scalars[i] = g.RandomScalar()
+		}
+
+		want[i] = scalars[i].Copy().Invert()
+	}
+
+	BatchInvert(scalars)
+
+	for i := range scalars {
+		if !(scalars[i].Equal(want[i]) == 1) {
+			test.Report(t, scalars[i], want[i], i)
+		}
+	}
+}
+
+func testPowScalar(t *testing.T, testTimes int, g Group) {
+	t.Helper()
+
+	for i := 0; i < testTimes; i++ {
+		x := g.RandomScalar()
+		e := int64(i)
+
+		want := g.NewScalar().One()
+		for j := int64(0); j < e; j++ {
+			want.Multiply(x)
+		}
+
+		got := x.Copy().Pow(big.NewInt(e))
+		if !(got.Equal(want) == 1) {
+			test.Report(t, got, want, e)
+		}
+
+		got = x.Copy().Pow(big.NewInt(-e))
+		if !(got.Equal(want.Invert()) == 1) {
+			test.Report(t, got, want, -e)
+		}
+	}
+
+	// x^(order-1) = 1
+	x := g.RandomScalar()
+	order := new(big.Int).Add(g.NewScalar().Zero().Subtract(g.NewScalar().One()).BigInt(), big.NewInt(1))
+	got := x.Pow(new(big.Int).Sub(order, big.NewInt(1)))
+	test.CheckOk(t, got.Equal(g.NewScalar().One()) == 1, "fermat's little theorem does not hold")
+}
+
+func testConversionsScalar(t *testing.T, testTimes int, g Group) {
+	t.Helper()
+
+	for i := 0; i < testTimes; i++ {
+		x := g.RandomScalar()
+
+		y, err := g.NewScalar().SetBigInt(x.BigInt())
+		test.CheckNoErr(t, err, "SetBigInt failed")
+
+		if !(x.Equal(y) == 1) {
+			test.Report(t, y, x, "big.Int round trip")
+		}
+	}
+
+	for _, v := range []uint64{0, 1, 2, 255, 256, 1<<32 + 1, 1<<64 - 1} {
+		x := g.NewScalar().SetUint64(v)
+
+		got, err := x.Uint64()
+		test.CheckNoErr(t, err, "Uint64 failed")
+
+		if got != v {
+			test.Report(t, got, v, "uint64 round trip")
+		}
+
+		if x.BigInt().Cmp(new(big.Int).SetUint64(v)) != 0 {
+			test.Report(t, x.BigInt(), v, "big.Int mismatch")
+		}
+	}
+
+	minusOne := g.NewScalar().Zero().Subtract(g.NewScalar().One())
+	order := new(big.Int).Add(minusOne.BigInt(), big.NewInt(1))
+
+	_, err := minusOne.Uint64()
+	test.CheckIsErr(t, err, "Uint64 should overflow")
+
+	_, err = g.NewScalar().SetBigInt(order)
+	test.CheckIsErr(t, err, "order should be rejected")
+
+	_, err = g.NewScalar().SetBigInt(big.NewInt(-1))
+	test.CheckIsErr(t, err, "negative integers should be rejected")
+
+	_, err = g.NewScalar().SetBigInt(new(big.Int).Lsh(order, 64))
+	test.CheckIsErr(t, err, "large integers should be rejected")
+}
+
+func testCompareScalar(t *testing.T, testTimes int, g Group) {
+	t.Helper()
+
+	for i := 0; i < testTimes; i++ {
+		x, y := g.RandomScalar(), g.RandomScalar()
+
+		want := x.BigInt().Cmp(y.BigInt())
+		if got := x.Compare(y); got != want {
+			test.Report(t, got, want, x, y)
+		}
+
+		wantLess := 0
+		if want < 0 {
+			wantLess = 1
+		}
+
+		if got := x.Less(y); got != wantLess {
+			test.Report(t, got, wantLess, x, y)
+		}
+
+		test.CheckOk(t, x.Compare(x.Copy()) == 0, "scalar should be equal to its copy")
+		test.CheckOk(t, x.Less(x.Copy()) == 0, "scalar should not be less than its copy")
+	}
+
+	small, large := g.NewScalar().SetUint64(256), g.NewScalar().SetUint64(257)
+	test.CheckOk(t, small.Compare(large) == -1 && large.Compare(small) == 1, "compare mismatch")
+
+	// scalars of the same length but of different groups are not compared
+	other := otherGroup(g).NewScalar().SetUint64(257)
+	err := test.CheckPanic(func() { small.Compare(other) })
+	test.CheckNoErr(t, err, "scalars of different groups should not be compared")
+}
+
+// TestScalarImplNil checks that every backend sets its scalars to 0 when they are set to or multiplied with nil, as
+// the ScalarImpl interface documents.
+func TestScalarImplNil(t *testing.T) {
+	for g := Group(1); g < maxID; g++ {
+		s := g.RandomScalar().Scalar
+		test.CheckOk(t, s.Set(nil).IsZero(), g.String()+": Set(nil) should set the scalar to 0")
+
+		s = g.RandomScalar().Scalar
+		test.CheckOk(t, s.Multiply(nil).IsZero(), g.String()+": Multiply(nil) should set the scalar to 0")
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eccgroup
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// The tagged binary encoding of a scalar or an element is group || kind || encoding, where group is the one byte
+// Group identifier, and kind is one byte telling scalars and elements apart.
+// The identifiers of registered groups depend on the registration order, so their tagged binary encodings must only
+// be decoded by programs registering the same groups in the same order. The JSON encoding uses the ciphersuite
+// identifier of the group instead, and has no such restriction.
+const (
+	tagScalar  byte = 0x01
+	tagElement byte = 0x02
+
+	taggedHeaderLength = 2
+)
+
+var (
+	errNoGroup       = errors.New("value is not bound to a group")
+	errGroupMismatch = errors.New("encoded value belongs to another group")
+	errUnknownSuite  = errors.New("unknown group ciphersuite identifier")
+	errInvalidTagged = errors.New("invalid tagged encoding")
+)
+
+// Tagged is a scalar or an element decoded by DecodeTagged, with its group.
+type Tagged struct {
+	Group   Group
+	Scalar  *Scalar  // set if the encoding is a scalar's
+	Element *Element // set if the encoding is an element's
+}
+
+// DecodeTagged decodes a scalar or an element from its tagged binary encoding, as returned by MarshalTagged, with the
+// group given by the encoding.
+func DecodeTagged(data []byte) (*Tagged, error) {
+	if len(data) < taggedHeaderLength || !Group(data[0]).Available() {
+		return nil, errInvalidTagged
+	}
+
+	t := &Tagged{Group: Group(data[0])}
+
+	switch data[1] {
+	case tagScalar:
+		t.Scalar = t.Group.NewScalar()
+		if err := t.Scalar.Decode(data[taggedHeaderLength:]); err != nil {
+			return nil, err
+		}
+	case tagElement:
+		t.Element = t.Group.NewElement()
+		if err := t.Element.Decode(data[taggedHeaderLength:]); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errInvalidTagged
+	}
+
+	return t, nil
+}
+
+// Group returns the group of the scalar.
+func (s *Scalar) Group() Group {
+	return s.g
+}
+
+// Group returns the group of the element.
+func (e *Element) Group() Group {
+	return e.g
+}
+
+// checkTag returns the group of a tagged encoding of the given kind, and an error if the receiver belongs to another group.
+func checkTag(data []byte, kind byte, receiver Group, bound bool) (Group, error) {
+	if len(data) < taggedHeaderLength || data[1] != kind || !Group(data[0]).Available() {
+		return 0, errInvalidTagged
+	}
+
+	if g := Group(data[0]); !bound || g == receiver {
+		return g, nil
+	}
+
+	return 0, errGroupMismatch
+}
+
+// checkSuite returns the group of a ciphersuite identifier, and an error if the receiver belongs to another group.
+func checkSuite(suite string, receiver Group, bound bool) (Group, error) {
+	g, ok := GroupByCiphersuite(suite)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", errUnknownSuite, suite)
+	}
+
+	if bound && g != receiver {
+		return 0, errGroupMismatch
+	}
+
+	return g, nil
+}
+
+// decode sets the receiver to the decoding of the input data in the given group, and binds it to the group if it
+// is not bound yet. The receiver is not modified on failure.
+func (s *Scalar) decode(g Group, data []byte) error {
+	sc := g.get().NewScalar()
+	if err := sc.Decode(data); err != nil {
+		return err
+	}
+
+	if s.Scalar == nil {
+		s.Scalar, s.g = sc, g
+		return nil
+	}
+
+	s.Scalar.Set(sc)
+
+	return nil
+}
+
+// decode sets the receiver to the decoding of the input data in the given group, and binds it to the group if it
+// is not bound yet. The receiver is not modified on failure.
+func (e *Element) decode(g Group, data []byte) error {
+	el := g.get().NewElement()
+	if err := el.Decode(data); err != nil {
+		return err
+	}
+
+	if e.Element == nil {
+		e.Element, e.g = el, g
+		return nil
+	}
+
+	e.Element.Set(el)
+
+	return nil
+}
+
+// MarshalTagged returns the tagged binary encoding of the scalar, which carries its group.
+func (s *Scalar) MarshalTagged() ([]byte, error) {
+	if s.Scalar == nil || !s.g.Available() {
+		return nil, errNoGroup
+	}
+
+	return append([]byte{byte(s.g), tagScalar}, s.Encode()...), nil
+}
+
+// UnmarshalTagged sets the receiver to the decoding of the tagged binary encoding, and returns an error on failure
+// or if the receiver belongs to another group. A zero Scalar is bound to the group of the encoding.
+func (s *Scalar) UnmarshalTagged(data []byte) error {
+	g, err := checkTag(data, tagScalar, s.g, s.Scalar != nil)
+	if err != nil {
+		return err
+	}
+
+	return s.decode(g, data[taggedHeaderLength:])
+}
+
+// MarshalTagged returns the tagged binary encoding of the element, which carries its group.
+func (e *Element) MarshalTagged() ([]byte, error) {
+	if e.Element == nil || !e.g.Available() {
+		return nil, errNoGroup
+	}
+
+	return append([]byte{byte(e.g), tagElement}, e.Encode()...), nil
+}
+
+// UnmarshalTagged sets the receiver to the decoding of the tagged binary encoding, and returns an error on failure
+// or if the receiver belongs to another group. A zero Element is bound to the group of the encoding.
+func (e *Element) UnmarshalTagged(data []byte) error {
+	g, err := checkTag(data, tagElement, e.g, e.Element != nil)
+	if err != nil {
+		return err
+	}
+
+	return e.decode(g, data[taggedHeaderLength:])
+}
+
+// jsonText reports whether the JSON data is a string, as in the former JSON encoding of scalars and elements, and
+// returns its content. The string can only be decoded by a receiver bound to a group.
+func jsonText(data []byte, bound bool) (text []byte, ok bool, err error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '"' {
+		return nil, false, nil
+	}
+
+	if !bound {
+		return nil, true, errNoGroup
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return nil, true, err
+	}
+
+	return []byte(str), true, nil
+}
+
+type jsonScalar struct {
+	Group  string `json:"group"`
+	Scalar []byte `json:"scalar"`
+}
+
+type jsonElement struct {
+	Group   string `json:"group"`
+	Element []byte `json:"element"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. The scalar is encoded with the ciphersuite identifier of its
+// group, and its base64 encoding.
+func (s *Scalar) MarshalJSON() ([]byte, error) {
+	if s.Scalar == nil || !s.g.Available() {
+		return nil, errNoGroup
+	}
+
+	return json.Marshal(&jsonScalar{Group: s.g.String(), Scalar: s.Encode()})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, and returns an error if the receiver belongs to another
+// group. A zero Scalar is bound to the group of the encoding. The former encoding, a JSON string of the MarshalText
+// encoding, is decoded in the group of the receiver, which must be bound to a group.
+func (s *Scalar) UnmarshalJSON(data []byte) error {
+	if text, ok, err := jsonText(data, s.Scalar != nil); ok {
+		if err != nil {
+			return err
+		}
+
+		return s.UnmarshalText(text)
+	}
+
+	var js jsonScalar
+	if err := json.Unmarshal(data, &js); err != nil {
+		return err
+	}
+
+	g, err := checkSuite(js.Group, s.g, s.Scalar != nil)
+	if err != nil {
+		return err
+	}
+
+	return s.decode(g, js.Scalar)
+}
+
+// MarshalJSON implements the json.Marshaler interface. The element is encoded with the ciphersuite identifier of its
+// group, and its base64 encoding.
+func (e *Element) MarshalJSON() ([]byte, error) {
+	if e.Element == nil || !e.g.Available() {
+		return nil, errNoGroup
+	}
+
+	return json.Marshal(&jsonElement{Group: e.g.String(), Element: e.Encode()})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, and returns an error if the receiver belongs to another
+// group. A zero Element is bound to the group of the encoding. The former encoding, a JSON string of the MarshalText
+// encoding, is decoded in the group of the receiver, which must be bound to a group.
+func (e *Element) UnmarshalJSON(data []byte) error {
+	if text, ok, err := jsonText(data, e.Element != nil); ok {
+		if err != nil {
+			return err
+		}
+
+		return e.UnmarshalText(text)
+	}
+
+	var je jsonElement
+	if err := json.Unmarshal(data, &je); err != nil {
+		return err
+	}
+
+	g, err := checkSuite(je.Group, e.g, e.Element != nil)
+	if err != nil {
+		return err
+	}
+
+	return e.decode(g, je.Element)
+}
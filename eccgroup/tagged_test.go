@@ -0,0 +1,148 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eccgroup
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cymony/cryptomony/internal/test"
+)
+
+// otherGroup returns a group different from g.
+func otherGroup(g Group) Group {
+	if g == P256Sha256 {
+		return Ristretto255Sha512
+	}
+
+	return P256Sha256
+}
+
+func testTaggedJSON(t *testing.T, testTimes int, g Group) {
+	t.Helper()
+
+	type record struct {
+		Key   *Scalar  `json:"key"`
+		Point *Element `json:"point"`
+	}
+
+	for i := 0; i < testTimes; i++ {
+		want := record{Key: g.RandomScalar(), Point: g.RandomElement()}
+
+		data, err := json.Marshal(&want)
+		test.CheckNoErr(t, err, "marshaling failed")
+
+		var got record
+
+		err = json.Unmarshal(data, &got)
+		test.CheckNoErr(t, err, "unmarshaling failed")
+		test.CheckOk(t, got.Key.Group() == g && got.Point.Group() == g, "decoded values bound to the wrong group")
+		test.CheckOk(t, got.Key.Equal(want.Key) == 1, "scalar mismatch")
+		test.CheckOk(t, got.Point.Equal(want.Point) == 1, "element mismatch")
+
+		// values bound to the same group are overwritten
+		got = record{Key: g.NewScalar(), Point: g.NewElement()}
+		err = json.Unmarshal(data, &got)
+		test.CheckNoErr(t, err, "unmarshaling failed")
+		test.CheckOk(t, got.Key.Equal(want.Key) == 1 && got.Point.Equal(want.Point) == 1, "decoded values mismatch")
+
+		// values bound to another group are not
+		other := otherGroup(g)
+		err = json.Unmarshal(data, &record{Key: other.NewScalar()})
+		test.CheckIsErr(t, err, "scalar of another group should be rejected")
+		err = json.Unmarshal(data, &record{Point: other.NewElement()})
+		test.CheckIsErr(t, err, "element of another group should be rejected")
+	}
+
+	_, err := json.Marshal(&Element{})
+	test.CheckIsErr(t, err, "element without group should not be marshaled")
+	_, err = json.Marshal(&Scalar{})
+	test.CheckIsErr(t, err, "scalar without group should not be marshaled")
+
+	err = json.Unmarshal([]byte(`{"group":"unknown","element":"AA=="}`), &Element{})
+	test.CheckIsErr(t, err, "unknown group should be rejected")
+	err = json.Unmarshal([]byte(`{"group":"`+g.String()+`","scalar":""}`), &Scalar{})
+	test.CheckIsErr(t, err, "invalid scalar encoding should be rejected")
+}
+
+func testTaggedBinary(t *testing.T, testTimes int, g Group) {
+	t.Helper()
+
+	for i := 0; i < testTimes; i++ {
+		s, e := g.RandomScalar(), g.RandomElement()
+
+		sData, err := s.MarshalTagged()
+		test.CheckNoErr(t, err, "marshaling failed")
+		eData, err := e.MarshalTagged()
+		test.CheckNoErr(t, err, "marshaling failed")
+
+		decoded, err := DecodeTagged(sData)
+		test.CheckNoErr(t, err, "decoding failed")
+		test.CheckOk(t, decoded.Group == g && decoded.Element == nil, "wrong decoded scalar")
+		test.CheckOk(t, decoded.Scalar.Group() == g && decoded.Scalar.Equal(s) == 1, "scalar mismatch")
+
+		decoded, err = DecodeTagged(eData)
+		test.CheckNoErr(t, err, "decoding failed")
+		test.CheckOk(t, decoded.Group == g && decoded.Scalar == nil, "wrong decoded element")
+		test.CheckOk(t, decoded.Element.Group() == g && decoded.Element.Equal(e) == 1, "element mismatch")
+
+		var sc Scalar
+
+		test.CheckNoErr(t, sc.UnmarshalTagged(sData), "unmarshaling failed")
+		test.CheckOk(t, sc.Group() == g && sc.Equal(s) == 1, "scalar mismatch")
+
+		var el Element
+
+		test.CheckNoErr(t, el.UnmarshalTagged(eData), "unmarshaling failed")
+		test.CheckOk(t, el.Group() == g && el.Equal(e) == 1, "element mismatch")
+
+		test.CheckIsErr(t, el.UnmarshalTagged(sData), "scalar encoding should be rejected as element")
+		test.CheckIsErr(t, sc.UnmarshalTagged(eData), "element encoding should be rejected as scalar")
+		test.CheckIsErr(t, otherGroup(g).NewElement().UnmarshalTagged(eData), "element of another group should be rejected")
+		test.CheckIsErr(t, otherGroup(g).NewScalar().UnmarshalTagged(sData), "scalar of another group should be rejected")
+	}
+
+	_, err := (&Element{}).MarshalTagged()
+	test.CheckIsErr(t, err, "element without group should not be marshaled")
+
+	for _, data := range [][]byte{nil, {byte(g)}, {0, tagElement}, {byte(maxID), tagScalar}, {byte(g), 0x03}, {byte(g), tagElement}} {
+		_, err = DecodeTagged(data)
+		test.CheckIsErr(t, err, "invalid tagged encoding should be rejected")
+	}
+}
+
+func TestLegacyJSON(t *testing.T) {
+	type record struct {
+		Key   *Scalar  `json:"key"`
+		Point *Element `json:"point"`
+	}
+
+	dst := []byte("baseline-json-test-dst")
+
+	// encodings of MarshalText, the former JSON encoding
+	for _, v := range []struct {
+		g    Group
+		data string
+	}{
+		{
+			g:    P256Sha256,
+			data: `{"key":"HnRcrxUJAnI4gLPV/b3qzjqgjOWlAkvD4seu8jReUz8=","point":"AkenoHRydVA0dmbSmaHps8Z509vxpBfYOHh154cvH/Uj"}`,
+		},
+		{
+			g:    Ristretto255Sha512,
+			data: `{"key":"OhzafXofUH3awFBlOAQHo9s5vymxi/HzR9WDFELx8AE=","point":"9EIs7SKiC2tekIS38T6fypnv6jC/yd88ckGqwR92XhE="}`,
+		},
+	} {
+		got := record{Key: v.g.NewScalar(), Point: v.g.NewElement()}
+		err := json.Unmarshal([]byte(v.data), &got)
+		test.CheckNoErr(t, err, "unmarshaling failed")
+		test.CheckOk(t, got.Key.Equal(v.g.HashToScalar([]byte("key"), dst)) == 1, "scalar mismatch")
+		test.CheckOk(t, got.Point.Equal(v.g.HashToGroup([]byte("point"), dst)) == 1, "element mismatch")
+
+		// the group of the receiver is required
+		err = json.Unmarshal([]byte(v.data), &record{})
+		test.CheckIsErr(t, err, "values without group should be rejected")
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eccgroup
+
+import (
+	"github.com/cymony/cryptomony/eccgroup/internal"
+	"github.com/cymony/cryptomony/eccgroup/internal/nist"
+	"github.com/cymony/cryptomony/eccgroup/internal/r255"
+)
+
+// Ristretto255Scalar is the scalar type of the typed Ristretto255 group.
+type Ristretto255Scalar = r255.Scalar
+
+// Ristretto255Element is the element type of the typed Ristretto255 group.
+type Ristretto255Element = r255.Element
+
+// NISTScalar is the scalar type of the typed NIST and secp256k1 groups.
+type NISTScalar = nist.Scalar
+
+// P256Element is the element type of the typed P256 group.
+type P256Element = nist.P256Element
+
+// P384Element is the element type of the typed P384 group.
+type P384Element = nist.P384Element
+
+// P521Element is the element type of the typed P521 group.
+type P521Element = nist.P521Element
+
+// Secp256k1Element is the element type of the typed secp256k1 group.
+type Secp256k1Element = nist.Secp256k1Element
+
+// TypedScalar is implemented by the concrete scalar types of the typed groups. Its methods write their result to a
+// destination parameter, which may be the receiver or the argument, and return it.
+type TypedScalar[S any] interface {
+	internal.Scalar
+
+	// AddInto sets dst to the sum of the receiver and s, and returns dst.
+	AddInto(dst, s S) S
+
+	// SubtractInto sets dst to the receiver minus s, and returns dst.
+	SubtractInto(dst, s S) S
+
+	// MultiplyInto sets dst to the product of the receiver and s, and returns dst.
+	MultiplyInto(dst, s S) S
+
+	// InvertInto sets dst to the modular inverse of the receiver, and returns dst.
+	InvertInto(dst S) S
+
+	// CopyInto sets dst to the receiver, and returns dst.
+	CopyInto(dst S) S
+}
+
+// TypedElement is implemented by the concrete element types of the typed groups, with S the scalar type of the group.
+// Its methods write their result to a destination parameter, which may be the receiver or the argument, and return it.
+type TypedElement[S, E any] interface {
+	internal.Element
+
+	// AddInto sets dst to the sum of the receiver and e, and returns dst.
+	AddInto(dst, e E) E
+
+	// SubtractInto sets dst to the receiver minus e, and returns dst.
+	SubtractInto(dst, e E) E
+
+	// DoubleInto sets dst to the double of the receiver, and returns dst.
+	DoubleInto(dst E) E
+
+	// NegateInto sets dst to the negation of the receiver, and returns dst.
+	NegateInto(dst E) E
+
+	// MultiplyInto sets dst to the scalar multiplication of the receiver with s, and returns dst.
+	MultiplyInto(dst E, s S) E
+
+	// CopyInto sets dst to the receiver, and returns dst.
+	CopyInto(dst E) E
+}
+
+// G is the typed API of a Group, whose scalars and elements have the concrete types S and E instead of the
+// Scalar and Element wrappers. Operations on typed values do not go through interfaces, and write their result
+// to a destination parameter instead of allocating, e.g. e.MultiplyInto(dst, s).
+//
+// Typed values can be converted from and to the Group's Scalar and Element, with which they share their state.
+type G[S TypedScalar[S], E TypedElement[S, E]] struct {
+	id Group
+}
+
+var (
+	ristretto255 = G[*Ristretto255Scalar, *Ristretto255Element]{Ristretto255Sha512}
+	p256         = G[*NISTScalar, *P256Element]{P256Sha256}
+	p384         = G[*NISTScalar, *P384Element]{P384Sha384}
+	p521         = G[*NISTScalar, *P521Element]{P521Sha512}
+	secp256k1    = G[*NISTScalar, *Secp256k1Element]{Secp256k1Sha256}
+)
+
+// Ristretto255 returns the typed API of the Ristretto255Sha512 group.
+func Ristretto255() *G[*Ristretto255Scalar, *Ristretto255Element] {
+	return &ristretto255
+}
+
+// P256 returns the typed API of the P256Sha256 group.
+func P256() *G[*NISTScalar, *P256Element] {
+	return &p256
+}
+
+// P384 returns the typed API of the P384Sha384 group.
+func P384() *G[*NISTScalar, *P384Element] {
+	return &p384
+}
+
+// P521 returns the typed API of the P521Sha512 group.
+func P521() *G[*NISTScalar, *P521Element] {
+	return &p521
+}
+
+// Secp256k1 returns the typed API of the Secp256k1Sha256 group.
+func Secp256k1() *G[*NISTScalar, *Secp256k1Element] {
+	return &secp256k1
+}
+
+// Group returns the identifier of the group.
+func (g *G[S, E]) Group() Group {
+	return g.id
+}
+
+// NewScalar returns a new scalar set to 0.
+func (g *G[S, E]) NewScalar() S {
+	return g.id.get().NewScalar().(S) //nolint:forcetypeassert //the group determines the type
+}
+
+// NewElement returns a new element set to the identity element.
+func (g *G[S, E]) NewElement() E {
+	return g.id.get().NewElement().(E) //nolint:forcetypeassert //the group determines the type
+}
+
+// RandomScalar returns a new random, non-zero, scalar.
+func (g *G[S, E]) RandomScalar() S {
+	return g.id.get().RandomScalar().(S) //nolint:forcetypeassert //the group determines the type
+}
+
+// Base returns a new element set to the group's base point.
+func (g *G[S, E]) Base() E {
+	return g.id.get().Base().(E) //nolint:forcetypeassert //the group determines the type
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to a scalar.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *G[S, E]) HashToScalar(input, dst []byte) S {
+	checkDST(dst)
+	return g.id.get().HashToScalar(input, dst).(S) //nolint:forcetypeassert //the group determines the type
+}
+
+// HashToGroup returns a safe mapping of the arbitrary input to an element.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *G[S, E]) HashToGroup(input, dst []byte) E {
+	checkDST(dst)
+	return g.id.get().HashToGroup(input, dst).(E) //nolint:forcetypeassert //the group determines the type
+}
+
+// Scalar returns a Scalar wrapping s. Both share the same value.
+func (g *G[S, E]) Scalar(s S) *Scalar {
+	return newScalar(g.id, s)
+}
+
+// Element returns an Element wrapping e. Both share the same value.
+func (g *G[S, E]) Element(e E) *Element {
+	return newPoint(g.id, e)
+}
+
+// TypedScalar returns the typed value wrapped by the Scalar, which it shares, and panics if the scalar does not
+// belong to the group.
+func (g *G[S, E]) TypedScalar(s *Scalar) S {
+	ts, ok := s.Scalar.(S)
+	if !ok || s.g != g.id {
+		panic(internal.ErrCastScalar)
+	}
+
+	return ts
+}
+
+// TypedElement returns the typed value wrapped by the Element, which it shares, and panics if the element does not
+// belong to the group.
+func (g *G[S, E]) TypedElement(e *Element) E {
+	te, ok := e.Element.(E)
+	if !ok || e.g != g.id {
+		panic(internal.ErrCastElement)
+	}
+
+	return te
+}
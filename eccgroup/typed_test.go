@@ -0,0 +1,150 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eccgroup
+
+import (
+	"testing"
+
+	"github.com/cymony/cryptomony/internal/test"
+)
+
+func testTyped[S TypedScalar[S], E TypedElement[S, E]](t *testing.T, g *G[S, E]) {
+	t.Helper()
+
+	group := g.Group()
+	a, b := group.RandomScalar(), group.RandomScalar()
+	p, q := group.RandomElement(), group.RandomElement()
+	ta, tb := g.TypedScalar(a), g.TypedScalar(b)
+	tp, tq := g.TypedElement(p), g.TypedElement(q)
+
+	checkScalar := func(got S, want *Scalar, op string) {
+		if g.Scalar(got).Equal(want) != 1 {
+			test.Report(t, got, want, op)
+		}
+	}
+
+	checkElement := func(got E, want *Element, op string) {
+		if g.Element(got).Equal(want) != 1 {
+			test.Report(t, got, want, op)
+		}
+	}
+
+	checkScalar(ta.AddInto(g.NewScalar(), tb), a.Copy().Add(b), "AddInto")
+	checkScalar(ta.SubtractInto(g.NewScalar(), tb), a.Copy().Subtract(b), "SubtractInto")
+	checkScalar(ta.MultiplyInto(g.NewScalar(), tb), a.Copy().Multiply(b), "MultiplyInto")
+	checkScalar(ta.InvertInto(g.NewScalar()), a.Copy().Invert(), "InvertInto")
+	checkScalar(ta.CopyInto(g.NewScalar()), a, "CopyInto")
+
+	checkElement(tp.AddInto(g.NewElement(), tq), p.Copy().Add(q), "AddInto")
+	checkElement(tp.SubtractInto(g.NewElement(), tq), p.Copy().Subtract(q), "SubtractInto")
+	checkElement(tp.DoubleInto(g.NewElement()), p.Copy().Double(), "DoubleInto")
+	checkElement(tp.NegateInto(g.NewElement()), p.Copy().Negate(), "NegateInto")
+	checkElement(tp.MultiplyInto(g.NewElement(), ta), p.Copy().Multiply(a), "MultiplyInto")
+	checkElement(g.Base().MultiplyInto(g.NewElement(), ta), group.Base().Multiply(a), "MultiplyInto with the base")
+	checkElement(tp.CopyInto(g.NewElement()), p, "CopyInto")
+
+	// the destination may alias the receiver and the arguments
+	sum := a.Copy().Add(b)
+	checkScalar(ta.AddInto(ta, tb), sum, "AddInto in place")
+
+	if a.Equal(sum) != 1 {
+		test.Report(t, a, sum, "shared scalar")
+	}
+
+	product := q.Copy().Multiply(b)
+	checkElement(tq.MultiplyInto(tq, tb), product, "MultiplyInto in place")
+
+	if q.Equal(product) != 1 {
+		test.Report(t, q, product, "shared element")
+	}
+
+	// hashing gives the same results as the group
+	input, dst := []byte("input"), []byte("typed-group-test-dst")
+	checkScalar(g.HashToScalar(input, dst), group.HashToScalar(input, dst), "HashToScalar")
+	checkElement(g.HashToGroup(input, dst), group.HashToGroup(input, dst), "HashToGroup")
+
+	if err := test.CheckPanic(func() { g.TypedScalar(Decaf448Shake256.RandomScalar()) }); err != nil {
+		t.Fatal("expected panic on a scalar of another group")
+	}
+
+	if err := test.CheckPanic(func() { g.TypedElement(Decaf448Shake256.RandomElement()) }); err != nil {
+		t.Fatal("expected panic on an element of another group")
+	}
+}
+
+func TestTyped(t *testing.T) {
+	t.Run(Ristretto255Sha512.String(), func(t *testing.T) { testTyped(t, Ristretto255()) })
+	t.Run(P256Sha256.String(), func(t *testing.T) { testTyped(t, P256()) })
+	t.Run(P384Sha384.String(), func(t *testing.T) { testTyped(t, P384()) })
+	t.Run(P521Sha512.String(), func(t *testing.T) { testTyped(t, P521()) })
+	t.Run(Secp256k1Sha256.String(), func(t *testing.T) { testTyped(t, Secp256k1()) })
+
+	// P256 and P384 share the scalar type, but not the scalar field
+	err := test.CheckPanic(func() { P256().TypedScalar(P384Sha384.RandomScalar()) })
+	test.CheckNoErr(t, err, "panic expected")
+
+	// nor do the elements multiply with scalars of other curves, even of the same length
+	p256 := P256()
+	for _, e := range []*P256Element{p256.TypedElement(P256Sha256.Base()), p256.TypedElement(P256Sha256.RandomElement())} {
+		for _, s := range []*NISTScalar{
+			P384().TypedScalar(P384Sha384.RandomScalar()),
+			Secp256k1().TypedScalar(Secp256k1Sha256.RandomScalar()),
+		} {
+			err = test.CheckPanic(func() { e.MultiplyInto(p256.TypedElement(P256Sha256.NewElement()), s) })
+			test.CheckNoErr(t, err, "panic expected")
+		}
+	}
+}
+
+func benchTyped[S TypedScalar[S], E TypedElement[S, E]](b *testing.B, g *G[S, E]) {
+	b.Helper()
+
+	group := g.Group()
+	s, e := group.RandomScalar(), group.RandomElement()
+	ts, te := g.TypedScalar(s), g.TypedElement(e)
+	dst := g.NewElement()
+
+	b.Run(group.String()+"/Multiply", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			group.NewElement().Set(e).Multiply(s)
+		}
+	})
+
+	b.Run(group.String()+"/MultiplyInto", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			te.MultiplyInto(dst, ts)
+		}
+	})
+
+	b.Run(group.String()+"/ScalarMultiply", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			s.Copy().Multiply(s)
+		}
+	})
+
+	b.Run(group.String()+"/ScalarMultiplyInto", func(b *testing.B) {
+		b.ReportAllocs()
+
+		acc := g.NewScalar()
+
+		for i := 0; i < b.N; i++ {
+			ts.MultiplyInto(acc, ts)
+		}
+	})
+}
+
+func BenchmarkTyped(b *testing.B) {
+	benchTyped(b, Ristretto255())
+	benchTyped(b, P256())
+	benchTyped(b, P384())
+	benchTyped(b, P521())
+	benchTyped(b, Secp256k1())
+}
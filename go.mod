@@ -5,7 +5,8 @@ go 1.19
 require (
 	filippo.io/edwards25519 v1.0.0
 	filippo.io/nistec v0.0.0-20220825075812-a82cab4ea6f0
-	golang.org/x/crypto v0.0.0-20220829220503-c86fa9a7ed90
+	github.com/cloudflare/circl v1.3.7
+	golang.org/x/crypto v0.17.0
 )
 
-require golang.org/x/sys v0.2.0 // indirect
+require golang.org/x/sys v0.15.0 // indirect
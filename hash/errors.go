@@ -9,4 +9,6 @@ import "errors"
 var (
 	// ErrMismatchLengthWrite returns when hash's write operation writes wrong length of data
 	ErrMismatchLengthWrite = errors.New("hash: mismatch requested data and written data lengths")
+	// ErrXOF returns when HMAC or HKDF is requested over an extendable-output function, over which they are not defined
+	ErrXOF = errors.New("hash: HMAC and HKDF are not defined over extendable-output functions")
 )
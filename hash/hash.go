@@ -20,8 +20,9 @@ import (
 	_ "golang.org/x/crypto/blake2s"
 
 	"golang.org/x/crypto/hkdf"
-	// it prevent panic
-	_ "golang.org/x/crypto/sha3"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/cymony/cryptomony/internal/blake3"
 )
 
 // Hash interface wraps standart library's hash.Hash interface with additional functions to make usage easier.
@@ -56,15 +57,17 @@ type Hash interface {
 	// String returns string representation of the hash algorithm.
 	String() string
 
-	// Hmac wraps the built-in hmac.
+	// Hmac wraps the built-in hmac. It returns ErrXOF for extendable-output functions, such as SHAKE256.
 	Hmac(message, key []byte) ([]byte, error)
 
 	// HKDFExtract is an "extract" only HKDF, where the secret and salt are used to generate a pseudorandom key. This key
 	// can then be used in multiple HKDFExpand calls to derive individual different keys.
+	// It panics with ErrXOF for extendable-output functions, such as SHAKE256.
 	HKDFExtract(secret, salt []byte) []byte
 
 	// HKDFExpand is an "expand" only HKDF, where the key should be an already random/hashed input,
 	// and info specific key usage identifying information.
+	// It panics with ErrXOF for extendable-output functions, such as SHAKE256.
 	HKDFExpand(pseudorandomKey, info []byte, length int) []byte
 }
 
@@ -88,20 +91,81 @@ const (
 	BLAKE2b_512 = Hashing(crypto.BLAKE2b_512) //nolint:revive,stylecheck // because of compatibility with crypto library
 )
 
+// SHAKE256 is the SHAKE256 extendable-output function used as a hash function with 64 bytes output, as the
+// decaf448 OPRF ciphersuite hashes its outputs. It has no counterpart in the crypto library, and is only a hash
+// function: HMAC and HKDF, which are not defined over extendable-output functions, return or panic with ErrXOF.
+const SHAKE256 = Hashing(256)
+
+// BLAKE3 is the BLAKE3 hash function with 32 bytes output. It has no counterpart in the crypto library.
+const BLAKE3 = Hashing(257)
+
+const (
+	strSHAKE256 = "SHAKE-256"
+	strBLAKE3   = "BLAKE3"
+)
+
 // New returns a new hash.Hash calculating the given hash function. New panics
 // if the hash function is not linked into the binary.
 func (i Hashing) New() Hash {
-	return &hashWrap{i.CryptoID().New(), i.CryptoID()}
+	switch i {
+	case SHAKE256:
+		return &hashWrap{sha3.NewShake256(), newShake256, strSHAKE256, true}
+	case BLAKE3:
+		return &hashWrap{blake3.New(), newBlake3, strBLAKE3, false}
+	default:
+		return &hashWrap{i.CryptoID().New(), i.CryptoID().New, i.CryptoID().String(), false}
+	}
+}
+
+// Available reports whether the given hash function is linked into the binary.
+func (i Hashing) Available() bool {
+	if i == SHAKE256 || i == BLAKE3 {
+		return true
+	}
+
+	return i.CryptoID().Available()
+}
+
+// IsXOF reports whether the hash function is an extendable-output function, such as SHAKE256, over which HMAC and
+// HKDF are not defined.
+func (i Hashing) IsXOF() bool {
+	return i == SHAKE256
 }
 
 // CryptoID returns the built-in crypto identifier corresponding the Hashing identifier.
+// It must not be used with hash functions that have no counterpart in the crypto library, such as SHAKE256 and
+// BLAKE3.
 func (i Hashing) CryptoID() crypto.Hash {
 	return crypto.Hash(i)
 }
 
+// Size returns the length, in bytes, of a digest resulting from the given hash function.
+func (i Hashing) Size() int {
+	switch i {
+	case SHAKE256:
+		return shake256Size
+	case BLAKE3:
+		return blake3.Size
+	default:
+		return i.CryptoID().Size()
+	}
+}
+
+const shake256Size = 64
+
+func newShake256() stdHash.Hash {
+	return sha3.NewShake256()
+}
+
+func newBlake3() stdHash.Hash {
+	return blake3.New()
+}
+
 type hashWrap struct {
 	stdHash.Hash
-	cryptoID crypto.Hash
+	newFn func() stdHash.Hash
+	name  string
+	xof   bool
 }
 
 // OutputSize returns the number of bytes Sum will return.
@@ -133,12 +197,16 @@ func (hw *hashWrap) MustReadFull(buff []byte) error {
 
 // String returns string representation of the hash algorithm.
 func (hw *hashWrap) String() string {
-	return hw.cryptoID.String()
+	return hw.name
 }
 
 // Hmac wraps the built-in hmac.
 func (hw *hashWrap) Hmac(message, key []byte) ([]byte, error) {
-	hm := hmac.New(hw.cryptoID.New, key)
+	if hw.xof {
+		return nil, ErrXOF
+	}
+
+	hm := hmac.New(hw.newFn, key)
 	n, err := hm.Write(message)
 
 	if err != nil {
@@ -153,17 +221,25 @@ func (hw *hashWrap) Hmac(message, key []byte) ([]byte, error) {
 // HKDFExtract is an "extract" only HKDF, where the secret and salt are used to generate a pseudorandom key. This key
 // can then be used in multiple HKDFExpand calls to derive individual different keys.
 func (hw *hashWrap) HKDFExtract(secret, salt []byte) []byte {
-	return hkdf.Extract(hw.cryptoID.New, secret, salt)
+	if hw.xof {
+		panic(ErrXOF)
+	}
+
+	return hkdf.Extract(hw.newFn, secret, salt)
 }
 
 // HKDFExpand is an "expand" only HKDF, where the key should be an already random/hashed input,
 // and info specific key usage identifying information.
 func (hw *hashWrap) HKDFExpand(pseudorandomKey, info []byte, length int) []byte {
+	if hw.xof {
+		panic(ErrXOF)
+	}
+
 	if length == 0 {
 		length = hw.OutputSize()
 	}
 
-	kdf := hkdf.Expand(hw.cryptoID.New, pseudorandomKey, info)
+	kdf := hkdf.Expand(hw.newFn, pseudorandomKey, info)
 	dst := make([]byte, length)
 
 	_, err := kdf.Read(dst)
@@ -7,6 +7,7 @@ package hash_test
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"testing"
 
 	"github.com/cymony/cryptomony/hash"
@@ -151,6 +152,8 @@ var hashVectors = []vector{
 func TestHashing(t *testing.T) {
 	for _, v := range hashVectors {
 		t.Run(v.id.New().String(), func(t *testing.T) {
+			test.CheckOk(t, v.id.Available(), "hash function should be available")
+
 			h := v.id.New()
 			if h.BlockSize() != v.id.CryptoID().New().BlockSize() {
 				test.Report(t, h.BlockSize(), v.id.CryptoID().New().BlockSize(), h.String())
@@ -256,3 +259,84 @@ func TestHmac(t *testing.T) {
 		})
 	}
 }
+
+func TestSHAKE256(t *testing.T) {
+	const want = "46b9dd2b0ba88d13233b3feb743eeb243fcd52ea62b81b82b50c27646ed5762fd75dc4ddd8c0f200cb05019d67b592f6fc821c49479ab48640292eacb3b7c4be"
+
+	test.CheckOk(t, hash.SHAKE256.Available(), "SHAKE256 should be available")
+	test.CheckOk(t, !hash.Hashing(0).Available(), "zero hash identifier should not be available")
+
+	h := hash.SHAKE256.New()
+	if h.String() != "SHAKE-256" {
+		test.Report(t, h.String(), "SHAKE-256")
+	}
+
+	if h.OutputSize() != 64 || hash.SHAKE256.Size() != 64 {
+		test.Report(t, h.OutputSize(), 64)
+	}
+
+	if h.BlockSize() != 136 {
+		test.Report(t, h.BlockSize(), 136)
+	}
+
+	out := make([]byte, h.OutputSize())
+	err := h.MustReadFull(out)
+	test.CheckNoErr(t, err, "err not expected read full")
+
+	if got := hex.EncodeToString(out); got != want {
+		test.Report(t, got, want)
+	}
+
+	// HMAC and HKDF are not defined over XOFs
+	test.CheckOk(t, hash.SHAKE256.IsXOF() && !hash.SHA512.IsXOF(), "only SHAKE256 is an XOF")
+
+	_, err = h.Hmac([]byte("message"), []byte("key"))
+	test.CheckOk(t, errors.Is(err, hash.ErrXOF), "hmac over an XOF should be rejected")
+
+	err = test.CheckPanic(func() { h.HKDFExtract([]byte("secret"), nil) })
+	test.CheckNoErr(t, err, "hkdf extract over an XOF should panic")
+
+	err = test.CheckPanic(func() { h.HKDFExpand(make([]byte, 64), []byte("info"), 64) })
+	test.CheckNoErr(t, err, "hkdf expand over an XOF should panic")
+}
+
+func TestBLAKE3(t *testing.T) {
+	const want = "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262"
+
+	test.CheckOk(t, hash.BLAKE3.Available(), "BLAKE3 should be available")
+
+	h := hash.BLAKE3.New()
+	if h.String() != "BLAKE3" {
+		test.Report(t, h.String(), "BLAKE3")
+	}
+
+	if h.OutputSize() != 32 || hash.BLAKE3.Size() != 32 || h.BlockSize() != 64 {
+		test.Report(t, h.OutputSize(), 32)
+	}
+
+	out := make([]byte, h.OutputSize())
+	err := h.MustReadFull(out)
+	test.CheckNoErr(t, err, "err not expected read full")
+
+	if hex.EncodeToString(out) != want {
+		test.Report(t, hex.EncodeToString(out), want)
+	}
+
+	// HMAC and HKDF work through the Hash interface
+	key, msg := []byte("key"), []byte("message")
+
+	mac, err := h.Hmac(msg, key)
+	test.CheckNoErr(t, err, "hmac err not expected")
+	test.CheckOk(t, len(mac) == 32, "wrong HMAC length")
+
+	otherMac, err := h.Hmac(msg, []byte("other key"))
+	test.CheckNoErr(t, err, "hmac err not expected")
+	test.CheckOk(t, !bytes.Equal(mac, otherMac), "HMAC should depend on the key")
+
+	prk := h.HKDFExtract([]byte("secret"), []byte("salt"))
+	test.CheckOk(t, len(prk) == 32, "wrong extracted key length")
+
+	okm := h.HKDFExpand(prk, []byte("info"), 100)
+	test.CheckOk(t, len(okm) == 100, "wrong expanded key length")
+	test.CheckOk(t, bytes.Equal(okm[:32], h.HKDFExpand(prk, []byte("info"), 0)), "expansion should be a prefix stream")
+}
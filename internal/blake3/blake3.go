@@ -0,0 +1,343 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package blake3 implements the BLAKE3 hash function, in its hash, keyed hash and key derivation modes,
+// with extendable output. It follows the reference implementation of the specification.
+// See https://github.com/BLAKE3-team/BLAKE3-specs/blob/master/blake3.pdf
+package blake3
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+const (
+	// Size is the default output size of BLAKE3 in bytes.
+	Size = 32
+	// BlockSize is the block size of BLAKE3 in bytes.
+	BlockSize = 64
+	// KeySize is the key size of the keyed hash mode in bytes.
+	KeySize = 32
+
+	chunkLen = 1024
+	maxDepth = 54 // 2^54 chunks of 1 KiB is the maximum input length of 2^64 bytes
+)
+
+const (
+	flagChunkStart = 1 << iota
+	flagChunkEnd
+	flagParent
+	flagRoot
+	flagKeyedHash
+	flagDeriveKeyContext
+	flagDeriveKeyMaterial
+)
+
+// ErrKeySize is returned when the key of the keyed hash mode is not KeySize bytes long.
+var ErrKeySize = errors.New("blake3: the key must be 32 bytes long")
+
+var iv = [8]uint32{0x6A09E667, 0xBB67AE85, 0x3C6EF372, 0xA54FF53A, 0x510E527F, 0x9B05688C, 0x1F83D9AB, 0x5BE0CD19}
+
+var msgPermutation = [16]int{2, 6, 3, 10, 7, 0, 4, 13, 1, 11, 12, 5, 9, 14, 15, 8}
+
+func g(state *[16]uint32, a, b, c, d int, mx, my uint32) {
+	state[a] += state[b] + mx
+	state[d] = bits.RotateLeft32(state[d]^state[a], -16)
+	state[c] += state[d]
+	state[b] = bits.RotateLeft32(state[b]^state[c], -12)
+	state[a] += state[b] + my
+	state[d] = bits.RotateLeft32(state[d]^state[a], -8)
+	state[c] += state[d]
+	state[b] = bits.RotateLeft32(state[b]^state[c], -7)
+}
+
+func round(state, m *[16]uint32) {
+	// columns
+	g(state, 0, 4, 8, 12, m[0], m[1])
+	g(state, 1, 5, 9, 13, m[2], m[3])
+	g(state, 2, 6, 10, 14, m[4], m[5])
+	g(state, 3, 7, 11, 15, m[6], m[7])
+	// diagonals
+	g(state, 0, 5, 10, 15, m[8], m[9])
+	g(state, 1, 6, 11, 12, m[10], m[11])
+	g(state, 2, 7, 8, 13, m[12], m[13])
+	g(state, 3, 4, 9, 14, m[14], m[15])
+}
+
+func permute(m *[16]uint32) {
+	var p [16]uint32
+	for i, j := range msgPermutation {
+		p[i] = m[j]
+	}
+
+	*m = p
+}
+
+// compress returns the 16 words of the compression of the block, whose first 8 are the chaining value.
+func compress(cv *[8]uint32, block *[16]uint32, counter uint64, blockLen, flags uint32) [16]uint32 {
+	state := [16]uint32{
+		cv[0], cv[1], cv[2], cv[3], cv[4], cv[5], cv[6], cv[7],
+		iv[0], iv[1], iv[2], iv[3],
+		uint32(counter), uint32(counter >> 32), blockLen, flags,
+	}
+	m := *block
+
+	for r := 0; r < 7; r++ {
+		round(&state, &m)
+
+		if r < 6 {
+			permute(&m)
+		}
+	}
+
+	for i := 0; i < 8; i++ {
+		state[i] ^= state[i+8]
+		state[i+8] ^= cv[i]
+	}
+
+	return state
+}
+
+func wordsFromBytes(b []byte, words *[16]uint32) {
+	var block [BlockSize]byte
+	copy(block[:], b)
+
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint32(block[4*i:])
+	}
+}
+
+func firstEight(words [16]uint32) [8]uint32 {
+	var cv [8]uint32
+	copy(cv[:], words[:8])
+
+	return cv
+}
+
+// output is the state of a node before its compression, from which its chaining value or the root output
+// are computed.
+type output struct {
+	cv       [8]uint32
+	block    [16]uint32
+	counter  uint64
+	blockLen uint32
+	flags    uint32
+}
+
+func (o *output) chainingValue() [8]uint32 {
+	return firstEight(compress(&o.cv, &o.block, o.counter, o.blockLen, o.flags))
+}
+
+type chunkState struct {
+	cv               [8]uint32
+	counter          uint64
+	block            [BlockSize]byte
+	blockLen         int
+	blocksCompressed int
+	flags            uint32
+}
+
+func newChunkState(key *[8]uint32, counter uint64, flags uint32) chunkState {
+	return chunkState{cv: *key, counter: counter, flags: flags}
+}
+
+func (c *chunkState) len() int {
+	return BlockSize*c.blocksCompressed + c.blockLen
+}
+
+func (c *chunkState) startFlag() uint32 {
+	if c.blocksCompressed == 0 {
+		return flagChunkStart
+	}
+
+	return 0
+}
+
+func (c *chunkState) update(p []byte) {
+	for len(p) > 0 {
+		// a full block buffer is only compressed when more input follows, as the last block is the chunk end
+		if c.blockLen == BlockSize {
+			var words [16]uint32
+			wordsFromBytes(c.block[:], &words)
+			c.cv = firstEight(compress(&c.cv, &words, c.counter, BlockSize, c.flags|c.startFlag()))
+			c.blocksCompressed++
+			c.block = [BlockSize]byte{}
+			c.blockLen = 0
+		}
+
+		n := copy(c.block[c.blockLen:], p)
+		c.blockLen += n
+		p = p[n:]
+	}
+}
+
+func (c *chunkState) output() output {
+	o := output{cv: c.cv, counter: c.counter, blockLen: uint32(c.blockLen), flags: c.flags | c.startFlag() | flagChunkEnd}
+	wordsFromBytes(c.block[:c.blockLen], &o.block)
+
+	return o
+}
+
+func parentOutput(left, right *[8]uint32, key *[8]uint32, flags uint32) output {
+	o := output{cv: *key, blockLen: BlockSize, flags: flags | flagParent}
+	copy(o.block[:8], left[:])
+	copy(o.block[8:], right[:])
+
+	return o
+}
+
+// Hasher is an incremental BLAKE3 hasher. It implements hash.Hash, with a Size bytes output, and its output
+// can be extended with XOF.
+type Hasher struct {
+	key      [8]uint32
+	chunk    chunkState
+	stack    [maxDepth][8]uint32
+	stackLen int
+	flags    uint32
+}
+
+func newHasher(key *[8]uint32, flags uint32) *Hasher {
+	return &Hasher{key: *key, chunk: newChunkState(key, 0, flags), flags: flags}
+}
+
+// New returns a new Hasher in the default hash mode.
+func New() *Hasher {
+	return newHasher(&iv, 0)
+}
+
+// NewKeyed returns a new Hasher in the keyed hash mode, and an error if the key is not KeySize bytes long.
+func NewKeyed(key []byte) (*Hasher, error) {
+	if len(key) != KeySize {
+		return nil, ErrKeySize
+	}
+
+	var words [16]uint32
+	wordsFromBytes(key, &words)
+	k := firstEight(words)
+
+	return newHasher(&k, flagKeyedHash), nil
+}
+
+// NewDeriveKey returns a new Hasher in the key derivation mode with the context string, to which the key material
+// is written. The context should be hardcoded, globally unique, and application-specific.
+func NewDeriveKey(context string) *Hasher {
+	ctx := newHasher(&iv, flagDeriveKeyContext)
+	_, _ = ctx.Write([]byte(context))
+
+	var words [16]uint32
+	wordsFromBytes(ctx.Sum(nil), &words)
+	k := firstEight(words)
+
+	return newHasher(&k, flagDeriveKeyMaterial)
+}
+
+// addChunkCV merges the chaining value of a new chunk with the completed subtrees, given the total number of chunks.
+func (h *Hasher) addChunkCV(cv [8]uint32, totalChunks uint64) {
+	for totalChunks&1 == 0 {
+		h.stackLen--
+		o := parentOutput(&h.stack[h.stackLen], &cv, &h.key, h.flags)
+		cv = o.chainingValue()
+		totalChunks >>= 1
+	}
+
+	h.stack[h.stackLen] = cv
+	h.stackLen++
+}
+
+// Write adds more data to the running hash. It never returns an error.
+func (h *Hasher) Write(p []byte) (int, error) {
+	written := len(p)
+
+	for len(p) > 0 {
+		// a full chunk is only finalized when more input follows, as the last chunk may be the root
+		if h.chunk.len() == chunkLen {
+			o := h.chunk.output()
+			total := h.chunk.counter + 1
+			h.addChunkCV(o.chainingValue(), total)
+			h.chunk = newChunkState(&h.key, total, h.flags)
+		}
+
+		n := chunkLen - h.chunk.len()
+		if n > len(p) {
+			n = len(p)
+		}
+
+		h.chunk.update(p[:n])
+		p = p[n:]
+	}
+
+	return written, nil
+}
+
+// rootOutput returns the output of the root node, without changing the state.
+func (h *Hasher) rootOutput() output {
+	o := h.chunk.output()
+
+	for i := h.stackLen - 1; i >= 0; i-- {
+		cv := o.chainingValue()
+		o = parentOutput(&h.stack[i], &cv, &h.key, h.flags)
+	}
+
+	return o
+}
+
+// Sum appends the Size bytes hash to b and returns the resulting slice. It does not change the state.
+func (h *Hasher) Sum(b []byte) []byte {
+	out := make([]byte, Size)
+	_, _ = h.XOF().Read(out)
+
+	return append(b, out...)
+}
+
+// Reset resets the Hasher to its initial state, in the same mode.
+func (h *Hasher) Reset() {
+	h.chunk = newChunkState(&h.key, 0, h.flags)
+	h.stackLen = 0
+}
+
+// Size returns the default output size in bytes.
+func (h *Hasher) Size() int { return Size }
+
+// BlockSize returns the block size in bytes.
+func (h *Hasher) BlockSize() int { return BlockSize }
+
+// Clone returns a copy of the Hasher in its current state.
+func (h *Hasher) Clone() *Hasher {
+	c := *h
+	return &c
+}
+
+// XOF returns a reader of the extendable output of the current state, which it does not change.
+func (h *Hasher) XOF() *OutputReader {
+	return &OutputReader{root: h.rootOutput()}
+}
+
+// OutputReader reads the extendable output of a Hasher.
+type OutputReader struct {
+	root  output
+	block [BlockSize]byte // output of the current root compression
+	off   uint64          // offset of the next output byte
+}
+
+// Read reads more output. It never returns an error.
+func (r *OutputReader) Read(p []byte) (int, error) {
+	read := len(p)
+
+	for len(p) > 0 {
+		pos := r.off % BlockSize
+		if pos == 0 {
+			words := compress(&r.root.cv, &r.root.block, r.off/BlockSize, r.root.blockLen, r.root.flags|flagRoot)
+			for i, w := range words {
+				binary.LittleEndian.PutUint32(r.block[4*i:], w)
+			}
+		}
+
+		n := copy(p, r.block[pos:])
+		r.off += uint64(n)
+		p = p[n:]
+	}
+
+	return read, nil
+}
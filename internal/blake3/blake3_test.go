@@ -0,0 +1,115 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blake3
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cymony/cryptomony/internal/test"
+)
+
+const (
+	vectorKey     = "whats the Elvish word for friend"
+	vectorContext = "BLAKE3 2019-12-27 16:29:52 test vectors context"
+)
+
+// Vectors of https://github.com/BLAKE3-team/BLAKE3/blob/master/test_vectors/test_vectors.json, whose input is the
+// repeating pattern 00 01 .. FA.
+var vectors = []struct {
+	inputLen int
+	hash     string
+}{
+	{0, "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262"},
+	{1, "2d3adedff11b61f14c886e35afa036736dcd87a74d27b5c1510225d0f592e213"},
+	{2, "7b7015bb92cf0b318037702a6cdd81dee41224f734684c2c122cd6359cb1ee63"},
+	{3, "e1be4d7a8ab5560aa4199eea339849ba8e293d55ca0a81006726d184519e647f"},
+	{8, "2351207d04fc16ade43ccab08600939c7c1fa70a5c0aaca76063d04c3228eaeb"},
+	{63, "e9bc37a594daad83be9470df7f7b3798297c3d834ce80ba85d6e207627b7db7b"},
+	{64, "4eed7141ea4a5cd4b788606bd23f46e212af9cacebacdc7d1f4c6dc7f2511b98"},
+	{65, "de1e5fa0be70df6d2be8fffd0e99ceaa8eb6e8c93a63f2d8d1c30ecb6b263dee"},
+	{127, "d81293fda863f008c09e92fc382a81f5a0b4a1251cba1634016a0f86a6bd640d"},
+	{128, "f17e570564b26578c33bb7f44643f539624b05df1a76c81f30acd548c44b45ef"},
+	{129, "683aaae9f3c5ba37eaaf072aed0f9e30bac0865137bae68b1fde4ca2aebdcb12"},
+	{1023, "10108970eeda3eb932baac1428c7a2163b0e924c9a9e25b35bba72b28f70bd11"},
+	{1024, "42214739f095a406f3fc83deb889744ac00df831c10daa55189b5d121c855af7"},
+	{1025, "d00278ae47eb27b34faecf67b4fe263f82d5412916c1ffd97c8cb7fb814b8444"},
+	{2048, "e776b6028c7cd22a4d0ba182a8bf62205d2ef576467e838ed6f2529b85fba24a"},
+}
+
+func pattern(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i % 251)
+	}
+
+	return b
+}
+
+func TestVectors(t *testing.T) {
+	for _, v := range vectors {
+		want, err := hex.DecodeString(v.hash)
+		test.CheckNoErr(t, err, "decode string err")
+
+		h := New()
+		_, _ = h.Write(pattern(v.inputLen))
+
+		if got := h.Sum(nil); !bytes.Equal(got, want) {
+			test.Report(t, got, want, v.inputLen)
+		}
+	}
+}
+
+func TestModes(t *testing.T) {
+	k, err := NewKeyed([]byte(vectorKey))
+	test.CheckNoErr(t, err, "keyed hasher creation failed")
+
+	want, _ := hex.DecodeString("92b2b75604ed3c761f9d6f62392c8a9227ad0ea3f09573e783f1498a4ed60d26")
+	if got := k.Sum(nil); !bytes.Equal(got, want) {
+		test.Report(t, got, want, "keyed hash")
+	}
+
+	want, _ = hex.DecodeString("2cc39783c223154fea8dfb7c1b1660f2ac2dcbd1c1de8277b0b0dd39b7e50d7d")
+	if got := NewDeriveKey(vectorContext).Sum(nil); !bytes.Equal(got, want) {
+		test.Report(t, got, want, "derive key")
+	}
+
+	_, err = NewKeyed(make([]byte, KeySize-1))
+	test.CheckIsErr(t, err, "short key should be rejected")
+}
+
+func TestXOF(t *testing.T) {
+	h := New()
+	_, _ = h.Write(pattern(3000))
+
+	// the extended output starts with the hash, and does not depend on how it is read
+	long := make([]byte, 300)
+	_, _ = h.XOF().Read(long)
+	test.CheckOk(t, bytes.Equal(long[:Size], h.Sum(nil)), "the output should extend the hash")
+
+	r := h.XOF()
+	split := make([]byte, len(long))
+
+	for i := 0; i < len(split); i += 7 {
+		end := i + 7
+		if end > len(split) {
+			end = len(split)
+		}
+
+		_, _ = r.Read(split[i:end])
+	}
+
+	test.CheckOk(t, bytes.Equal(split, long), "split reads should give the same output")
+
+	// writing in pieces and resetting give the same hash
+	h.Reset()
+
+	for _, p := range [][]byte{pattern(3000)[:1], pattern(3000)[1:1024], pattern(3000)[1024:]} {
+		_, _ = h.Write(p)
+	}
+
+	test.CheckOk(t, bytes.Equal(long[:Size], h.Sum(nil)), "incremental writes should give the same hash")
+}
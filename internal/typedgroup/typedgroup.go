@@ -0,0 +1,37 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package typedgroup runs the hot group operations of the protocols with the typed API of eccgroup, for the groups
+// that have one, and with the Scalar and Element wrappers otherwise.
+package typedgroup
+
+import "github.com/cymony/cryptomony/eccgroup"
+
+// MultiplyInto sets dst to the scalar multiplication of the element with the scalar, and returns dst. The destination
+// must be an element of the group of the operands, and may be the element itself. It does not allocate for the groups
+// with a typed API, so callers reuse the destination across operations.
+func MultiplyInto(dst *eccgroup.Element, s *eccgroup.Scalar, e *eccgroup.Element) *eccgroup.Element {
+	switch dst.Group() {
+	case eccgroup.Ristretto255Sha512:
+		multiplyInto(eccgroup.Ristretto255(), dst, s, e)
+	case eccgroup.P256Sha256:
+		multiplyInto(eccgroup.P256(), dst, s, e)
+	case eccgroup.P384Sha384:
+		multiplyInto(eccgroup.P384(), dst, s, e)
+	case eccgroup.P521Sha512:
+		multiplyInto(eccgroup.P521(), dst, s, e)
+	case eccgroup.Secp256k1Sha256:
+		multiplyInto(eccgroup.Secp256k1(), dst, s, e)
+	default:
+		dst.Set(e).Multiply(s)
+	}
+
+	return dst
+}
+
+func multiplyInto[S eccgroup.TypedScalar[S], E eccgroup.TypedElement[S, E]](g *eccgroup.G[S, E], dst *eccgroup.Element,
+	s *eccgroup.Scalar, e *eccgroup.Element,
+) {
+	g.TypedElement(e).MultiplyInto(g.TypedElement(dst), g.TypedScalar(s))
+}
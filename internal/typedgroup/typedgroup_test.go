@@ -0,0 +1,70 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typedgroup
+
+import (
+	"testing"
+
+	"github.com/cymony/cryptomony/eccgroup"
+	"github.com/cymony/cryptomony/internal/test"
+)
+
+// groups are the groups with a typed API, and a group without one.
+var groups = []eccgroup.Group{
+	eccgroup.Ristretto255Sha512,
+	eccgroup.P256Sha256,
+	eccgroup.P384Sha384,
+	eccgroup.P521Sha512,
+	eccgroup.Secp256k1Sha256,
+	eccgroup.Decaf448Shake256,
+}
+
+func TestMultiplyInto(t *testing.T) {
+	for _, g := range groups {
+		s, e := g.RandomScalar(), g.RandomElement()
+		want := g.NewElement().Set(e).Multiply(s)
+
+		dst := g.NewElement()
+		got := MultiplyInto(dst, s, e)
+		test.CheckOk(t, got == dst, g.String()+": dst should be returned")
+		test.CheckOk(t, got.Equal(want) == 1, g.String()+": wrong multiplication")
+
+		// the destination is reused, and may be the element
+		got = MultiplyInto(dst, s, g.Base())
+		test.CheckOk(t, got.Equal(g.Base().Multiply(s)) == 1, g.String()+": wrong multiplication of the generator")
+
+		got = MultiplyInto(e, s, e)
+		test.CheckOk(t, got == e && got.Equal(want) == 1, g.String()+": wrong multiplication in place")
+
+		allocs := testing.AllocsPerRun(10, func() { MultiplyInto(dst, s, e) })
+		test.CheckOk(t, allocs == 0, g.String()+": multiplication into a destination should not allocate")
+	}
+}
+
+// BenchmarkMultiplyInto compares the allocating wrapper operation, which the protocols used, with the typed operation
+// into a reused destination.
+func BenchmarkMultiplyInto(b *testing.B) {
+	for _, g := range groups {
+		s, e := g.RandomScalar(), g.RandomElement()
+
+		b.Run(g.String()+"/Wrapper", func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				g.NewElement().Set(e).Multiply(s)
+			}
+		})
+
+		b.Run(g.String()+"/MultiplyInto", func(b *testing.B) {
+			b.ReportAllocs()
+
+			dst := g.NewElement()
+
+			for i := 0; i < b.N; i++ {
+				MultiplyInto(dst, s, e)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package kdf provides key derivation functions built on the hash package: HKDF streams, the labeled HKDF of
+// TLS 1.3 and HPKE, and the key-based key derivation functions of NIST SP 800-108.
+package kdf
+
+import (
+	"errors"
+	"io"
+
+	"github.com/cymony/cryptomony/hash"
+	"github.com/cymony/cryptomony/utils"
+)
+
+var (
+	// ErrLengthTooHigh is returned when the requested output length is too high for the key derivation function.
+	ErrLengthTooHigh = errors.New("kdf: requested output length is too high")
+	// ErrLabelTooLong is returned when a label or a context is too long to be encoded.
+	ErrLabelTooLong = errors.New("kdf: label or context is too long")
+	// ErrInvalidHash is returned when the hash function is not available.
+	ErrInvalidHash = errors.New("kdf: unavailable hash function")
+)
+
+// maxHKDFBlocks is the maximum number of output blocks of HKDF-Expand.
+const maxHKDFBlocks = 255
+
+// NewHKDF returns a reader of the output of HKDF with the hash function, which extracts a pseudorandom key from the
+// secret and the salt, and expands it with the info. The output is bounded to 255 hash outputs, after which Read
+// returns io.EOF. Read returns hash.ErrXOF for extendable-output functions.
+func NewHKDF(h hash.Hashing, secret, salt, info []byte) io.Reader {
+	if h.IsXOF() {
+		return errReader{hash.ErrXOF}
+	}
+
+	return NewHKDFExpand(h, h.New().HKDFExtract(secret, salt), info)
+}
+
+// NewHKDFExpand returns a reader of the output of HKDF-Expand with the hash function, where the key should be an
+// already random/hashed input. The output is bounded to 255 hash outputs, after which Read returns io.EOF.
+// Read returns hash.ErrXOF for extendable-output functions.
+func NewHKDFExpand(h hash.Hashing, pseudorandomKey, info []byte) io.Reader {
+	return &hkdfReader{h: h.New(), prk: append([]byte(nil), pseudorandomKey...), info: append([]byte(nil), info...)}
+}
+
+// hkdfReader computes the blocks T(i) = HMAC-Hash(PRK, T(i-1) | info | i) of HKDF-Expand as they are read.
+type hkdfReader struct {
+	h    hash.Hash
+	prk  []byte
+	info []byte
+	t    []byte // T(counter)
+	off  int    // number of bytes of t read
+	n    int    // counter
+}
+
+func (r *hkdfReader) Read(p []byte) (int, error) {
+	read := 0
+
+	for len(p) > 0 {
+		if r.off == len(r.t) {
+			if r.n == maxHKDFBlocks {
+				if read == 0 {
+					return 0, io.EOF
+				}
+
+				break
+			}
+
+			r.n++
+
+			t, err := r.h.Hmac(utils.Concat(r.t, r.info, []byte{byte(r.n)}), r.prk)
+			if err != nil {
+				return read, err
+			}
+
+			r.t, r.off = t, 0
+		}
+
+		k := copy(p, r.t[r.off:])
+		r.off += k
+		read += k
+		p = p[k:]
+	}
+
+	return read, nil
+}
+
+// errReader is a reader whose reads fail with err.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
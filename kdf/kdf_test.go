@@ -0,0 +1,211 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kdf_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/cymony/cryptomony/hash"
+	"github.com/cymony/cryptomony/internal/test"
+	"github.com/cymony/cryptomony/kdf"
+)
+
+func hexDecode(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	test.CheckNoErr(t, err, "decode string err")
+
+	return b
+}
+
+func checkEqual(t *testing.T, got, want []byte, name string) {
+	t.Helper()
+
+	if !bytes.Equal(got, want) {
+		test.Report(t, got, want, name)
+	}
+}
+
+// Test case 1 of https://www.rfc-editor.org/rfc/rfc5869#appendix-A.1
+func TestHKDF(t *testing.T) {
+	ikm := bytes.Repeat([]byte{0x0b}, 22)
+	salt := hexDecode(t, "000102030405060708090a0b0c")
+	info := hexDecode(t, "f0f1f2f3f4f5f6f7f8f9")
+	prk := hexDecode(t, "077709362c2e32df0ddc3f0dc47bba6390b6c73bb50f9c3122ec844ad7c2b3e5")
+	okm := hexDecode(t, "3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865")
+
+	got := make([]byte, len(okm))
+	_, err := io.ReadFull(kdf.NewHKDF(hash.SHA256, ikm, salt, info), got)
+	test.CheckNoErr(t, err, "read err")
+	checkEqual(t, got, okm, "HKDF")
+
+	// the stream does not depend on how it is read
+	r := kdf.NewHKDFExpand(hash.SHA256, prk, info)
+	for i := 0; i < len(got); i += 5 {
+		end := i + 5
+		if end > len(got) {
+			end = len(got)
+		}
+
+		_, err = io.ReadFull(r, got[i:end])
+		test.CheckNoErr(t, err, "read err")
+	}
+
+	checkEqual(t, got, okm, "HKDF-Expand")
+
+	// the stream is bounded to 255 blocks
+	all, err := io.ReadAll(kdf.NewHKDFExpand(hash.SHA256, prk, info))
+	test.CheckNoErr(t, err, "read err")
+	test.CheckOk(t, len(all) == 255*32, "wrong HKDF stream length")
+	checkEqual(t, all[:len(okm)], okm, "HKDF stream")
+
+	// HKDF is not defined over XOFs
+	_, err = kdf.NewHKDF(hash.SHAKE256, ikm, salt, info).Read(got)
+	test.CheckOk(t, errors.Is(err, hash.ErrXOF), "HKDF over an XOF should be rejected")
+	_, err = kdf.NewHKDFExpand(hash.SHAKE256, prk, info).Read(got)
+	test.CheckOk(t, errors.Is(err, hash.ErrXOF), "HKDF-Expand over an XOF should be rejected")
+}
+
+// The derived secret of the TLS 1.3 key schedule without PSK, see https://www.rfc-editor.org/rfc/rfc8448#section-3
+func TestLabeled(t *testing.T) {
+	tls := &kdf.Labeled{Hash: hash.SHA256, Prefix: kdf.TLS13Prefix}
+
+	early := hash.SHA256.New().HKDFExtract(make([]byte, 32), nil)
+	checkEqual(t, early, hexDecode(t, "33ad0a1c607ec03b09e6cd9893680ce210adf300aa1f2660e1b22e10f170f92a"), "early secret")
+
+	emptyHash := sha256.Sum256(nil)
+	derived, err := tls.DeriveSecret(early, []byte("derived"), emptyHash[:])
+	test.CheckNoErr(t, err, "derive secret err")
+	checkEqual(t, derived, hexDecode(t, "6f2615a108c702c5678f54fc9dbab69716c076189c48250cebeac3576c3611ba"), "derived secret")
+
+	label, err := kdf.HKDFLabel("OPAQUE-", []byte("ServerMAC"), nil, 64)
+	test.CheckNoErr(t, err, "label err")
+	checkEqual(t, label, append(append([]byte{0, 64, 16}, "OPAQUE-ServerMAC"...), 0), "HkdfLabel")
+
+	_, err = kdf.HKDFLabel(kdf.TLS13Prefix, make([]byte, 250), nil, 32)
+	test.CheckIsErr(t, err, "long label should be rejected")
+
+	_, err = kdf.HKDFLabel(kdf.TLS13Prefix, nil, make([]byte, 256), 32)
+	test.CheckIsErr(t, err, "long context should be rejected")
+
+	_, err = tls.ExpandLabel(early, []byte("key"), nil, 255*32+1)
+	test.CheckIsErr(t, err, "long output should be rejected")
+
+	empty, err := tls.ExpandLabel(early, []byte("key"), nil, 0)
+	test.CheckNoErr(t, err, "expand label err")
+	test.CheckOk(t, len(empty) == 0, "empty output expected")
+
+	_, err = (&kdf.Labeled{Hash: hash.SHAKE256, Prefix: kdf.TLS13Prefix}).ExpandLabel(early, []byte("key"), nil, 32)
+	test.CheckOk(t, errors.Is(err, hash.ErrXOF), "labeled HKDF over an XOF should be rejected")
+}
+
+// The key schedule of https://www.rfc-editor.org/rfc/rfc9180#appendix-A.1.1, with the info "Ode on a Grecian Urn".
+func TestHPKE(t *testing.T) {
+	h := &kdf.HPKE{Hash: hash.SHA256, SuiteID: append([]byte("HPKE"), 0x00, 0x20, 0x00, 0x01, 0x00, 0x01)}
+	sharedSecret := hexDecode(t, "fe0e18c9f024ce43799ae393c7e8fe8fce9d218875e8227b0187c04e7d2ea1fc")
+	info := hexDecode(t, "4f6465206f6e2061204772656369616e2055726e")
+
+	pskIDHash := h.LabeledExtract(nil, []byte("psk_id_hash"), nil)
+	infoHash := h.LabeledExtract(nil, []byte("info_hash"), info)
+	keyScheduleContext := append(append([]byte{0x00}, pskIDHash...), infoHash...)
+	checkEqual(t, keyScheduleContext, hexDecode(t, "00725611c9d98c07c03f60095cd32d400d8347d45ed67097bbad50fc56da742d07"+
+		"cb6cffde367bb0565ba28bb02c90744a20f5ef37f30523526106f637abb05449"), "key_schedule_context")
+
+	secret := h.LabeledExtract(sharedSecret, []byte("secret"), nil)
+	checkEqual(t, secret, hexDecode(t, "12fff91991e93b48de37e7daddb52981084bd8aa64289c3788471d9a9712f397"), "secret")
+
+	for _, v := range []struct {
+		label, out string
+	}{
+		{"key", "4531685d41d65f03dc48f6b8302c05b0"},
+		{"base_nonce", "56d890e5accaaf011cff4b7d"},
+		{"exp", "45ff1c2e220db587171952c0592d5f5ebe103f1561a2614e38f2ffd47e99e3f8"},
+	} {
+		want := hexDecode(t, v.out)
+		got, err := h.LabeledExpand(secret, []byte(v.label), keyScheduleContext, len(want))
+		test.CheckNoErr(t, err, "labeled expand err")
+		checkEqual(t, got, want, v.label)
+	}
+
+	_, err := h.LabeledExpand(secret, []byte("key"), nil, 255*32+1)
+	test.CheckIsErr(t, err, "long output should be rejected")
+}
+
+func TestSP800108(t *testing.T) {
+	key, label, context := []byte("key derivation key"), []byte("label"), []byte("context")
+	length := 40
+	fixed := append(append(append(append([]byte{}, label...), 0), context...), 0, 0, 1, 64)
+
+	mac := func(inputs ...[]byte) []byte {
+		m := hmac.New(sha256.New, key)
+		for _, in := range inputs {
+			m.Write(in)
+		}
+
+		return m.Sum(nil)
+	}
+
+	// the modes agree with their definitions over HMAC-SHA256
+	k1 := mac([]byte{0, 0, 0, 1}, fixed)
+	k2 := mac([]byte{0, 0, 0, 2}, fixed)
+	got, err := kdf.CounterMode(kdf.HMAC(hash.SHA256), key, label, context, length)
+	test.CheckNoErr(t, err, "counter mode err")
+	checkEqual(t, got, append(k1, k2...)[:length], "counter mode")
+
+	iv := []byte("initialization value")
+	k1 = mac(iv, []byte{0, 0, 0, 1}, fixed)
+	k2 = mac(k1, []byte{0, 0, 0, 2}, fixed)
+	got, err = kdf.FeedbackMode(kdf.HMAC(hash.SHA256), key, iv, label, context, length)
+	test.CheckNoErr(t, err, "feedback mode err")
+	checkEqual(t, got, append(k1, k2...)[:length], "feedback mode")
+
+	a1 := mac(fixed)
+	a2 := mac(a1)
+	k1 = mac(a1, []byte{0, 0, 0, 1}, fixed)
+	k2 = mac(a2, []byte{0, 0, 0, 2}, fixed)
+	got, err = kdf.DoublePipelineMode(kdf.HMAC(hash.SHA256), key, label, context, length)
+	test.CheckNoErr(t, err, "double-pipeline mode err")
+	checkEqual(t, got, append(k1, k2...)[:length], "double-pipeline mode")
+
+	// the modes work over KMAC, and the output depends on its length
+	for _, prf := range []kdf.PRF{kdf.KMAC128(), kdf.KMAC256(), kdf.HMAC(hash.BLAKE3)} {
+		long, err := kdf.CounterMode(prf, key, label, context, 100)
+		test.CheckNoErr(t, err, "counter mode err")
+
+		short, err := kdf.CounterMode(prf, key, label, context, 50)
+		test.CheckNoErr(t, err, "counter mode err")
+		test.CheckOk(t, len(long) == 100 && !bytes.Equal(long[:50], short), "the output should depend on its length")
+	}
+
+	// KMAC KDF of SP 800-108r1 is KMAC(K_IN, Context, L, Label)
+	kmac, err := kdf.KMACKDF128(key, label, context, 32)
+	test.CheckNoErr(t, err, "KMAC128 KDF err")
+
+	swapped, err := kdf.KMACKDF128(key, context, label, 32)
+	test.CheckNoErr(t, err, "KMAC128 KDF err")
+	test.CheckOk(t, len(kmac) == 32 && !bytes.Equal(kmac, swapped), "label and context should be separated")
+
+	kmac, err = kdf.KMACKDF256(key, label, context, 64)
+	test.CheckNoErr(t, err, "KMAC256 KDF err")
+	test.CheckOk(t, len(kmac) == 64, "wrong KMAC256 KDF length")
+
+	// the KMAC KDFs reject invalid lengths like the other modes, and derive nothing for a length of 0
+	kmac, err = kdf.KMACKDF128(key, label, context, 0)
+	test.CheckNoErr(t, err, "KMAC128 KDF err")
+	test.CheckOk(t, len(kmac) == 0, "wrong KMAC128 KDF length")
+
+	_, err = kdf.KMACKDF256(key, label, context, -1)
+	test.CheckIsErr(t, err, "negative length should be rejected")
+
+	_, err = kdf.CounterMode(kdf.HMAC(hash.SHA256), key, label, context, -1)
+	test.CheckIsErr(t, err, "negative length should be rejected")
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kdf
+
+import (
+	"math"
+
+	"github.com/cymony/cryptomony/hash"
+	"github.com/cymony/cryptomony/utils"
+)
+
+// TLS13Prefix is the label prefix of TLS 1.3.
+const TLS13Prefix = "tls13 "
+
+// hpkeVersion is the version label of HPKE.
+const hpkeVersion = "HPKE-v1"
+
+// HKDFLabel returns the HkdfLabel structure of TLS 1.3, with the label prefixed by prefix instead of "tls13 ".
+// See https://www.rfc-editor.org/rfc/rfc8446#section-7.1
+//
+//	struct {
+//	  uint16 length = Length;
+//	  opaque label<7..255> = prefix + Label;
+//	  opaque context<0..255> = Context;
+//	} HkdfLabel;
+func HKDFLabel(prefix string, label, context []byte, length int) ([]byte, error) {
+	if length < 0 || length > math.MaxUint16 {
+		return nil, ErrLengthTooHigh
+	}
+
+	if len(prefix)+len(label) > math.MaxUint8 || len(context) > math.MaxUint8 {
+		return nil, ErrLabelTooLong
+	}
+
+	return utils.Concat(
+		[]byte{byte(length >> 8), byte(length), byte(len(prefix) + len(label))},
+		[]byte(prefix), label,
+		[]byte{byte(len(context))}, context,
+	), nil
+}
+
+// Labeled is the labeled HKDF of TLS 1.3 with the hash function, whose labels are prefixed by Prefix, e.g. TLS13Prefix.
+type Labeled struct {
+	Hash   hash.Hashing
+	Prefix string
+}
+
+// ExpandLabel returns HKDF-Expand-Label(Secret, Label, Context, Length) = HKDF-Expand(Secret, HkdfLabel, Length).
+func (l *Labeled) ExpandLabel(secret, label, context []byte, length int) ([]byte, error) {
+	if !l.Hash.Available() {
+		return nil, ErrInvalidHash
+	}
+
+	if l.Hash.IsXOF() {
+		return nil, hash.ErrXOF
+	}
+
+	if length > maxHKDFBlocks*l.Hash.Size() {
+		return nil, ErrLengthTooHigh
+	}
+
+	info, err := HKDFLabel(l.Prefix, label, context, length)
+	if err != nil {
+		return nil, err
+	}
+
+	return hkdfExpand(l.Hash, secret, info, length), nil
+}
+
+// DeriveSecret returns Derive-Secret(Secret, Label, Messages) = HKDF-Expand-Label(Secret, Label,
+// Transcript-Hash(Messages), Hash.length), given the transcript hash.
+func (l *Labeled) DeriveSecret(secret, label, transcriptHash []byte) ([]byte, error) {
+	return l.ExpandLabel(secret, label, transcriptHash, l.Hash.Size())
+}
+
+// HPKE is the labeled HKDF of HPKE with the hash function, whose labels are prefixed by "HPKE-v1" and the suite
+// identifier, e.g. "KEM" || I2OSP(kem_id, 2) for the KEM.
+// See https://www.rfc-editor.org/rfc/rfc9180#section-4
+type HPKE struct {
+	Hash    hash.Hashing
+	SuiteID []byte
+}
+
+// LabeledExtract returns Extract(salt, "HPKE-v1" || suite_id || label || ikm). It panics with hash.ErrXOF for
+// extendable-output functions.
+func (h *HPKE) LabeledExtract(salt, label, ikm []byte) []byte {
+	return h.Hash.New().HKDFExtract(utils.Concat([]byte(hpkeVersion), h.SuiteID, label, ikm), salt)
+}
+
+// LabeledExpand returns Expand(prk, I2OSP(L, 2) || "HPKE-v1" || suite_id || label || info, L).
+func (h *HPKE) LabeledExpand(prk, label, info []byte, length int) ([]byte, error) {
+	if !h.Hash.Available() {
+		return nil, ErrInvalidHash
+	}
+
+	if h.Hash.IsXOF() {
+		return nil, hash.ErrXOF
+	}
+
+	if length < 0 || length > math.MaxUint16 || length > maxHKDFBlocks*h.Hash.Size() {
+		return nil, ErrLengthTooHigh
+	}
+
+	labeledInfo := utils.Concat([]byte{byte(length >> 8), byte(length)}, []byte(hpkeVersion), h.SuiteID, label, info)
+
+	return hkdfExpand(h.Hash, prk, labeledInfo, length), nil
+}
+
+// hkdfExpand returns HKDF-Expand(prk, info, length), where Hash.HKDFExpand would return a full block for length 0.
+func hkdfExpand(h hash.Hashing, prk, info []byte, length int) []byte {
+	if length == 0 {
+		return []byte{}
+	}
+
+	return h.New().HKDFExpand(prk, info, length)
+}
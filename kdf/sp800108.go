@@ -0,0 +1,252 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kdf
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/cymony/cryptomony/hash"
+	"github.com/cymony/cryptomony/utils"
+	"github.com/cymony/cryptomony/xof"
+)
+
+// Key-based key derivation functions of NIST SP 800-108r1.
+// See https://nvlpubs.nist.gov/nistpubs/SpecialPublications/NIST.SP.800-108r1-upd1.pdf
+//
+// The fixed input data of the counter, feedback and double-pipeline modes is
+// Label || 0x00 || Context || [L]_32, with L the output length in bits, and the counter [i]_32 precedes it.
+
+// PRF is a pseudorandom function of the key-based key derivation functions.
+type PRF interface {
+	// Size returns the output length of the PRF in bytes.
+	Size() int
+
+	// Sum returns the output of the PRF with the key over the concatenation of the inputs.
+	Sum(key []byte, inputs ...[]byte) ([]byte, error)
+}
+
+// HMAC returns the HMAC PRF with the hash function.
+func HMAC(h hash.Hashing) PRF {
+	return hmacPRF{h}
+}
+
+// KMAC128 returns the KMAC128 PRF, with a 32 bytes output and an empty customization string.
+func KMAC128() PRF {
+	return kmacPRF{xof.NewKMAC128, 32} //nolint:gomnd //256-bit outputs
+}
+
+// KMAC256 returns the KMAC256 PRF, with a 64 bytes output and an empty customization string.
+func KMAC256() PRF {
+	return kmacPRF{xof.NewKMAC256, 64} //nolint:gomnd //512-bit outputs
+}
+
+type hmacPRF struct {
+	h hash.Hashing
+}
+
+func (p hmacPRF) Size() int {
+	return p.h.Size()
+}
+
+func (p hmacPRF) Sum(key []byte, inputs ...[]byte) ([]byte, error) {
+	return p.h.New().Hmac(utils.Concat(inputs...), key)
+}
+
+type kmacPRF struct {
+	newKMAC func(key, customization []byte, length int) xof.XOF
+	size    int
+}
+
+func (p kmacPRF) Size() int {
+	return p.size
+}
+
+func (p kmacPRF) Sum(key []byte, inputs ...[]byte) ([]byte, error) {
+	k := p.newKMAC(key, nil, p.size)
+	if err := k.MustWriteAll(inputs...); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, p.size)
+	if err := k.MustReadFull(out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// counterLen is the byte length of the counters of the modes.
+const counterLen = 4
+
+// checkLength returns ErrLengthTooHigh if the output length is negative, or if its bit length does not fit in 32 bits.
+func checkLength(length int) error {
+	if length < 0 || uint64(length) > math.MaxUint32/8 {
+		return ErrLengthTooHigh
+	}
+
+	return nil
+}
+
+// fixedInput returns the fixed input data.
+func fixedInput(label, context []byte, length int) ([]byte, error) {
+	if err := checkLength(length); err != nil {
+		return nil, err
+	}
+
+	var l [4]byte
+
+	binary.BigEndian.PutUint32(l[:], uint32(length)*8) //nolint:gomnd //bit length
+
+	return utils.Concat(label, []byte{0}, context, l[:]), nil
+}
+
+// blocks returns the number of PRF blocks of the output, and an error if their counter of r bytes overflows.
+func blocks(prf PRF, r, length int) (int, error) {
+	n := (length + prf.Size() - 1) / prf.Size()
+	if uint64(n) >= 1<<(8*r) {
+		return 0, ErrLengthTooHigh
+	}
+
+	return n, nil
+}
+
+// counter returns [i]_r, the big-endian encoding of i in r bytes.
+func counter(i, r int) []byte {
+	var c [8]byte
+
+	binary.BigEndian.PutUint64(c[:], uint64(i))
+
+	return c[len(c)-r:]
+}
+
+// CounterMode returns length bytes derived from the key with the counter mode KDF over the PRF:
+// K(i) = PRF(K_IN, [i]_32 || Label || 0x00 || Context || [L]_32).
+func CounterMode(prf PRF, key, label, context []byte, length int) ([]byte, error) {
+	fixed, err := fixedInput(label, context, length)
+	if err != nil {
+		return nil, err
+	}
+
+	return counterMode(prf, key, fixed, counterLen, length)
+}
+
+// counterMode is CounterMode with the fixed input data and counters of r bytes.
+func counterMode(prf PRF, key, fixed []byte, r, length int) ([]byte, error) {
+	n, err := blocks(prf, r, length)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, n*prf.Size())
+
+	for i := 1; i <= n; i++ {
+		k, err := prf.Sum(key, counter(i, r), fixed)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, k...)
+	}
+
+	return out[:length], nil
+}
+
+// FeedbackMode returns length bytes derived from the key with the feedback mode KDF over the PRF, with the
+// initialization value iv: K(0) = IV, K(i) = PRF(K_IN, K(i-1) || [i]_32 || Label || 0x00 || Context || [L]_32).
+func FeedbackMode(prf PRF, key, iv, label, context []byte, length int) ([]byte, error) {
+	fixed, err := fixedInput(label, context, length)
+	if err != nil {
+		return nil, err
+	}
+
+	return feedbackMode(prf, key, iv, fixed, counterLen, length)
+}
+
+// feedbackMode is FeedbackMode with the fixed input data and counters of r bytes.
+func feedbackMode(prf PRF, key, iv, fixed []byte, r, length int) ([]byte, error) {
+	n, err := blocks(prf, r, length)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, n*prf.Size())
+	k := iv
+
+	for i := 1; i <= n; i++ {
+		if k, err = prf.Sum(key, k, counter(i, r), fixed); err != nil {
+			return nil, err
+		}
+
+		out = append(out, k...)
+	}
+
+	return out[:length], nil
+}
+
+// DoublePipelineMode returns length bytes derived from the key with the double-pipeline iteration mode KDF over the
+// PRF: A(0) = Label || 0x00 || Context || [L]_32, A(i) = PRF(K_IN, A(i-1)), and K(i) = PRF(K_IN, A(i) || [i]_32 ||
+// Label || 0x00 || Context || [L]_32).
+func DoublePipelineMode(prf PRF, key, label, context []byte, length int) ([]byte, error) {
+	fixed, err := fixedInput(label, context, length)
+	if err != nil {
+		return nil, err
+	}
+
+	return doublePipelineMode(prf, key, fixed, counterLen, length)
+}
+
+// doublePipelineMode is DoublePipelineMode with the fixed input data and counters of r bytes.
+func doublePipelineMode(prf PRF, key, fixed []byte, r, length int) ([]byte, error) {
+	n, err := blocks(prf, r, length)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, n*prf.Size())
+	a := fixed
+
+	for i := 1; i <= n; i++ {
+		if a, err = prf.Sum(key, a); err != nil {
+			return nil, err
+		}
+
+		k, err := prf.Sum(key, a, counter(i, r), fixed)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, k...)
+	}
+
+	return out[:length], nil
+}
+
+// KMACKDF128 returns length bytes derived from the key with the KMAC128 KDF of SP 800-108r1:
+// KMAC128(K_IN, Context, L, Label).
+func KMACKDF128(key, label, context []byte, length int) ([]byte, error) {
+	return kmacKDF(xof.KMAC128, key, label, context, length)
+}
+
+// KMACKDF256 returns length bytes derived from the key with the KMAC256 KDF of SP 800-108r1:
+// KMAC256(K_IN, Context, L, Label).
+func KMACKDF256(key, label, context []byte, length int) ([]byte, error) {
+	return kmacKDF(xof.KMAC256, key, label, context, length)
+}
+
+func kmacKDF(kmac func(key, message, customization []byte, length int) []byte, key, label, context []byte,
+	length int,
+) ([]byte, error) {
+	if err := checkLength(length); err != nil {
+		return nil, err
+	}
+
+	// KMAC has no empty output
+	if length == 0 {
+		return []byte{}, nil
+	}
+
+	return kmac(key, context, label, length), nil
+}
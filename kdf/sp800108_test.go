@@ -0,0 +1,108 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kdf
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec //PRF of the CAVP vectors
+	"encoding/hex"
+	"testing"
+
+	"github.com/cymony/cryptomony/hash"
+	"github.com/cymony/cryptomony/internal/test"
+)
+
+// hmacSHA1 is the HMAC-SHA1 PRF of the CAVP vectors, which the hash package does not provide.
+type hmacSHA1 struct{}
+
+func (hmacSHA1) Size() int {
+	return sha1.Size
+}
+
+func (hmacSHA1) Sum(key []byte, inputs ...[]byte) ([]byte, error) {
+	m := hmac.New(sha1.New, key)
+	for _, in := range inputs {
+		m.Write(in)
+	}
+
+	return m.Sum(nil), nil
+}
+
+// CAVP vectors of the SP 800-108 key-based KDFs, with the fixed input data given as is.
+// See https://csrc.nist.gov/projects/cryptographic-algorithm-validation-program/key-derivation
+func TestCAVP(t *testing.T) {
+	for _, v := range []struct {
+		name  string
+		prf   PRF
+		mode  string
+		r     int // counter length in bytes
+		ki    string
+		iv    string
+		fixed string
+		ko    string
+	}{
+		{
+			name:  "KDFCTR HMAC_SHA1 BEFORE_FIXED RLEN=32_BITS COUNT=0",
+			prf:   hmacSHA1{},
+			mode:  "counter",
+			r:     4,
+			ki:    "f7591733c856593565130975351954d0155abf3c",
+			fixed: "8e347ef55d5f5e99eab6de706b51de7ce004f3882889e259ff4e5cff102167a5a4bd711578d4ce17dd9abe56e51c1f2df950e2fc812ec1b217ca08d6",
+			ko:    "34fe44b0d8c41b93f5fa64fb96f00e5b",
+		},
+		{
+			name:  "KDFCTR HMAC_SHA256 BEFORE_FIXED RLEN=32_BITS COUNT=0",
+			prf:   HMAC(hash.SHA256),
+			mode:  "counter",
+			r:     4,
+			ki:    "dd1d91b7d90b2bd3138533ce92b272fbf8a369316aefe242e659cc0ae238afe0",
+			fixed: "01322b96b30acd197979444e468e1c5c6859bf1b1cf951b7e725303e237e46b864a145fab25e517b08f8683d0315bb2911d80a0e8aba17f3b413faac",
+			ko:    "10621342bfb0fd40046c0e29f2cfdbf0",
+		},
+		{
+			name:  "KDFFeedback HMAC_SHA1 AFTER_ITER RLEN=8_BITS COUNT=0",
+			prf:   hmacSHA1{},
+			mode:  "feedback",
+			r:     1,
+			ki:    "00a39bd547fb88b2d98727cf64c195c61e1cad6c",
+			fixed: "98132c1ffaf59ae5cbc0a3133d84c551bb97e0c75ecaddfc30056f6876f59803009bffc7d75c4ed46f40b8f80426750d15bc1ddb14ac5dcb69a68242",
+			ko:    "0611e1903609b47ad7a5fc2c82e47702",
+		},
+	} {
+		ki, iv, fixed, ko := hexString(t, v.ki), hexString(t, v.iv), hexString(t, v.fixed), hexString(t, v.ko)
+
+		var (
+			got []byte
+			err error
+		)
+
+		switch v.mode {
+		case "counter":
+			got, err = counterMode(v.prf, ki, fixed, v.r, len(ko))
+		case "feedback":
+			got, err = feedbackMode(v.prf, ki, iv, fixed, v.r, len(ko))
+		}
+
+		test.CheckNoErr(t, err, v.name+" err")
+
+		if !bytes.Equal(got, ko) {
+			test.Report(t, got, ko, v.name)
+		}
+	}
+
+	// the counters must not overflow
+	_, err := counterMode(hmacSHA1{}, nil, nil, 1, 256*sha1.Size)
+	test.CheckIsErr(t, err, "overflowing counter should be rejected")
+}
+
+func hexString(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	test.CheckNoErr(t, err, "decode string err")
+
+	return b
+}
@@ -11,13 +11,16 @@ import (
 )
 
 const (
-	argon2idStr = "Argon2id"
+	argon2idStr = "argon2id"
 
 	defaultArgon2idTime    = 3
 	defaultArgon2idMemory  = 64 * 1024
 	defaultArgon2idThreads = 4
 )
 
+// argon2idVersion is the version segment of the PHC strings, of the supported Argon2 version.
+var argon2idVersion = fmt.Sprintf("v=%d", argon2.Version)
+
 type argon2KSF struct {
 	str                   string
 	time, memory, threads int
@@ -36,16 +39,27 @@ func (a *argon2KSF) Harden(password, salt []byte, length int) ([]byte, error) {
 	return argon2.IDKey(password, salt, uint32(a.time), uint32(a.memory), uint8(a.threads), uint32(length)), nil
 }
 
+// SetOptions applies the options, and leaves the parameters unchanged if they are invalid together, since Argon2
+// requires 8 KiB of memory per thread.
 func (a *argon2KSF) SetOptions(options ...Option) error {
+	c := *a
+
 	for _, option := range options {
-		if err := option(a); err != nil {
+		if err := option(&c); err != nil {
 			return err
 		}
 	}
 
+	if c.memory < 8*c.threads { //nolint:gomnd //Argon2 requires 8 KiB blocks per lane
+		return ErrInvalidParameter
+	}
+
+	*a = c
+
 	return nil
 }
 
+// String returns the PHC string of the parameters, e.g. $argon2id$v=19$m=65536,t=3,p=4.
 func (a *argon2KSF) String() string {
-	return fmt.Sprintf("%s(%d,%d,%d)", a.str, a.time, a.memory, a.threads)
+	return phcString(a.str, argon2idVersion, phcParam{"m", a.memory}, phcParam{"t", a.time}, phcParam{"p", a.threads})
 }
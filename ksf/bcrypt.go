@@ -4,34 +4,51 @@
 
 package ksf
 
-import (
-	"fmt"
-
-	"golang.org/x/crypto/bcrypt"
-)
+import "golang.org/x/crypto/bcrypt"
 
 const (
-	bcryptStr = "Bcrypt"
+	bcryptStr = "bcrypt-pbkdf"
 
-	defaultBcryptCost = 10
+	defaultBcryptRounds = 16
+	defaultBcryptCost   = 10
 )
 
+// PasswordHasher is implemented by the Bcrypt KSF instances, which hash passwords for storage in the bcrypt modular
+// crypt format ($2a$), with a random salt and the bcrypt cost. It is not deterministic, and cannot be used as a KSF.
+type PasswordHasher interface {
+	// HashPassword returns the bcrypt hash of the password, with a random salt.
+	HashPassword(password []byte) ([]byte, error)
+	// ComparePassword returns nil if the bcrypt hash is the hash of the password, and an error otherwise.
+	ComparePassword(hashed, password []byte) error
+}
+
 type bcryptKSF struct {
-	str  string
-	cost int
+	str    string
+	rounds int
+	cost   int
 }
 
 func newBcrypt() KSF {
 	return &bcryptKSF{
-		str:  bcryptStr,
-		cost: defaultBcryptCost,
+		str:    bcryptStr,
+		rounds: defaultBcryptRounds,
+		cost:   defaultBcryptCost,
 	}
 }
 
-func (b *bcryptKSF) Harden(password, _ []byte, _ int) ([]byte, error) {
+// Harden returns bcrypt_pbkdf(password, salt, rounds) of length bytes, which is at most 1024.
+func (b *bcryptKSF) Harden(password, salt []byte, length int) ([]byte, error) {
+	return bcryptPBKDF(password, salt, b.rounds, length)
+}
+
+func (b *bcryptKSF) HashPassword(password []byte) ([]byte, error) {
 	return bcrypt.GenerateFromPassword(password, b.cost)
 }
 
+func (b *bcryptKSF) ComparePassword(hashed, password []byte) error {
+	return bcrypt.CompareHashAndPassword(hashed, password)
+}
+
 func (b *bcryptKSF) SetOptions(options ...Option) error {
 	for _, option := range options {
 		if err := option(b); err != nil {
@@ -42,6 +59,7 @@ func (b *bcryptKSF) SetOptions(options ...Option) error {
 	return nil
 }
 
+// String returns the PHC string of the parameters, e.g. $bcrypt-pbkdf$rounds=16,cost=10.
 func (b *bcryptKSF) String() string {
-	return fmt.Sprintf("%s(%d)", b.str, b.cost)
+	return phcString(b.str, "", phcParam{"rounds", b.rounds}, phcParam{"cost", b.cost})
 }
@@ -0,0 +1,102 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ksf
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+
+	"golang.org/x/crypto/blowfish"
+)
+
+// bcrypt_pbkdf, the bcrypt-based key derivation function of OpenBSD, used by OpenSSH to encrypt private keys.
+// See https://cvsweb.openbsd.org/cgi-bin/cvsweb/src/lib/libutil/bcrypt_pbkdf.c
+
+const (
+	// bcryptHashSize is the output size of bcrypt_hash.
+	bcryptHashSize = 32
+	// maxBcryptPBKDFLength is the maximum output length of bcrypt_pbkdf, of 32 interleaved blocks.
+	maxBcryptPBKDFLength = bcryptHashSize * bcryptHashSize
+	// bcryptHashRounds is the number of key expansions and encryptions of bcrypt_hash, a bcrypt cost of 6.
+	bcryptHashRounds = 64
+)
+
+// bcryptMagic is the plaintext encrypted by bcrypt_hash.
+var bcryptMagic = []byte("OxychromaticBlowfishSwatDynamite")
+
+// bcryptHash sets out to bcrypt_hash(sha2pass, sha2salt).
+func bcryptHash(out, sha2pass, sha2salt []byte) {
+	c, err := blowfish.NewSaltedCipher(sha2pass, sha2salt)
+	if err != nil {
+		// the key is a SHA-512 output, which is a valid blowfish key
+		panic(err)
+	}
+
+	for i := 0; i < bcryptHashRounds; i++ {
+		blowfish.ExpandKey(sha2salt, c)
+		blowfish.ExpandKey(sha2pass, c)
+	}
+
+	copy(out, bcryptMagic)
+
+	for i := 0; i < bcryptHashSize; i += blowfish.BlockSize {
+		for j := 0; j < bcryptHashRounds; j++ {
+			c.Encrypt(out[i:i+blowfish.BlockSize], out[i:i+blowfish.BlockSize])
+		}
+	}
+
+	// the big-endian words of the cipher are output in little-endian order
+	for i := 0; i < bcryptHashSize; i += 4 {
+		binary.LittleEndian.PutUint32(out[i:], binary.BigEndian.Uint32(out[i:]))
+	}
+}
+
+// bcryptPBKDF returns bcrypt_pbkdf(password, salt, rounds) of length bytes. Unlike OpenBSD, it accepts empty passwords
+// and salts, since OPAQUE stretches the OPRF output without salt.
+func bcryptPBKDF(password, salt []byte, rounds, length int) ([]byte, error) {
+	if rounds < 1 {
+		return nil, ErrInvalidRounds
+	}
+
+	if length < 0 || length > maxBcryptPBKDFLength {
+		return nil, ErrLengthTooHigh
+	}
+
+	blocks := (length + bcryptHashSize - 1) / bcryptHashSize
+	key := make([]byte, blocks*bcryptHashSize)
+
+	sha2pass := sha512.Sum512(password)
+	h := sha512.New()
+
+	var counter [4]byte
+
+	tmp, out := make([]byte, bcryptHashSize), make([]byte, bcryptHashSize)
+
+	for block := 1; block <= blocks; block++ {
+		binary.BigEndian.PutUint32(counter[:], uint32(block))
+
+		h.Reset()
+		_, _ = h.Write(salt)       //nolint:errcheck //hash writes never fail
+		_, _ = h.Write(counter[:]) //nolint:errcheck //hash writes never fail
+		bcryptHash(tmp, sha2pass[:], h.Sum(nil))
+		copy(out, tmp)
+
+		for r := 1; r < rounds; r++ {
+			sha2salt := sha512.Sum512(tmp)
+			bcryptHash(tmp, sha2pass[:], sha2salt[:])
+
+			for i := range out {
+				out[i] ^= tmp[i]
+			}
+		}
+
+		// the output blocks are interleaved
+		for i, v := range out {
+			key[i*blocks+block-1] = v
+		}
+	}
+
+	return key[:length], nil
+}
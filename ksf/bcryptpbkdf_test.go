@@ -0,0 +1,112 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ksf
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/cymony/cryptomony/internal/test"
+)
+
+// Vectors of the OpenBSD reference implementation, as in the OpenSSH tests of golang.org/x/crypto.
+func TestBcryptPBKDF(t *testing.T) {
+	for i, v := range []struct {
+		rounds         int
+		password, salt string
+		want           string
+	}{
+		{
+			rounds:   12,
+			password: "password",
+			salt:     "salt",
+			want:     "1ae42c05d487bc02f64921a4ebe4ea93bcacfe135fda99974c06b7b01fae149a",
+		},
+		{
+			rounds:   3,
+			password: "passwordy\x00PASSWORD\x00",
+			salt:     "salty\x00SALT\x00",
+			want:     "7f310bd3e78c3280c59ce4595211a2928e8d4ec744c1ed2efc9f764e3388e0ad",
+		},
+		{
+			rounds:   8,
+			password: "секретное слово",
+			salt:     "посолить немножко",
+			want: "8df43fc6fe131fc47f0c9e39224bd94c70b6fcc8ee8135faddf61156e6cb2733ea765f315a3e1e4afc35bf8687d189254c1e05a6fe80" +
+				"c0617f9183d67260d6a115c6c94e3603e2303fbb43a76a64523ffda686b1d4518543",
+		},
+	} {
+		want, err := hex.DecodeString(v.want)
+		test.CheckNoErr(t, err, "decode string err")
+
+		k := Bcrypt.New()
+		test.CheckNoErr(t, k.SetOptions(WithBcryptRounds(v.rounds)), "options err")
+
+		got, err := k.Harden([]byte(v.password), []byte(v.salt), len(want))
+		test.CheckNoErr(t, err, "harden err")
+
+		if !bytes.Equal(got, want) {
+			test.Report(t, got, want, i)
+		}
+	}
+
+	var pass, salt [64]byte
+
+	for i := range pass {
+		pass[i] = byte(i)
+		salt[i] = byte(i + 64)
+	}
+
+	got := make([]byte, bcryptHashSize)
+	bcryptHash(got, pass[:], salt[:])
+
+	if want, _ := hex.DecodeString("87904870eef9deddf8e7611a140106e6aaf1a363d9a2c504db356443721eb555"); !bytes.Equal(got, want) {
+		test.Report(t, got, want, "bcrypt_hash")
+	}
+}
+
+func TestBcrypt(t *testing.T) {
+	k := Bcrypt.New()
+	test.CheckNoErr(t, k.SetOptions(WithBcryptRounds(2), WithBcryptCost(4)), "options err")
+
+	password, salt := []byte("SecretPass"), []byte("salt")
+
+	one, err := k.Harden(password, salt, 64)
+	test.CheckNoErr(t, err, "harden err")
+	two, err := k.Harden(password, salt, 64)
+	test.CheckNoErr(t, err, "harden err")
+	test.CheckOk(t, bytes.Equal(one, two), "harden should be deterministic")
+
+	other, err := k.Harden(password, []byte("other salt"), 64)
+	test.CheckNoErr(t, err, "harden err")
+	test.CheckOk(t, !bytes.Equal(one, other), "harden should depend on the salt")
+
+	noSalt, err := k.Harden(password, nil, 32)
+	test.CheckNoErr(t, err, "empty salt should be accepted")
+	test.CheckOk(t, len(noSalt) == 32, "wrong output length")
+
+	_, err = k.Harden(password, salt, maxBcryptPBKDFLength+1)
+	test.CheckOk(t, errors.Is(err, ErrLengthTooHigh), "too long output should be rejected")
+
+	err = k.SetOptions(WithBcryptRounds(0))
+	test.CheckOk(t, errors.Is(err, ErrInvalidParameter), "zero rounds should be rejected")
+
+	_, err = bcryptPBKDF(password, salt, 0, 32)
+	test.CheckOk(t, errors.Is(err, ErrInvalidRounds), "zero rounds should be rejected")
+
+	hasher, ok := k.(PasswordHasher)
+	test.CheckOk(t, ok, "bcrypt should be a password hasher")
+
+	hashed, err := hasher.HashPassword(password)
+	test.CheckNoErr(t, err, "hash password err")
+	test.CheckOk(t, bytes.HasPrefix(hashed, []byte("$2a$04$")), "wrong bcrypt hash prefix")
+	test.CheckNoErr(t, hasher.ComparePassword(hashed, password), "password should match")
+	test.CheckIsErr(t, hasher.ComparePassword(hashed, []byte("WrongPass")), "wrong password should not match")
+
+	err = Scrypt.New().SetOptions(WithBcryptRounds(1))
+	test.CheckOk(t, errors.Is(err, ErrNotBcrypt), "bcrypt option should be rejected")
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ksf
+
+import (
+	"time"
+)
+
+const (
+	// calibrationArgon2idMemory is the memory in KiB of the first Argon2id measure, from which the memory meeting the
+	// target is extrapolated.
+	calibrationArgon2idMemory = 16 * 1024
+	// calibrationScryptN is the n parameter of the first scrypt measure.
+	calibrationScryptN = 1 << 14
+	// maxCalibrationMeasures bounds the measures of the parameters decreasing to the target.
+	maxCalibrationMeasures = 8
+)
+
+// calibrationPassword and calibrationSalt are the inputs of the measures.
+var (
+	calibrationPassword = []byte("calibration password")
+	calibrationSalt     = []byte("calibration salt")
+)
+
+// Calibrate benchmarks the KSF, Argon2id or Scrypt, on the current machine, and returns the options of the most
+// expensive parameters whose Harden takes at most the target duration and uses at most maxMemory bytes. The memory
+// budget is used first, and the remaining time is spent on the number of Argon2id passes. If even the cheapest
+// parameters exceed the target, they are returned. The options can be passed to SetOptions, NewHasher, or the opaque
+// suites.
+func Calibrate(id Identifier, target time.Duration, maxMemory int) ([]Option, error) {
+	if target <= 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	switch id {
+	case Argon2id:
+		return calibrateArgon2id(target, maxMemory)
+	case Scrypt:
+		return calibrateScrypt(target, maxMemory)
+	default:
+		return nil, ErrNotSupportedAlgorithm
+	}
+}
+
+// measure returns the duration of Harden with the options, of at least 1ns.
+func measure(id Identifier, options ...Option) (time.Duration, error) {
+	k := id.New()
+	if err := k.SetOptions(options...); err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+
+	if _, err := k.Harden(calibrationPassword, calibrationSalt, keyLength); err != nil {
+		return 0, err
+	}
+
+	if elapsed := time.Since(start); elapsed > 0 {
+		return elapsed, nil
+	}
+
+	return 1, nil
+}
+
+// scale returns x * num / den, where the duration of Harden is roughly linear in x.
+func scale(x int, num, den time.Duration) int {
+	return int(float64(x) * float64(num) / float64(den))
+}
+
+func calibrateArgon2id(target time.Duration, maxMemory int) ([]Option, error) {
+	threads := defaultArgon2idThreads
+	minMemory := 8 * threads //nolint:gomnd //Argon2 requires 8 KiB blocks per lane
+
+	maxKiB := maxMemory / 1024 //nolint:gomnd //Argon2 memory is in KiB
+	if maxKiB < minMemory {
+		return nil, ErrInvalidParameter
+	}
+
+	if maxKiB > maxArgon2Memory {
+		maxKiB = maxArgon2Memory
+	}
+
+	options := func(memory, passes int) []Option {
+		return []Option{WithArgon2Memory(memory), WithArgon2Time(passes), WithArgon2Threads(threads)}
+	}
+
+	memory := calibrationArgon2idMemory
+	if memory > maxKiB {
+		memory = maxKiB
+	}
+
+	elapsed, err := measure(Argon2id, options(memory, 1)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if memory < maxKiB && elapsed < target {
+		if memory = scale(memory, target, elapsed); memory > maxKiB {
+			memory = maxKiB
+		}
+
+		if elapsed, err = measure(Argon2id, options(memory, 1)...); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := 0; i < maxCalibrationMeasures && elapsed > target && memory > minMemory; i++ {
+		if memory = scale(memory, target, elapsed); memory < minMemory {
+			memory = minMemory
+		}
+
+		if elapsed, err = measure(Argon2id, options(memory, 1)...); err != nil {
+			return nil, err
+		}
+	}
+
+	passes := int(target / elapsed)
+	if passes < 1 {
+		passes = 1
+	}
+
+	return options(memory, passes), nil
+}
+
+func calibrateScrypt(target time.Duration, maxMemory int) ([]Option, error) {
+	r, p := defaultScryptr, defaultScryptp
+
+	// scrypt uses 128 * r * n bytes
+	maxN := 2
+	for 128*r*maxN*2 <= maxMemory {
+		maxN *= 2
+	}
+
+	if 128*r*maxN > maxMemory {
+		return nil, ErrInvalidParameter
+	}
+
+	options := func(n int) []Option {
+		return []Option{WithScryptN(n), WithScryptR(r), WithScryptP(p)}
+	}
+
+	n := calibrationScryptN
+	if n > maxN {
+		n = maxN
+	}
+
+	elapsed, err := measure(Scrypt, options(n)...)
+	if err != nil {
+		return nil, err
+	}
+
+	for n < maxN && 2*elapsed <= target {
+		n *= 2
+
+		if elapsed, err = measure(Scrypt, options(n)...); err != nil {
+			return nil, err
+		}
+	}
+
+	for elapsed > target && n > 2 {
+		n /= 2
+
+		if elapsed, err = measure(Scrypt, options(n)...); err != nil {
+			return nil, err
+		}
+	}
+
+	return options(n), nil
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ksf
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cymony/cryptomony/internal/test"
+)
+
+func TestCalibrate(t *testing.T) {
+	const (
+		target    = 20 * time.Millisecond
+		maxMemory = 8 << 20
+	)
+
+	options, err := Calibrate(Argon2id, target, maxMemory)
+	test.CheckNoErr(t, err, "argon2id calibration err")
+
+	k := Argon2id.New()
+	test.CheckNoErr(t, k.SetOptions(options...), "argon2id options err")
+
+	ar, _ := k.(*argon2KSF) //nolint:errcheck //type is known
+	test.CheckOk(t, ar.memory*1024 <= maxMemory, "argon2id memory over budget "+k.String())
+	test.CheckOk(t, ar.memory >= 8*ar.threads && ar.time >= 1, "invalid argon2id parameters "+k.String())
+
+	options, err = Calibrate(Scrypt, target, maxMemory)
+	test.CheckNoErr(t, err, "scrypt calibration err")
+
+	k = Scrypt.New()
+	test.CheckNoErr(t, k.SetOptions(options...), "scrypt options err")
+
+	sc, _ := k.(*scryptKSF) //nolint:errcheck //type is known
+	test.CheckOk(t, 128*sc.r*sc.n <= maxMemory, "scrypt memory over budget "+k.String())
+
+	// the calibrated options can be used by a Hasher
+	h, err := NewHasher(Scrypt, options...)
+	test.CheckNoErr(t, err, "hasher creation err")
+	encoded, err := h.Hash([]byte("password"))
+	test.CheckNoErr(t, err, "hash err")
+	test.CheckOk(t, !h.NeedsRehash(encoded), "hash should follow the calibrated policy")
+
+	// a tiny target gives the cheapest parameters
+	options, err = Calibrate(Scrypt, time.Nanosecond, maxMemory)
+	test.CheckNoErr(t, err, "scrypt calibration err")
+	test.CheckNoErr(t, k.SetOptions(options...), "scrypt options err")
+	test.CheckOk(t, k.String() == "$scrypt$ln=1,r=8,p=1", "wrong cheapest parameters "+k.String())
+
+	_, err = Calibrate(Argon2id, 0, maxMemory)
+	test.CheckOk(t, errors.Is(err, ErrInvalidParameter), "non-positive target should be rejected")
+
+	_, err = Calibrate(Argon2id, target, 16*1024)
+	test.CheckOk(t, errors.Is(err, ErrInvalidParameter), "too small memory should be rejected")
+
+	_, err = Calibrate(Scrypt, target, 1024)
+	test.CheckOk(t, errors.Is(err, ErrInvalidParameter), "too small memory should be rejected")
+
+	_, err = Calibrate(Bcrypt, target, maxMemory)
+	test.CheckOk(t, errors.Is(err, ErrNotSupportedAlgorithm), "bcrypt should be rejected")
+}
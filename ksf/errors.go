@@ -15,4 +15,12 @@ var (
 	ErrNotScrypt = errors.New("ksf: instance is not scrypt")
 	// ErrNotSupportedAlgorithm returns non supported ksf algorithm selected.
 	ErrNotSupportedAlgorithm = errors.New("ksf: algorithm not supported")
+	// ErrInvalidRounds returns when the number of bcrypt_pbkdf rounds is not positive.
+	ErrInvalidRounds = errors.New("ksf: number of rounds must be positive")
+	// ErrLengthTooHigh returns when the requested output length is too high for the algorithm.
+	ErrLengthTooHigh = errors.New("ksf: requested output length is too high")
+	// ErrInvalidParameter returns when an option sets an invalid parameter.
+	ErrInvalidParameter = errors.New("ksf: invalid parameter")
+	// ErrInvalidEncoding returns when a PHC string is malformed.
+	ErrInvalidEncoding = errors.New("ksf: invalid PHC string")
 )
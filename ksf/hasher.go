@@ -0,0 +1,132 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ksf
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/cymony/cryptomony/utils"
+)
+
+const (
+	// saltLength is the length of the random salts of Hasher.Hash.
+	saltLength = 16
+	// keyLength is the length of the hashes of Hasher.Hash.
+	keyLength = 32
+	// minHashLength is the minimum length of the hashes accepted by Hasher.Verify.
+	minHashLength = 16
+)
+
+// bcryptPrefixes are the prefixes of the bcrypt modular crypt format.
+var bcryptPrefixes = []string{"$2a$", "$2b$", "$2y$"}
+
+// Hasher hashes passwords for storage, and verifies them, with the KSF and the options of its policy.
+// Argon2id and scrypt hashes are PHC strings with a random salt, e.g. $argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>,
+// and bcrypt hashes are in the bcrypt modular crypt format, e.g. $2a$10$<salt and hash>.
+type Hasher struct {
+	k      KSF
+	params string // PHC string of the parameters of k
+}
+
+// NewHasher returns a Hasher whose policy is the KSF, which must be Argon2id, Scrypt or Bcrypt, with the options.
+func NewHasher(id Identifier, options ...Option) (*Hasher, error) {
+	if id != Argon2id && id != Scrypt && id != Bcrypt {
+		return nil, ErrNotSupportedAlgorithm
+	}
+
+	k := id.New()
+	if err := k.SetOptions(options...); err != nil {
+		return nil, err
+	}
+
+	return &Hasher{k: k, params: k.String()}, nil
+}
+
+// Hash returns the hash of the password with a random salt, in the encoding of the KSF of the policy.
+func (h *Hasher) Hash(password []byte) (string, error) {
+	if ph, ok := h.k.(PasswordHasher); ok {
+		hashed, err := ph.HashPassword(password)
+		return string(hashed), err
+	}
+
+	salt := utils.RandomBytes(saltLength)
+
+	key, err := h.k.Harden(password, salt, keyLength)
+	if err != nil {
+		return "", err
+	}
+
+	return Encode(h.k, salt, key)
+}
+
+// Verify reports whether the encoded hash is the hash of the password, with the parameters of the encoding rather than
+// the policy. The hashes are compared in constant time. It returns an error if the encoding is invalid, has invalid
+// parameters, or a hash shorter than 16 bytes.
+func (h *Hasher) Verify(password []byte, encoded string) (bool, error) {
+	if isBcrypt(encoded) {
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), password)
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+
+		return err == nil, err
+	}
+
+	k, salt, hash, err := Parse(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	if _, ok := k.(*identity); ok || len(salt) == 0 || len(hash) < minHashLength {
+		return false, ErrInvalidEncoding
+	}
+
+	key, err := k.Harden(password, salt, len(hash))
+	if err != nil {
+		return false, err
+	}
+
+	return subtle.ConstantTimeCompare(key, hash) == 1, nil
+}
+
+// NeedsRehash reports whether the encoded hash does not follow the policy, in which case the password should be hashed
+// again after its verification. Invalid encodings need a rehash.
+func (h *Hasher) NeedsRehash(encoded string) bool {
+	if isBcrypt(encoded) {
+		bc, ok := h.k.(*bcryptKSF)
+		if !ok {
+			return true
+		}
+
+		cost, err := bcrypt.Cost([]byte(encoded))
+
+		return err != nil || cost != bc.cost
+	}
+
+	if _, ok := h.k.(*bcryptKSF); ok {
+		return true
+	}
+
+	k, salt, hash, err := Parse(encoded)
+	if err != nil {
+		return true
+	}
+
+	return k.String() != h.params || len(salt) < saltLength || len(hash) < keyLength
+}
+
+func isBcrypt(encoded string) bool {
+	for _, prefix := range bcryptPrefixes {
+		if strings.HasPrefix(encoded, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
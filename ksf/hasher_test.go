@@ -0,0 +1,129 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ksf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cymony/cryptomony/internal/test"
+)
+
+func TestHasher(t *testing.T) {
+	password, wrong := []byte("SecretPass"), []byte("WrongPass")
+
+	for _, v := range []struct {
+		id      Identifier
+		options []Option
+		prefix  string
+		stale   []Option // options of a policy the hashes do not follow
+	}{
+		{
+			id:      Argon2id,
+			options: []Option{WithArgon2Memory(1024), WithArgon2Time(1), WithArgon2Threads(1)},
+			prefix:  "$argon2id$v=19$m=1024,t=1,p=1$",
+			stale:   []Option{WithArgon2Memory(2048), WithArgon2Time(1), WithArgon2Threads(1)},
+		},
+		{
+			id:      Scrypt,
+			options: []Option{WithScryptN(1024)},
+			prefix:  "$scrypt$ln=10,r=8,p=1$",
+			stale:   []Option{WithScryptN(2048)},
+		},
+		{
+			id:      Bcrypt,
+			options: []Option{WithBcryptCost(4)},
+			prefix:  "$2a$04$",
+			stale:   []Option{WithBcryptCost(5)},
+		},
+	} {
+		h, err := NewHasher(v.id, v.options...)
+		test.CheckNoErr(t, err, "hasher creation err")
+
+		encoded, err := h.Hash(password)
+		test.CheckNoErr(t, err, "hash err")
+		test.CheckOk(t, strings.HasPrefix(encoded, v.prefix), "wrong encoding "+encoded)
+
+		other, err := h.Hash(password)
+		test.CheckNoErr(t, err, "hash err")
+		test.CheckOk(t, other != encoded, "hashes should be salted")
+
+		ok, err := h.Verify(password, encoded)
+		test.CheckNoErr(t, err, "verify err")
+		test.CheckOk(t, ok, "password should match "+encoded)
+
+		ok, err = h.Verify(wrong, encoded)
+		test.CheckNoErr(t, err, "verify err")
+		test.CheckOk(t, !ok, "wrong password should not match "+encoded)
+
+		test.CheckOk(t, !h.NeedsRehash(encoded), "hash follows the policy "+encoded)
+
+		stale, err := NewHasher(v.id, v.stale...)
+		test.CheckNoErr(t, err, "hasher creation err")
+		test.CheckOk(t, stale.NeedsRehash(encoded), "hash does not follow the policy "+encoded)
+
+		// hashes are verified with their own parameters, whatever the policy
+		ok, err = stale.Verify(password, encoded)
+		test.CheckNoErr(t, err, "verify err")
+		test.CheckOk(t, ok, "password should match with another policy "+encoded)
+
+		for _, id := range []Identifier{Argon2id, Scrypt, Bcrypt} {
+			if id != v.id {
+				migrated, err := NewHasher(id)
+				test.CheckNoErr(t, err, "hasher creation err")
+				test.CheckOk(t, migrated.NeedsRehash(encoded), "hash of another KSF needs a rehash "+encoded)
+
+				ok, err = migrated.Verify(password, encoded)
+				test.CheckNoErr(t, err, "verify err")
+				test.CheckOk(t, ok, "password should match with another KSF "+encoded)
+			}
+		}
+	}
+
+	h, err := NewHasher(Scrypt, WithScryptN(1024))
+	test.CheckNoErr(t, err, "hasher creation err")
+
+	// short salts and hashes need a rehash
+	test.CheckOk(t, h.NeedsRehash("$scrypt$ln=10,r=8,p=1$c2FsdA$aGFzaA"), "short salt and hash need a rehash")
+
+	for _, encoded := range []string{
+		"",
+		"plaintext",
+		"$scrypt$ln=10,r=8,p=1",
+		"$scrypt$ln=10,r=8,p=1$c2FsdA",
+		"$identity$c2FsdA$aGFzaA",
+		"$scrypt$ln=10,r=8,p=1$c2FsdA$Jg",
+		"$scrypt$ln=10,r=0,p=1$c2FsdHNhbHRzYWx0c2FsdA$aGFzaGhhc2hoYXNoaGFzaA",
+		"$argon2id$v=19$m=64,t=0,p=1$c2FsdHNhbHRzYWx0c2FsdA$aGFzaGhhc2hoYXNoaGFzaA",
+		"$argon2id$v=19$m=64,t=1,p=0$c2FsdHNhbHRzYWx0c2FsdA$aGFzaGhhc2hoYXNoaGFzaA",
+		"$argon2id$v=19$m=65536,t=1,p=256$c2FsdHNhbHRzYWx0c2FsdA$aGFzaGhhc2hoYXNoaGFzaA",
+		"$bcrypt-pbkdf$rounds=0,cost=10$c2FsdHNhbHRzYWx0c2FsdA$aGFzaGhhc2hoYXNoaGFzaA",
+	} {
+		_, err = h.Verify(password, encoded)
+		test.CheckIsErr(t, err, "invalid encoding should be rejected: "+encoded)
+		test.CheckOk(t, h.NeedsRehash(encoded), "invalid encoding needs a rehash: "+encoded)
+	}
+
+	// truncated hashes are rejected even if they match
+	k := Scrypt.New()
+	test.CheckNoErr(t, k.SetOptions(WithScryptN(1024)), "options err")
+	salt := []byte("some salt")
+	key, err := k.Harden(password, salt, minHashLength-1)
+	test.CheckNoErr(t, err, "harden err")
+	truncated, err := Encode(k, salt, key)
+	test.CheckNoErr(t, err, "encode err")
+	_, err = h.Verify(password, truncated)
+	test.CheckOk(t, errors.Is(err, ErrInvalidEncoding), "truncated hash should be rejected")
+
+	_, err = h.Verify(password, "$2a$04$invalid")
+	test.CheckIsErr(t, err, "invalid bcrypt hash should be rejected")
+
+	_, err = NewHasher(Identity)
+	test.CheckOk(t, errors.Is(err, ErrNotSupportedAlgorithm), "identity should be rejected")
+
+	_, err = NewHasher(Scrypt, WithBcryptCost(4))
+	test.CheckOk(t, errors.Is(err, ErrNotBcrypt), "invalid option should be rejected")
+}
@@ -4,12 +4,8 @@
 
 package ksf
 
-import (
-	"fmt"
-)
-
 const (
-	identityStr = "Identity"
+	identityStr = "identity"
 )
 
 type identity struct {
@@ -30,6 +26,7 @@ func (i *identity) SetOptions(options ...Option) error {
 	return nil
 }
 
+// String returns the PHC string $identity.
 func (i *identity) String() string {
-	return fmt.Sprintf("%s()", i.str)
+	return phcString(i.str, "")
 }
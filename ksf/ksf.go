@@ -13,12 +13,17 @@ const (
 	Identity Identifier = iota
 	// Argon2id identfier
 	Argon2id
-	// Bcrypt identifier
+	// Bcrypt identifier, of bcrypt_pbkdf
 	Bcrypt
 	// Scrypt identifier
 	Scrypt
 )
 
+// Available reports whether the receiver identifies a supported ksf function.
+func (i Identifier) Available() bool {
+	return i <= Scrypt
+}
+
 // New returns a new KSF instance of receiver identifier
 func (i Identifier) New() KSF {
 	switch i {
@@ -41,6 +46,6 @@ type KSF interface {
 	Harden(password, salt []byte, length int) ([]byte, error)
 	// SetOptions lets change the functions parameters with the new ones
 	SetOptions(options ...Option) error
-	// String returns the string representation with current parameters
+	// String returns the PHC string of the current parameters, e.g. $scrypt$ln=15,r=8,p=1, which Parse reads back
 	String() string
 }
@@ -167,6 +167,8 @@ func TestKSF(t *testing.T) {
 
 	for i, v := range testVectors {
 		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			test.CheckOk(t, v.ksfType.Available() != v.wantPanic, "availability mismatch")
+
 			if v.wantPanic {
 				isPanic := test.CheckPanic(func() {
 					v.ksfType.New()
@@ -196,8 +198,8 @@ func TestKSF(t *testing.T) {
 					if sc.p != 8 {
 						test.Report(t, sc.p, 8, fmt.Sprintf("%s#%d", k.String(), i))
 					}
-					if sc.String() != "Scrypt(8,8,8)" {
-						test.Report(t, sc.String(), "Scrypt(8,8,8)", fmt.Sprintf("%s#%d", k.String(), i))
+					if sc.String() != "$scrypt$ln=3,r=8,p=8" {
+						test.Report(t, sc.String(), "$scrypt$ln=3,r=8,p=8", fmt.Sprintf("%s#%d", k.String(), i))
 					}
 				}
 
@@ -206,8 +208,8 @@ func TestKSF(t *testing.T) {
 					if bc.cost != 5 {
 						test.Report(t, bc.cost, 5, fmt.Sprintf("%s#%d", k.String(), i))
 					}
-					if bc.String() != "Bcrypt(5)" {
-						test.Report(t, bc.String(), "Bcrypt(5)", fmt.Sprintf("%s#%d", k.String(), i))
+					if bc.String() != "$bcrypt-pbkdf$rounds=16,cost=5" {
+						test.Report(t, bc.String(), "$bcrypt-pbkdf$rounds=16,cost=5", fmt.Sprintf("%s#%d", k.String(), i))
 					}
 				}
 
@@ -222,15 +224,15 @@ func TestKSF(t *testing.T) {
 					if ar.threads != 3 {
 						test.Report(t, ar.threads, 3, fmt.Sprintf("%s#%d", k.String(), i))
 					}
-					if ar.String() != fmt.Sprintf("%s(%d,%d,%d)", ar.str, 3, 3*1024, 3) {
-						test.Report(t, ar.String(), fmt.Sprintf("%s(%d,%d,%d)", ar.str, 3, 3*1024, 3), fmt.Sprintf("%s#%d", k.String(), i))
+					if ar.String() != "$argon2id$v=19$m=3072,t=3,p=3" {
+						test.Report(t, ar.String(), "$argon2id$v=19$m=3072,t=3,p=3", fmt.Sprintf("%s#%d", k.String(), i))
 					}
 				}
 
 				id, ok := k.(*identity)
 				if ok {
-					if id.String() != fmt.Sprintf("%s()", id.str) {
-						test.Report(t, id.String(), fmt.Sprintf("%s()", id.str), fmt.Sprintf("%s#%d", k.String(), i))
+					if id.String() != "$identity" {
+						test.Report(t, id.String(), "$identity", fmt.Sprintf("%s#%d", k.String(), i))
 					}
 				}
 			}
@@ -4,10 +4,26 @@
 
 package ksf
 
+import (
+	"math"
+	"math/bits"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// maxArgon2Memory is the maximum Argon2 memory in KiB, of 4 GiB.
+	maxArgon2Memory = 4 * 1024 * 1024
+	// maxArgon2Threads is the maximum Argon2 degree of parallelism, which is a byte.
+	maxArgon2Threads = math.MaxUint8
+	// maxScryptRP is the bound of the scrypt r * p product.
+	maxScryptRP = 1 << 30
+)
+
 // Option type indicates option functions
 type Option func(KSF) error
 
-// WithArgon2Time sets argon algorithm's time parameter.
+// WithArgon2Time sets argon algorithm's time parameter, which must be positive.
 // This option must used with only argon instance
 func WithArgon2Time(time int) Option {
 	return func(k KSF) error {
@@ -16,14 +32,18 @@ func WithArgon2Time(time int) Option {
 			return ErrNotArgon2
 		}
 
+		if time < 1 || uint64(time) > math.MaxUint32 {
+			return ErrInvalidParameter
+		}
+
 		argon.time = time
 
 		return nil
 	}
 }
 
-// WithArgon2Memory sets argon algorithm's memory parameter.
-// This option must used with only argon instance
+// WithArgon2Memory sets argon algorithm's memory parameter in KiB, which must be at most 4 GiB and at least 8 KiB per
+// thread. This option must used with only argon instance
 func WithArgon2Memory(memory int) Option {
 	return func(k KSF) error {
 		argon, ok := k.(*argon2KSF)
@@ -31,13 +51,17 @@ func WithArgon2Memory(memory int) Option {
 			return ErrNotArgon2
 		}
 
+		if memory < 1 || memory > maxArgon2Memory {
+			return ErrInvalidParameter
+		}
+
 		argon.memory = memory
 
 		return nil
 	}
 }
 
-// WithArgon2Threads sets argon algorithm's threads parameter.
+// WithArgon2Threads sets argon algorithm's threads parameter, which must be between 1 and 255.
 // This option must used with only argon instance
 func WithArgon2Threads(threads int) Option {
 	return func(k KSF) error {
@@ -46,14 +70,37 @@ func WithArgon2Threads(threads int) Option {
 			return ErrNotArgon2
 		}
 
+		if threads < 1 || threads > maxArgon2Threads {
+			return ErrInvalidParameter
+		}
+
 		argon.threads = threads
 
 		return nil
 	}
 }
 
-// WithBcryptCost sets bcrypt algorithm's cost parameter.
+// WithBcryptRounds sets bcrypt_pbkdf algorithm's rounds parameter, used by Harden, which must be positive.
 // This option must used with only bcrypt instance
+func WithBcryptRounds(rounds int) Option {
+	return func(k KSF) error {
+		bc, ok := k.(*bcryptKSF)
+		if !ok {
+			return ErrNotBcrypt
+		}
+
+		if rounds < 1 || uint64(rounds) > math.MaxUint32 {
+			return ErrInvalidParameter
+		}
+
+		bc.rounds = rounds
+
+		return nil
+	}
+}
+
+// WithBcryptCost sets bcrypt algorithm's cost parameter, used by the PasswordHasher methods, which must be between 4
+// and 31. This option must used with only bcrypt instance
 func WithBcryptCost(cost int) Option {
 	return func(k KSF) error {
 		bc, ok := k.(*bcryptKSF)
@@ -61,13 +108,17 @@ func WithBcryptCost(cost int) Option {
 			return ErrNotBcrypt
 		}
 
+		if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+			return ErrInvalidParameter
+		}
+
 		bc.cost = cost
 
 		return nil
 	}
 }
 
-// WithScryptN sets scrypt algorithm's n parameter.
+// WithScryptN sets scrypt algorithm's n parameter, which must be a power of two greater than 1.
 // This option must used with only scrypt instance
 func WithScryptN(n int) Option {
 	return func(k KSF) error {
@@ -76,13 +127,26 @@ func WithScryptN(n int) Option {
 			return ErrNotScrypt
 		}
 
+		if n <= 1 || n&(n-1) != 0 {
+			return ErrInvalidParameter
+		}
+
 		sc.n = n
 
 		return nil
 	}
 }
 
-// WithScryptR sets scrypt algorithm's r parameter.
+// withScryptLogN sets scrypt algorithm's n parameter to 2^ln, as encoded in PHC strings.
+func withScryptLogN(ln int) Option {
+	if ln >= bits.UintSize-1 {
+		return func(KSF) error { return ErrInvalidParameter }
+	}
+
+	return WithScryptN(1 << ln)
+}
+
+// WithScryptR sets scrypt algorithm's r parameter, which must be positive, with r * p < 2^30.
 // This option must used with only scrypt instance
 func WithScryptR(r int) Option {
 	return func(k KSF) error {
@@ -91,13 +155,17 @@ func WithScryptR(r int) Option {
 			return ErrNotScrypt
 		}
 
+		if r < 1 || r >= maxScryptRP {
+			return ErrInvalidParameter
+		}
+
 		sc.r = r
 
 		return nil
 	}
 }
 
-// WithScryptP sets scrypt algorithm's p parameter.
+// WithScryptP sets scrypt algorithm's p parameter, which must be positive, with r * p < 2^30.
 // This option must used with only scrypt instance
 func WithScryptP(p int) Option {
 	return func(k KSF) error {
@@ -106,6 +174,10 @@ func WithScryptP(p int) Option {
 			return ErrNotScrypt
 		}
 
+		if p < 1 || p >= maxScryptRP {
+			return ErrInvalidParameter
+		}
+
 		sc.p = p
 
 		return nil
@@ -0,0 +1,177 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ksf
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The PHC string format: $<id>[$v=<version>][$<param>=<value>(,<param>=<value>)*][$<salt>[$<hash>]]
+// See https://github.com/P-H-C/phc-string-format/blob/master/phc-sf-spec.md
+
+// phcEncoding is the base64 encoding of salts and hashes, without padding.
+var phcEncoding = base64.RawStdEncoding
+
+// phcParam is a decimal parameter of a PHC string.
+type phcParam struct {
+	name  string
+	value int
+}
+
+// phcAlgorithm describes the PHC string of a KSF.
+type phcAlgorithm struct {
+	id      Identifier
+	version string                            // version segment, if any
+	options map[string]func(value int) Option // options of the parameters, by name
+}
+
+var phcAlgorithms = map[string]phcAlgorithm{
+	identityStr: {id: Identity},
+	argon2idStr: {
+		id:      Argon2id,
+		version: argon2idVersion,
+		options: map[string]func(int) Option{"m": WithArgon2Memory, "t": WithArgon2Time, "p": WithArgon2Threads},
+	},
+	scryptStr: {
+		id:      Scrypt,
+		options: map[string]func(int) Option{"ln": withScryptLogN, "r": WithScryptR, "p": WithScryptP},
+	},
+	bcryptStr: {
+		id:      Bcrypt,
+		options: map[string]func(int) Option{"rounds": WithBcryptRounds, "cost": WithBcryptCost},
+	},
+}
+
+// phcString returns the PHC string of the parameters.
+func phcString(id, version string, params ...phcParam) string {
+	var b strings.Builder
+
+	b.WriteString("$" + id)
+
+	if version != "" {
+		b.WriteString("$" + version)
+	}
+
+	for i, p := range params {
+		if i == 0 {
+			b.WriteString("$")
+		} else {
+			b.WriteString(",")
+		}
+
+		b.WriteString(p.name + "=" + strconv.Itoa(p.value))
+	}
+
+	return b.String()
+}
+
+// Encode returns the PHC string of the KSF instance with its parameters, followed by the salt and the hash if they
+// are not empty, e.g. $argon2id$v=19$m=65536,t=3,p=4$c2FsdA$aGFzaA. A hash requires a salt.
+func Encode(k KSF, salt, hash []byte) (string, error) {
+	switch k.(type) {
+	case *identity, *argon2KSF, *scryptKSF, *bcryptKSF:
+	default:
+		return "", ErrNotSupportedAlgorithm
+	}
+
+	if len(salt) == 0 && len(hash) != 0 {
+		return "", ErrInvalidEncoding
+	}
+
+	encoded := k.String()
+
+	if len(salt) != 0 {
+		encoded += "$" + phcEncoding.EncodeToString(salt)
+	}
+
+	if len(hash) != 0 {
+		encoded += "$" + phcEncoding.EncodeToString(hash)
+	}
+
+	return encoded, nil
+}
+
+// Parse reconstructs the KSF instance and its options from the PHC string of Encode, and returns it with the salt and
+// the hash, which are nil if absent. Parameters missing from the string keep their default values.
+func Parse(encoded string) (k KSF, salt, hash []byte, err error) {
+	fields := strings.Split(encoded, "$")
+	if len(fields) < 2 || fields[0] != "" {
+		return nil, nil, nil, ErrInvalidEncoding
+	}
+
+	alg, ok := phcAlgorithms[fields[1]]
+	if !ok {
+		return nil, nil, nil, ErrNotSupportedAlgorithm
+	}
+
+	fields = fields[2:]
+
+	if alg.version != "" {
+		if len(fields) == 0 || fields[0] != alg.version {
+			return nil, nil, nil, ErrInvalidEncoding
+		}
+
+		fields = fields[1:]
+	}
+
+	var options []Option
+
+	// salts are base64 encoded, without the '=' of parameters
+	if len(fields) != 0 && strings.Contains(fields[0], "=") {
+		if options, err = parsePHCParams(fields[0], alg.options); err != nil {
+			return nil, nil, nil, err
+		}
+
+		fields = fields[1:]
+	}
+
+	if len(fields) > 2 { //nolint:gomnd //salt and hash
+		return nil, nil, nil, ErrInvalidEncoding
+	}
+
+	k = alg.id.New()
+	if err = k.SetOptions(options...); err != nil {
+		return nil, nil, nil, err
+	}
+
+	decoded := make([][]byte, 2) //nolint:gomnd //salt and hash
+
+	for i, f := range fields {
+		if decoded[i], err = phcEncoding.DecodeString(f); err != nil || len(decoded[i]) == 0 {
+			return nil, nil, nil, ErrInvalidEncoding
+		}
+	}
+
+	return k, decoded[0], decoded[1], nil
+}
+
+// parsePHCParams returns the options of the comma separated parameters, which must have an option and appear once.
+func parsePHCParams(params string, options map[string]func(int) Option) ([]Option, error) {
+	seen := make(map[string]bool)
+	parsed := make([]Option, 0, len(options))
+
+	for _, param := range strings.Split(params, ",") {
+		name, value, ok := strings.Cut(param, "=")
+
+		option, known := options[name]
+		if !ok || !known || seen[name] {
+			return nil, fmt.Errorf("%w: parameter %q", ErrInvalidEncoding, param)
+		}
+
+		// decimal values have no sign nor leading zeros
+		v, err := strconv.Atoi(value)
+		if err != nil || v < 0 || strconv.Itoa(v) != value {
+			return nil, fmt.Errorf("%w: parameter %q", ErrInvalidEncoding, param)
+		}
+
+		seen[name] = true
+		parsed = append(parsed, option(v))
+	}
+
+	return parsed, nil
+}
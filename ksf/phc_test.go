@@ -0,0 +1,153 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ksf
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/cymony/cryptomony/internal/test"
+)
+
+func TestPHC(t *testing.T) {
+	salt, hash := []byte("some salt"), []byte("some hash")
+
+	for _, v := range []struct {
+		id      Identifier
+		options []Option
+		params  string
+		encoded string
+	}{
+		{
+			id:      Identity,
+			params:  "$identity",
+			encoded: "$identity$c29tZSBzYWx0$c29tZSBoYXNo",
+		},
+		{
+			id:      Argon2id,
+			params:  "$argon2id$v=19$m=65536,t=3,p=4",
+			encoded: "$argon2id$v=19$m=65536,t=3,p=4$c29tZSBzYWx0$c29tZSBoYXNo",
+		},
+		{
+			id:      Argon2id,
+			options: []Option{WithArgon2Memory(19456), WithArgon2Time(2), WithArgon2Threads(1)},
+			params:  "$argon2id$v=19$m=19456,t=2,p=1",
+			encoded: "$argon2id$v=19$m=19456,t=2,p=1$c29tZSBzYWx0$c29tZSBoYXNo",
+		},
+		{
+			id:      Scrypt,
+			params:  "$scrypt$ln=15,r=8,p=1",
+			encoded: "$scrypt$ln=15,r=8,p=1$c29tZSBzYWx0$c29tZSBoYXNo",
+		},
+		{
+			id:      Scrypt,
+			options: []Option{WithScryptN(1 << 17), WithScryptP(2)},
+			params:  "$scrypt$ln=17,r=8,p=2",
+			encoded: "$scrypt$ln=17,r=8,p=2$c29tZSBzYWx0$c29tZSBoYXNo",
+		},
+		{
+			id:      Bcrypt,
+			options: []Option{WithBcryptRounds(32)},
+			params:  "$bcrypt-pbkdf$rounds=32,cost=10",
+			encoded: "$bcrypt-pbkdf$rounds=32,cost=10$c29tZSBzYWx0$c29tZSBoYXNo",
+		},
+	} {
+		k := v.id.New()
+		test.CheckNoErr(t, k.SetOptions(v.options...), "options err")
+		test.CheckOk(t, k.String() == v.params, "wrong parameters string "+k.String())
+
+		params, err := Encode(k, nil, nil)
+		test.CheckNoErr(t, err, "encode err")
+		test.CheckOk(t, params == v.params, "wrong parameters encoding "+params)
+
+		encoded, err := Encode(k, salt, hash)
+		test.CheckNoErr(t, err, "encode err")
+		test.CheckOk(t, encoded == v.encoded, "wrong encoding "+encoded)
+
+		parsed, gotSalt, gotHash, err := Parse(encoded)
+		test.CheckNoErr(t, err, "parse err")
+		test.CheckOk(t, parsed.String() == v.params, "parsed parameters mismatch "+parsed.String())
+		test.CheckOk(t, bytes.Equal(gotSalt, salt) && bytes.Equal(gotHash, hash), "parsed salt or hash mismatch")
+
+		parsed, gotSalt, gotHash, err = Parse(v.params)
+		test.CheckNoErr(t, err, "parse err")
+		test.CheckOk(t, parsed.String() == v.params, "parsed parameters mismatch "+parsed.String())
+		test.CheckOk(t, gotSalt == nil && gotHash == nil, "salt and hash should be absent")
+
+		// the parsed instance hardens as the original one
+		want, err := k.Harden([]byte("password"), salt, 32)
+		test.CheckNoErr(t, err, "harden err")
+		got, err := parsed.Harden([]byte("password"), salt, 32)
+		test.CheckNoErr(t, err, "harden err")
+		test.CheckOk(t, bytes.Equal(got, want), "parsed instance should harden the same")
+	}
+
+	// salt only, and missing parameters keep their defaults
+	k, gotSalt, gotHash, err := Parse("$scrypt$r=4$c29tZSBzYWx0")
+	test.CheckNoErr(t, err, "parse err")
+	test.CheckOk(t, k.String() == "$scrypt$ln=15,r=4,p=1", "wrong parsed parameters "+k.String())
+	test.CheckOk(t, bytes.Equal(gotSalt, salt) && gotHash == nil, "wrong salt or hash")
+
+	_, err = Encode(Scrypt.New(), nil, hash)
+	test.CheckOk(t, errors.Is(err, ErrInvalidEncoding), "hash without salt should be rejected")
+
+	_, err = Encode(nil, nil, nil)
+	test.CheckOk(t, errors.Is(err, ErrNotSupportedAlgorithm), "nil KSF should be rejected")
+
+	for _, encoded := range []string{
+		"",
+		"scrypt$ln=15,r=8,p=1",
+		"$argon2id$m=65536,t=3,p=4",
+		"$argon2id$v=16$m=65536,t=3,p=4",
+		"$scrypt$ln=15,r=8,p=1,x=2",
+		"$scrypt$ln=15,r=8,r=8",
+		"$scrypt$ln=015",
+		"$scrypt$ln=-1",
+		"$scrypt$ln=+1",
+		"$scrypt$ln=",
+		"$scrypt$ln=15$c29tZSBzYWx0$c29tZSBoYXNo$c29tZSBoYXNo",
+		"$scrypt$ln=15$c29tZSBzYWx0=",
+		"$scrypt$ln=15$$c29tZSBoYXNo",
+	} {
+		_, _, _, err = Parse(encoded)
+		test.CheckOk(t, errors.Is(err, ErrInvalidEncoding), "malformed string should be rejected: "+encoded)
+	}
+
+	_, _, _, err = Parse("$pbkdf2-sha256$i=1000")
+	test.CheckOk(t, errors.Is(err, ErrNotSupportedAlgorithm), "unknown algorithm should be rejected")
+
+	for _, encoded := range []string{
+		"$scrypt$ln=0",
+		"$scrypt$ln=64",
+		"$scrypt$ln=1000000",
+		"$scrypt$ln=15,r=0,p=1",
+		"$scrypt$ln=15,r=8,p=0",
+		"$scrypt$ln=15,r=32768,p=32768",
+		"$argon2id$v=19$m=64,t=0,p=1",
+		"$argon2id$v=19$m=64,t=1,p=0",
+		"$argon2id$v=19$m=65536,t=1,p=256",
+		"$argon2id$v=19$m=31,t=1,p=4",
+		"$argon2id$v=19$m=0,t=1,p=1",
+		"$argon2id$v=19$m=8388608,t=1,p=1",
+		"$argon2id$v=19$m=4294967296,t=1,p=1",
+		"$argon2id$v=19$m=64,t=4294967296,p=1",
+		"$bcrypt-pbkdf$rounds=0",
+		"$bcrypt-pbkdf$cost=3",
+		"$bcrypt-pbkdf$cost=32",
+	} {
+		_, _, _, err = Parse(encoded)
+		test.CheckOk(t, errors.Is(err, ErrInvalidParameter), "invalid parameters should be rejected: "+encoded)
+	}
+
+	// the parameters are left unchanged if they are invalid together
+	k = Argon2id.New()
+	err = k.SetOptions(WithArgon2Memory(16), WithArgon2Threads(4))
+	test.CheckOk(t, errors.Is(err, ErrInvalidParameter), "memory below 8 KiB per thread should be rejected")
+	test.CheckOk(t, k.String() == "$argon2id$v=19$m=65536,t=3,p=4", "parameters should be unchanged "+k.String())
+
+	err = Scrypt.New().SetOptions(WithScryptN(1000))
+	test.CheckOk(t, errors.Is(err, ErrInvalidParameter), "n should be a power of two")
+}
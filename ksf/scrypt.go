@@ -5,13 +5,13 @@
 package ksf
 
 import (
-	"fmt"
+	"math/bits"
 
 	"golang.org/x/crypto/scrypt"
 )
 
 const (
-	scryptStr      = "Scrypt"
+	scryptStr      = "scrypt"
 	defaultScryptn = 32768
 	defaultScryptr = 8
 	defaultScryptp = 1
@@ -35,16 +35,27 @@ func (s *scryptKSF) Harden(password, salt []byte, length int) ([]byte, error) {
 	return scrypt.Key(password, salt, s.n, s.r, s.p, length)
 }
 
+// SetOptions applies the options, and leaves the parameters unchanged if they are invalid together, since scrypt
+// requires r * p < 2^30.
 func (s *scryptKSF) SetOptions(options ...Option) error {
+	c := *s
+
 	for _, option := range options {
-		if err := option(s); err != nil {
+		if err := option(&c); err != nil {
 			return err
 		}
 	}
 
+	if uint64(c.r)*uint64(c.p) >= maxScryptRP {
+		return ErrInvalidParameter
+	}
+
+	*s = c
+
 	return nil
 }
 
+// String returns the PHC string of the parameters, e.g. $scrypt$ln=15,r=8,p=1, where n = 2^ln.
 func (s *scryptKSF) String() string {
-	return fmt.Sprintf("%s(%d,%d,%d)", scryptStr, s.n, s.r, s.p)
+	return phcString(s.str, "", phcParam{"ln", bits.Len(uint(s.n)) - 1}, phcParam{"r", s.r}, phcParam{"p", s.p})
 }
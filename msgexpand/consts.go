@@ -12,4 +12,8 @@ var (
 
 	// See https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#name-domain-separation-requireme
 	recommendedDSTLen = 16
+
+	// readerDSTSuffix is appended to the DST of the streams of ExpandReader, to separate them from the expansions of
+	// the standard.
+	readerDSTSuffix = []byte("-EXPAND-READER")
 )
@@ -5,9 +5,35 @@
 // Package msgexpand generates arbitrary bytes from an XOF or Hash function.
 package msgexpand
 
+import "io"
+
 // MessageExpand is an interface that identify XMD and XOF Expand.
 type MessageExpand interface {
 	// Expand generates a pseudo-random byte string of a determined length by
 	// expanding an input string.
 	Expand(in, dst []byte, lenInBytes int) (uniform []byte, err error)
 }
+
+// IntoExpander is a MessageExpand that can write its output to a caller-provided buffer. The expanders of this
+// package implement it.
+type IntoExpander interface {
+	MessageExpand
+
+	// ExpandInto is Expand with len(uniform) as the length, writing the pseudo-random bytes to uniform instead of
+	// allocating them.
+	ExpandInto(uniform, in, dst []byte) error
+}
+
+// ReaderExpander is a MessageExpand that can stream its output. The expanders of this package implement it.
+//
+// The streams are specific to this library and do not interoperate: RFC 9380 defines no streaming expansion, and a
+// stream is not a prefix of any expand_message_xmd or expand_message_xof output.
+type ReaderExpander interface {
+	MessageExpand
+
+	// ExpandReader returns a stream of pseudo-random bytes expanding the input string, for when their number is not
+	// known in advance. The stream is the expansion with a length of 0 in its input, which denotes an unbounded length,
+	// and with "-EXPAND-READER" appended to the DST, so that it is separated from the standard expansions. The XOF
+	// stream is unbounded, and the XMD stream returns io.EOF after 255 blocks of the hash function.
+	ExpandReader(in, dst []byte) (io.Reader, error)
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -79,6 +80,97 @@ func testExpander(t *testing.T, vs *tstVectorSuite) {
 		if !bytes.Equal(got, want) {
 			test.Report(t, got, want, fmt.Sprintf("%s/%s", vs.Name, vs.Hash))
 		}
+
+		into := make([]byte, lenBytes)
+		test.CheckNoErr(t, e.(msgexpand.IntoExpander).ExpandInto(into, []byte(v.Msg), []byte(vs.DST)), "expand into err")
+
+		if !bytes.Equal(into, want) {
+			test.Report(t, into, want, fmt.Sprintf("%s/%s ExpandInto", vs.Name, vs.Hash))
+		}
+	}
+}
+
+func TestExpandReader(t *testing.T) {
+	msg, dst := []byte("msg"), []byte("QUUX-V01-CS02-with-expander-reader")
+
+	for _, tc := range []struct {
+		me  msgexpand.MessageExpand
+		max int
+	}{
+		{msgexpand.NewMessageExpandXMD(hash.SHA256), 255 * 32},
+		{msgexpand.NewMessageExpandXMD(hash.SHA512), 255 * 64},
+		{msgexpand.NewMessageExpandXOF(xof.SHAKE128, 128), -1},
+		{msgexpand.NewMessageExpandXOF(xof.SHAKE256, 256), -1},
+	} {
+		e, ok := tc.me.(msgexpand.ReaderExpander)
+		test.CheckOk(t, ok, "the expander should implement ReaderExpander")
+
+		t.Run(tc.me.(fmt.Stringer).String(), func(t *testing.T) {
+			r, err := e.ExpandReader(msg, dst)
+			test.CheckNoErr(t, err, "expand reader err")
+
+			// the stream does not depend on how it is read
+			whole := make([]byte, 1000)
+			_, err = io.ReadFull(r, whole)
+			test.CheckNoErr(t, err, "read err")
+
+			r, err = e.ExpandReader(msg, dst)
+			test.CheckNoErr(t, err, "expand reader err")
+
+			pieces := make([]byte, len(whole))
+			for i := 0; i < len(pieces); i += 7 {
+				_, err = io.ReadFull(r, pieces[i:minInt(i+7, len(pieces))])
+				test.CheckNoErr(t, err, "read err")
+			}
+
+			if !bytes.Equal(whole, pieces) {
+				test.Report(t, pieces, whole)
+			}
+
+			// it is separated from the fixed-length expansions
+			fixed, err := e.Expand(msg, dst, 64)
+			test.CheckNoErr(t, err, "expand err")
+			test.CheckOk(t, !bytes.Equal(fixed, whole[:64]), "the stream should differ from Expand")
+
+			// the XMD stream is bounded
+			if tc.max > 0 {
+				r, err = e.ExpandReader(msg, dst)
+				test.CheckNoErr(t, err, "expand reader err")
+
+				all, err := io.ReadAll(r)
+				test.CheckNoErr(t, err, "read err")
+				test.CheckOk(t, len(all) == tc.max, "wrong XMD stream length")
+				test.CheckOk(t, bytes.Equal(all[:len(whole)], whole), "XMD stream mismatch")
+			}
+
+			_, err = e.ExpandReader(msg, []byte("short"))
+			test.CheckIsErr(t, err, "short DST should be rejected")
+
+			test.CheckIsErr(t, tc.me.(msgexpand.IntoExpander).ExpandInto(make([]byte, 32), msg, nil),
+				"empty DST should be rejected")
+		})
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func TestExpanderString(t *testing.T) {
+	for want, e := range map[string]msgexpand.MessageExpand{
+		"XMD:SHA-256":   msgexpand.NewMessageExpandXMD(hash.SHA256),
+		"XMD:SHA-512":   msgexpand.NewMessageExpandXMD(hash.SHA512),
+		"XOF:SHAKE-128": msgexpand.NewMessageExpandXOF(xof.SHAKE128, 128),
+		"XOF:SHAKE-256": msgexpand.NewMessageExpandXOF(xof.SHAKE256, 128),
+		"XOF:BLAKE2XB":  msgexpand.NewMessageExpandXOF(xof.BLAKE2XB, 128),
+	} {
+		if got := e.(fmt.Stringer).String(); got != want {
+			test.Report(t, got, want)
+		}
 	}
 }
 
@@ -5,32 +5,70 @@
 package msgexpand
 
 import (
+	"io"
 	"math"
-	"math/big"
 
 	"github.com/cymony/cryptomony/hash"
 	"github.com/cymony/cryptomony/utils"
 )
 
+// maxXMDBlocks is the maximum number of blocks of an expand_message_xmd output.
+const maxXMDBlocks = 255
+
 type messageExpandXMD struct {
 	h hash.Hashing
 }
 
 // NewMessageExpandXMD returns a expander interface based on a Merkle-Damgård hash functions.
+// It implements IntoExpander, ReaderExpander and fmt.Stringer, whose String returns the expander identifier used in
+// hash-to-curve suite identifiers, e.g. XMD:SHA-256.
 func NewMessageExpandXMD(h hash.Hashing) MessageExpand {
 	return &messageExpandXMD{h: h}
 }
 
 func (me *messageExpandXMD) Expand(msg, dst []byte, lenInBytes int) ([]byte, error) {
+	if lenInBytes < 0 {
+		return nil, errLengthTooHigh
+	}
+
+	uniform := make([]byte, lenInBytes)
+	if err := me.ExpandInto(uniform, msg, dst); err != nil {
+		return nil, err
+	}
+
+	return uniform, nil
+}
+
+func (me *messageExpandXMD) ExpandInto(uniform, msg, dst []byte) error {
+	if err := checkDST(dst); err != nil {
+		return err
+	}
+
+	r, err := me.expandXMD(msg, dst, len(uniform))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.ReadFull(r, uniform)
+
+	return err
+}
+
+func (me *messageExpandXMD) ExpandReader(msg, dst []byte) (io.Reader, error) {
 	if err := checkDST(dst); err != nil {
 		return nil, err
 	}
 
-	return me.expandXMD(msg, dst, lenInBytes)
+	return me.expandXMD(msg, utils.Concat(dst, readerDSTSuffix), 0)
+}
+
+func (me *messageExpandXMD) String() string {
+	return "XMD:" + me.h.New().String()
 }
 
 // See https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#name-expand_message_xmd
-func (me *messageExpandXMD) expandXMD(msg, dst []byte, lenInBytes int) ([]byte, error) { //nolint:gocyclo //complexity 18 acceptable
+// expandXMD returns a reader of the uniform bytes, with lenInBytes bytes, or up to 255 blocks if lenInBytes is 0.
+func (me *messageExpandXMD) expandXMD(msg, dst []byte, lenInBytes int) (*xmdReader, error) {
 	// fix DST length. See https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#name-using-dsts-longer-than-255-
 	newDst, err := processDSTXMD(me.h, dst)
 	if err != nil {
@@ -43,119 +81,116 @@ func (me *messageExpandXMD) expandXMD(msg, dst []byte, lenInBytes int) ([]byte,
 	h := me.h.New()
 
 	// b_in_bytes, b / 8 for b the output size of H in bits.
-	bInBytes := uint(h.OutputSize())
+	bInBytes := h.OutputSize()
 	// s_in_bytes, the input block size of H, measured in bytes
-	sInBytes := uint(h.BlockSize())
+	sInBytes := h.BlockSize()
 
 	//nolint:gocritic //not a commented code
 	// ell = ceil(len_in_bytes / b_in_bytes)
-	ell := math.Ceil(float64(lenInBytes) / float64(bInBytes))
+	ell := (lenInBytes + bInBytes - 1) / bInBytes
+	if lenInBytes == 0 {
+		ell = maxXMDBlocks
+	}
 
 	// ABORT if ell > 255 or len_in_bytes > 65535 or len(DST) > 255
-	if uint(ell) > 255 || lenInBytes > math.MaxUint16 || len(dst) > 255 {
+	if ell > maxXMDBlocks || lenInBytes > math.MaxUint16 || len(dst) > maxDstLen {
 		return nil, errLengthTooHigh
 	}
 
-	//nolint:gocritic //not a commented code
-	// I2OSP(len(DST), 1)
-	dstPrimeLenDstI2osp1, err := utils.I2osp(big.NewInt(int64(len(dst))), 1)
-	if err != nil {
-		return nil, err
-	}
-
 	//nolint:gocritic //not a commented code
 	// DST_prime = DST || I2OSP(len(DST), 1)
-	dstPrime := append(dst, dstPrimeLenDstI2osp1...)
+	dstPrime := make([]byte, 0, len(dst)+1)
+	dstPrime = append(append(dstPrime, dst...), byte(len(dst)))
 
 	//nolint:gocritic //not a commented code
 	// Z_pad = I2OSP(0, s_in_bytes)
-	zPad, err := utils.I2osp(big.NewInt(0), int(sInBytes))
-	if err != nil {
-		return nil, err
-	}
+	zPad := make([]byte, sInBytes)
 
 	//nolint:gocritic //not a commented code
 	// l_i_b_str = I2OSP(len_in_bytes, 2)
-	libStr, err := utils.I2osp(big.NewInt(int64(lenInBytes)), 2)
-	if err != nil {
-		return nil, err
-	}
-
-	// I2OSP(0, 1)
-	i2osp01, err := utils.I2osp(big.NewInt(0), 1)
-	if err != nil {
-		return nil, err
-	}
+	libStr := []byte{byte(lenInBytes >> 8), byte(lenInBytes)}
 
 	//nolint:gocritic //not a commented code
 	// msg_prime = Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prime
 	// b_0 = H(msg_prime)
-	h.Reset()
-
-	err = h.MustWriteAll(zPad, msg, libStr, i2osp01, dstPrime)
-	if err != nil {
+	if err := h.MustWriteAll(zPad, msg, libStr, []byte{0}, dstPrime); err != nil {
 		return nil, err
 	}
 
-	b0 := make([]byte, h.OutputSize())
-
-	err = h.MustReadFull(b0)
-	if err != nil {
-		return nil, err
+	r := &xmdReader{
+		h:        h,
+		b0:       h.Sum(nil),
+		bi:       make([]byte, 0, bInBytes),
+		dstPrime: dstPrime,
+		next:     1,
+		ell:      ell,
 	}
 
-	// I2OSP(1, 1)
-	i2osp11, err := utils.I2osp(big.NewInt(1), 1)
-	if err != nil {
-		return nil, err
-	}
+	return r, nil
+}
 
-	//nolint:gocritic //not a commented code
-	// b_1 = H(b_0 || I2OSP(1, 1) || DST_prime)
-	h.Reset()
+// xmdReader reads the uniform bytes b_1 || ... || b_ell of expand_message_xmd, computing the blocks as they are read.
+type xmdReader struct {
+	h        hash.Hash
+	b0, bi   []byte
+	dstPrime []byte
+	next     int // index of the next block
+	ell      int // number of blocks
+	off      int // number of bytes of b_(next - 1) read
+}
 
-	err = h.MustWriteAll(b0, i2osp11, dstPrime)
-	if err != nil {
-		return nil, err
-	}
+func (r *xmdReader) Read(p []byte) (int, error) {
+	n := 0
 
-	b1 := make([]byte, h.OutputSize())
+	for len(p) > 0 {
+		if r.off == len(r.bi) {
+			if r.next > r.ell {
+				if n == 0 {
+					return 0, io.EOF
+				}
 
-	err = h.MustReadFull(b1)
-	if err != nil {
-		return nil, err
-	}
+				break
+			}
 
-	// for i in (2, ..., ell):
-	// 	b_i = H(strxor(b_0, b_(i - 1)) || I2OSP(i, 1) || DST_prime)
-	uniformBytes := make([]byte, 0, lenInBytes)
-	uniformBytes = append(uniformBytes, b1...)
-	bi := make([]byte, len(b1))
-	copy(bi, b1)
-
-	for i := uint(2); i <= uint(ell); i++ {
-		for i := range b0 {
-			bi[i] ^= b0[i]
+			if err := r.nextBlock(); err != nil {
+				return n, err
+			}
 		}
 
-		i2ospi1, err := utils.I2osp(big.NewInt(int64(i)), 1)
-		if err != nil {
-			return nil, err
-		}
+		k := copy(p, r.bi[r.off:])
+		r.off += k
+		n += k
+		p = p[k:]
+	}
 
-		h.Reset()
+	return n, nil
+}
 
-		err = h.MustWriteAll(bi, i2ospi1, dstPrime)
-		if err != nil {
-			return nil, err
+// nextBlock computes b_next.
+func (r *xmdReader) nextBlock() error {
+	//nolint:gocritic //not a commented code
+	// b_1 = H(b_0 || I2OSP(1, 1) || DST_prime)
+	// for i in (2, ..., ell):
+	// 	b_i = H(strxor(b_0, b_(i - 1)) || I2OSP(i, 1) || DST_prime)
+	if r.next == 1 {
+		r.bi = append(r.bi[:0], r.b0...)
+	} else {
+		for i := range r.b0 {
+			r.bi[i] ^= r.b0[i]
 		}
+	}
 
-		bi = h.Sum(nil)
+	r.h.Reset()
 
-		uniformBytes = append(uniformBytes, bi...)
+	if err := r.h.MustWriteAll(r.bi, []byte{byte(r.next)}, r.dstPrime); err != nil {
+		return err
 	}
 
-	return uniformBytes[0:lenInBytes], nil
+	r.bi = r.h.Sum(r.bi[:0])
+	r.next++
+	r.off = 0
+
+	return nil
 }
 
 // It implements https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html Section 5.3.3.
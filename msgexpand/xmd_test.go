@@ -5,7 +5,9 @@
 package msgexpand
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"testing"
 
 	"github.com/cymony/cryptomony/hash"
@@ -80,3 +82,22 @@ func TestXMDExpandErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestXMDExpandReaderSeparation(t *testing.T) {
+	msg, dst := []byte("msg"), []byte("QUUX-V01-CS02-with-expander-reader")
+	me := &messageExpandXMD{h: hash.SHA256}
+
+	r, err := me.ExpandReader(msg, dst)
+	test.CheckNoErr(t, err, "expand reader err")
+
+	zero, err := me.expandXMD(msg, dst, 0)
+	test.CheckNoErr(t, err, "expand err")
+
+	stream, unseparated := make([]byte, 64), make([]byte, 64)
+	_, err = io.ReadFull(r, stream)
+	test.CheckNoErr(t, err, "read err")
+	_, err = io.ReadFull(zero, unseparated)
+	test.CheckNoErr(t, err, "read err")
+
+	test.CheckOk(t, !bytes.Equal(stream, unseparated), "the stream should be separated from the zero-length expansion")
+}
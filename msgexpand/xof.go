@@ -5,8 +5,8 @@
 package msgexpand
 
 import (
+	"io"
 	"math"
-	"math/big"
 
 	"github.com/cymony/cryptomony/utils"
 	"github.com/cymony/cryptomony/xof"
@@ -19,20 +19,62 @@ type messageExpandXOF struct {
 
 // NewMessageExpandXOF returns an expander based on an extendable output functions.
 // The kSecLevel parameter is the target security level in bits.
+// It implements IntoExpander, ReaderExpander and fmt.Stringer, whose String returns the expander identifier used in
+// hash-to-curve suite identifiers, e.g. XOF:SHAKE-256.
 func NewMessageExpandXOF(id xof.Extendable, secLevel int) MessageExpand {
 	return &messageExpandXOF{id: id, k: secLevel}
 }
 
 func (me *messageExpandXOF) Expand(msg, dst []byte, lenInBytes int) ([]byte, error) {
+	if lenInBytes < 0 {
+		return nil, errLengthTooHigh
+	}
+
+	uniform := make([]byte, lenInBytes)
+	if err := me.ExpandInto(uniform, msg, dst); err != nil {
+		return nil, err
+	}
+
+	return uniform, nil
+}
+
+func (me *messageExpandXOF) ExpandInto(uniform, msg, dst []byte) error {
+	if err := checkDST(dst); err != nil {
+		return err
+	}
+
+	h, err := me.expandXOF(msg, dst, len(uniform), me.k)
+	if err != nil {
+		return err
+	}
+
+	//nolint:gocritic //not a commented code
+	// uniform_bytes = H(msg_prime, len_in_bytes)
+	return h.MustReadFull(uniform)
+}
+
+func (me *messageExpandXOF) ExpandReader(msg, dst []byte) (io.Reader, error) {
 	if err := checkDST(dst); err != nil {
 		return nil, err
 	}
 
-	return me.expandXOF(msg, dst, lenInBytes, me.k)
+	return me.expandXOF(msg, utils.Concat(dst, readerDSTSuffix), 0, me.k)
+}
+
+func (me *messageExpandXOF) String() string {
+	switch me.id {
+	case xof.SHAKE128:
+		return "XOF:SHAKE-128"
+	case xof.SHAKE256:
+		return "XOF:SHAKE-256"
+	default:
+		return "XOF:" + me.id.New().String()
+	}
 }
 
 // See https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#name-expand_message_xof
-func (me *messageExpandXOF) expandXOF(msg, dst []byte, lenInBytes, k int) ([]byte, error) {
+// expandXOF returns the XOF which absorbed msg_prime, from which the uniform bytes are read.
+func (me *messageExpandXOF) expandXOF(msg, dst []byte, lenInBytes, k int) (xof.XOF, error) {
 	// process DST > 255 case
 	newDST, err := processDSTXOF(me.id, k, dst)
 	if err != nil {
@@ -42,40 +84,19 @@ func (me *messageExpandXOF) expandXOF(msg, dst []byte, lenInBytes, k int) ([]byt
 	dst = newDST
 
 	// ABORT if len_in_bytes > 65535 or len(DST) > 255
-	if lenInBytes > math.MaxUint16 || len(dst) > 255 {
+	if lenInBytes > math.MaxUint16 || len(dst) > maxDstLen {
 		return nil, errLengthTooHigh
 	}
 
-	//nolint:gocritic //not a commented code
-	// I2OSP(len(DST), 1)
-	lenDST1i2osp, err := utils.I2osp(big.NewInt(int64(len(dst))), 1)
-	if err != nil {
-		return nil, err
-	}
-
-	//nolint:gocritic //not a commented code
-	// I2OSP(len_in_bytes, 2)
-	lenLenInBytes2i2osp, err := utils.I2osp(big.NewInt(int64(lenInBytes)), 2)
-	if err != nil {
-		return nil, err
-	}
-
 	//nolint:gocritic //not a commented code
 	// DST_prime = DST || I2OSP(len(DST), 1)
 	// msg_prime = msg || I2OSP(len_in_bytes, 2) || DST_prime
 	h := me.id.New()
-	if err := h.MustWriteAll(msg, lenLenInBytes2i2osp, dst, lenDST1i2osp); err != nil {
-		return nil, err
-	}
-
-	//nolint:gocritic //not a commented code
-	// uniform_bytes = H(msg_prime, len_in_bytes)
-	uniformBytes := make([]byte, lenInBytes)
-	if err := h.MustReadFull(uniformBytes); err != nil {
+	if err := h.MustWriteAll(msg, []byte{byte(lenInBytes >> 8), byte(lenInBytes)}, dst, []byte{byte(len(dst))}); err != nil {
 		return nil, err
 	}
 
-	return uniformBytes, nil
+	return h, nil
 }
 
 // Prepend H2C-OVERSIZE-DST- to dst if longer than 255.
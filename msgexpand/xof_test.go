@@ -5,7 +5,9 @@
 package msgexpand
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"testing"
 
 	"github.com/cymony/cryptomony/internal/test"
@@ -84,3 +86,21 @@ func TestXOFExpandErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestXOFExpandReaderSeparation(t *testing.T) {
+	msg, dst := []byte("msg"), []byte("QUUX-V01-CS02-with-expander-reader")
+	me := &messageExpandXOF{id: xof.SHAKE128, k: 128}
+
+	r, err := me.ExpandReader(msg, dst)
+	test.CheckNoErr(t, err, "expand reader err")
+
+	zero, err := me.expandXOF(msg, dst, 0, me.k)
+	test.CheckNoErr(t, err, "expand err")
+
+	stream, unseparated := make([]byte, 64), make([]byte, 64)
+	_, err = io.ReadFull(r, stream)
+	test.CheckNoErr(t, err, "read err")
+	test.CheckNoErr(t, zero.MustReadFull(unseparated), "read err")
+
+	test.CheckOk(t, !bytes.Equal(stream, unseparated), "the stream should be separated from the zero-length expansion")
+}
@@ -4,6 +4,12 @@
 
 package opaque
 
+import (
+	"io"
+
+	"github.com/cymony/cryptomony/ksf"
+)
+
 // Client interface represents the client instance.
 type Client interface {
 	// CreateRegistrationRequest computes blinded message and returns (RegistrationRequest, blind).
@@ -23,8 +29,10 @@ type Client interface {
 
 // ClientConfiguration contains configurations to initialize client instance
 type ClientConfiguration struct {
-	ServerID    []byte     // Server Identity. Usually, domain name
-	OpaqueSuite Identifier // Chosen Opaque Suite
+	ServerID    []byte       // Server Identity. Usually, domain name
+	OpaqueSuite Identifier   // Chosen Opaque Suite
+	Rand        io.Reader    // Source of randomness of blinds, nonces and keyshares. crypto/rand if nil
+	KSFOptions  []ksf.Option // Options of the suite's key stretching function, e.g. from ksf.Calibrate. Same for registration and logins
 }
 
 type client struct {
@@ -36,7 +44,7 @@ type client struct {
 func NewClient(conf *ClientConfiguration) Client {
 	return &client{
 		serverIdentity: conf.ServerID,
-		suite:          conf.OpaqueSuite.New(),
+		suite:          conf.OpaqueSuite.newSuite(WithRandom(conf.Rand), WithKSFOptions(conf.KSFOptions...)),
 	}
 }
 
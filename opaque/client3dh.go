@@ -7,6 +7,7 @@ package opaque
 import (
 	"crypto/hmac"
 
+	"github.com/cymony/cryptomony/internal/typedgroup"
 	"github.com/cymony/cryptomony/utils"
 )
 
@@ -56,15 +57,17 @@ func (os *opaqueSuite) AuthClientFinalize(state *ClientLoginState,
 	if err != nil {
 		return nil, nil, err
 	}
+	// the shared secrets are serialized once computed, so they share an element
+	dh := g.NewElement()
 	//nolint:gocritic // not a commented code
 	// dh1 = SerializeElement(state.client_secret * ke2.auth_response.server_keyshare)
-	dh1 := g.NewElement().Add(serverKeyshareEl).Multiply(clientSecretSc).Encode()
+	dh1 := typedgroup.MultiplyInto(dh, clientSecretSc, serverKeyshareEl).Encode()
 	//nolint:gocritic // not a commented code
 	// dh2 = SerializeElement(state.client_secret * server_public_key)
-	dh2 := g.NewElement().Add(serverPubEl).Multiply(clientSecretSc).Encode()
+	dh2 := typedgroup.MultiplyInto(dh, clientSecretSc, serverPubEl).Encode()
 	//nolint:gocritic // not a commented code
 	// dh3 = SerializeElement(client_private_key  * ke2.auth_response.server_keyshare)
-	dh3 := g.NewElement().Add(serverKeyshareEl).Multiply(clientPrivSc).Encode()
+	dh3 := typedgroup.MultiplyInto(dh, clientPrivSc, serverKeyshareEl).Encode()
 	//nolint:gocritic // not a commented code
 	// ikm = concat(dh1, dh2, dh3)
 	ikm := utils.Concat(dh1, dh2, dh3)
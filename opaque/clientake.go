@@ -10,11 +10,17 @@ import (
 )
 
 func (os *opaqueSuite) ClientInit(password []byte) (*ClientLoginState, *KE1, error) {
-	chosenBlind := os.OPRF().Group().RandomScalar()
+	chosenBlind, err := os.OPRF().Group().RandomScalarFrom(os.rnd)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	//nolint:gocritic // not a commented code
 	// client_nonce = random(Nn)
-	chosenClientNonce := utils.RandomBytes(os.Nn())
+	chosenClientNonce, err := utils.RandomBytesFrom(os.rnd, os.Nn())
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// (client_secret, client_keyshare) = GenerateAuthKeyPair()
 	chosenClientSecret, err := os.GenerateAuthKeyPair()
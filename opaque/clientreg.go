@@ -10,7 +10,10 @@ import (
 )
 
 func (os *opaqueSuite) CreateRegistrationRequest(password []byte) (*RegistrationRequest, *eccgroup.Scalar, error) {
-	chosenBlind := os.Group().RandomScalar()
+	chosenBlind, err := os.Group().RandomScalarFrom(os.rnd)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	return os.createRegistrationRequest(password, chosenBlind)
 }
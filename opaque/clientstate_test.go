@@ -72,7 +72,7 @@ func getRandomClientState(t *testing.T, suite Suite, stateType string) clStateIn
 }
 
 func TestClientStatesEncodeDecode(t *testing.T) {
-	suites := []Suite{Ristretto255Suite.New(), P256Suite.New()}
+	suites := []Suite{Ristretto255Suite.New(), P256Suite.New(), Decaf448Suite.New()}
 	stateTypes := []string{"Login", "Registration"}
 
 	for _, suite := range suites {
@@ -58,7 +58,10 @@ func (e *Envelope) Decode(suite Suite, data []byte) error {
 func (os *opaqueSuite) Store(randomizedPwd []byte, sPubKey *PublicKey, serverIdentity, clientIdentity []byte) (*Envelope, *PublicKey, []byte, []byte, error) {
 	//nolint:gocritic //not a commented code
 	// envelope_nonce = random(Nn)
-	envelopeNonce := utils.RandomBytes(os.Nn())
+	envelopeNonce, err := utils.RandomBytesFrom(os.rnd, os.Nn())
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
 
 	return os.store(randomizedPwd, sPubKey, serverIdentity, clientIdentity, envelopeNonce)
 }
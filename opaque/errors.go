@@ -25,4 +25,5 @@ var (
 	ErrEncodingFailed           = errors.New("opaque: encoding failed")
 	ErrDeserializationFailed    = errors.New("opaque: deserialization failed")
 	ErrSerializationFailed      = errors.New("opaque: serialization failed")
+	ErrInvalidSuiteConfig       = errors.New("opaque: invalid suite configuration")
 )
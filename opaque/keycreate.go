@@ -102,7 +102,11 @@ func (os *opaqueSuite) DeriveKeyPair(seed []byte) (*PrivateKey, error) {
 }
 
 func (os *opaqueSuite) GenerateKeyPair() (*PrivateKey, error) {
-	seed := utils.RandomBytes(os.Nseed())
+	seed, err := utils.RandomBytesFrom(os.rnd, os.Nseed())
+	if err != nil {
+		return nil, err
+	}
+
 	return os.DeriveKeyPair(seed)
 }
 
@@ -120,6 +124,10 @@ func (os *opaqueSuite) DeriveAuthKeyPair(seed []byte) (*PrivateKey, error) {
 }
 
 func (os *opaqueSuite) GenerateAuthKeyPair() (*PrivateKey, error) {
-	rndSeed := utils.RandomBytes(os.Nseed())
+	rndSeed, err := utils.RandomBytesFrom(os.rnd, os.Nseed())
+	if err != nil {
+		return nil, err
+	}
+
 	return os.DeriveAuthKeyPair(rndSeed)
 }
@@ -5,53 +5,11 @@
 package opaque
 
 import (
-	"math/big"
-
+	"github.com/cymony/cryptomony/kdf"
 	"github.com/cymony/cryptomony/opaque/internal/common"
 	"github.com/cymony/cryptomony/utils"
 )
 
-//	struct {
-//	  uint16 length = Length;
-//	  opaque label<8..255> = "OPAQUE-" + Label;
-//	  uint8 context<0..255> = Context;
-//	} CustomLabel;
-func buildLabel(label, context []byte, l int) ([]byte, error) {
-	length, err := utils.I2osp(big.NewInt(int64(l)), 2)
-	if err != nil {
-		return nil, err
-	}
-
-	lbl, err := common.I2ospLenX(utils.Concat([]byte(labelOPAQUEDash), label), 1)
-	if err != nil {
-		return nil, err
-	}
-
-	ctx, err := common.I2ospLenX(context, 1)
-	if err != nil {
-		return nil, err
-	}
-
-	return utils.Concat(length, lbl, ctx), nil
-}
-
-// Expand-Label(Secret, Label, Context, Length) =
-// Expand(Secret, CustomLabel, Length)
-func expandLabel(suite Suite, secret, label, context []byte, length int) ([]byte, error) {
-	customLabel, err := buildLabel(label, context, length)
-	if err != nil {
-		return nil, err
-	}
-
-	return suite.Expand(secret, customLabel, length), nil
-}
-
-// Derive-Secret(Secret, Label, Transcript-Hash) =
-// Expand-Label(Secret, Label, Transcript-Hash, Nx)
-func deriveSecret(suite Suite, secret, label, transcript []byte) ([]byte, error) {
-	return expandLabel(suite, secret, label, transcript, suite.Nx())
-}
-
 // Reference: https://www.ietf.org/archive/id/draft-irtf-cfrg-opaque-09.html#name-transcript-functions
 func preamble(clientIdentity []byte,
 	ke1 *KE1,
@@ -102,7 +60,17 @@ func preamble(clientIdentity []byte,
 }
 
 // Reference: https://www.ietf.org/archive/id/draft-irtf-cfrg-opaque-09.html#name-shared-secret-derivation
-func deriveKeys(suite Suite, ikm, preamble []byte) ([]byte, []byte, []byte, error) {
+//
+// Expand-Label and Derive-Secret are the labeled HKDF of TLS 1.3 over the KDF of the suite, with the "OPAQUE-" prefix:
+//
+//	struct {
+//	  uint16 length = Length;
+//	  opaque label<8..255> = "OPAQUE-" + Label;
+//	  uint8 context<0..255> = Context;
+//	} CustomLabel;
+func deriveKeys(suite *opaqueSuite, ikm, preamble []byte) ([]byte, []byte, []byte, error) {
+	labeled := &kdf.Labeled{Hash: suite.kdf, Prefix: labelOPAQUEDash}
+
 	h := suite.Hash()
 	if err := h.MustWriteAll(preamble); err != nil {
 		return nil, nil, nil, err
@@ -119,28 +87,28 @@ func deriveKeys(suite Suite, ikm, preamble []byte) ([]byte, []byte, []byte, erro
 
 	//nolint:gocritic //not a commented code
 	// handshake_secret = Derive-Secret(prk, "HandshakeSecret", Hash(preamble))
-	handshakeSecret, err := deriveSecret(suite, prk, []byte(labelHandshakeSecret), hPreamble)
+	handshakeSecret, err := labeled.DeriveSecret(prk, []byte(labelHandshakeSecret), hPreamble)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
 	//nolint:gocritic //not a commented code
 	// session_key = Derive-Secret(prk, "SessionKey", Hash(preamble))
-	sessionKey, err := deriveSecret(suite, prk, []byte(labelSessionKey), hPreamble)
+	sessionKey, err := labeled.DeriveSecret(prk, []byte(labelSessionKey), hPreamble)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
 	//nolint:gocritic //not a commented code
 	// Km2 = Derive-Secret(handshake_secret, "ServerMAC", "")
-	km2, err := deriveSecret(suite, handshakeSecret, []byte(labelServerMAC), nil)
+	km2, err := labeled.DeriveSecret(handshakeSecret, []byte(labelServerMAC), nil)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
 	//nolint:gocritic //not a commented code
 	// Km3 = Derive-Secret(handshake_secret, "ClientMAC", "")
-	km3, err := deriveSecret(suite, handshakeSecret, []byte(labelClientMAC), nil)
+	km3, err := labeled.DeriveSecret(handshakeSecret, []byte(labelClientMAC), nil)
 	if err != nil {
 		return nil, nil, nil, err
 	}
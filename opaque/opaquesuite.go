@@ -10,6 +10,9 @@
 package opaque
 
 import (
+	"io"
+	"sync"
+
 	"github.com/cymony/cryptomony/eccgroup"
 	"github.com/cymony/cryptomony/hash"
 	"github.com/cymony/cryptomony/ksf"
@@ -26,18 +29,131 @@ const (
 	// P256Suite is the identifier for recommended opaque suite -> OPRF(P-256, SHA-256), HKDF-SHA-256, HMAC-SHA-256, SHA-256, Scrypt(32768,8,1), internal, P-256
 	// Reference: https://www.ietf.org/archive/id/draft-irtf-cfrg-opaque-09.html#name-configurations
 	P256Suite
+	// Decaf448Suite is the identifier for high-security opaque suite -> OPRF(decaf448, SHAKE-256), HKDF-SHA-512, HMAC-SHA-512, SHA-512, Scrypt(32768,8,1), internal, decaf448
+	// This configuration is specific to this library: the draft defines no decaf448 configuration.
+	Decaf448Suite
+)
+
+// firstRegisteredSuite is the identifier of the first suite registered with RegisterSuite.
+const firstRegisteredSuite Identifier = 0x80
+
+var (
+	registeredSuitesMu sync.RWMutex
+	registeredSuites   []SuiteConfiguration
 )
 
+// SuiteConfiguration describes an opaque suite to register with RegisterSuite.
+type SuiteConfiguration struct {
+	OPRF       oprf.Suite     // OPRF suite, e.g. built with oprf.NewSuite over a group registered with eccgroup.Register
+	KSF        ksf.Identifier // Key stretching function
+	KSFOptions []ksf.Option   // Options of the key stretching function, e.g. from ksf.Calibrate. Defaults if empty
+	KDF        hash.Hashing   // Hash function of HKDF
+	MAC        hash.Hashing   // Hash function of HMAC
+	Hash       hash.Hashing   // Hash function of the protocol
+}
+
+// RegisterSuite makes the suite available under a new Identifier, and returns the identifier.
+// Clients and servers must register the same suites in the same order to agree on the identifiers.
+func RegisterSuite(conf *SuiteConfiguration) (Identifier, error) {
+	if conf == nil || conf.OPRF == nil || !conf.KSF.Available() {
+		return 0, ErrInvalidSuiteConfig
+	}
+
+	for _, h := range []hash.Hashing{conf.KDF, conf.MAC, conf.Hash} {
+		if h == 0 || !h.Available() {
+			return 0, ErrInvalidSuiteConfig
+		}
+	}
+
+	// HKDF and HMAC are not defined over extendable-output functions
+	if conf.KDF.IsXOF() || conf.MAC.IsXOF() {
+		return 0, ErrInvalidSuiteConfig
+	}
+
+	registeredSuitesMu.Lock()
+	defer registeredSuitesMu.Unlock()
+
+	registeredSuites = append(registeredSuites, *conf)
+
+	return firstRegisteredSuite + Identifier(len(registeredSuites)-1), nil
+}
+
 // New initialize new suite instance and returns it.
 func (i Identifier) New() Suite {
+	return i.newSuite()
+}
+
+// SuiteOption configures a suite instance returned by Identifier.NewWithOptions.
+type SuiteOption func(*opaqueSuite)
+
+// WithRandom generates the blinds, nonces and keyshares of the suite from the randomness of the reader. The reader is
+// crypto/rand if nil.
+func WithRandom(r io.Reader) SuiteOption {
+	return func(os *opaqueSuite) {
+		os.rnd = r
+	}
+}
+
+// WithKSFOptions applies the options to the key stretching function of the suite's Stretch, after the options of the
+// suite. The options can be computed with ksf.Calibrate, and must be the same for the registration and the logins of
+// a client.
+func WithKSFOptions(options ...ksf.Option) SuiteOption {
+	return func(os *opaqueSuite) {
+		os.ksfOptions = append(os.ksfOptions[:len(os.ksfOptions):len(os.ksfOptions)], options...)
+	}
+}
+
+// NewWithOptions initialize new suite instance configured with the options, e.g. both WithRandom and
+// WithKSFOptions, and returns it.
+func (i Identifier) NewWithOptions(options ...SuiteOption) Suite {
+	return i.newSuite(options...)
+}
+
+// NewWithRandom initialize new suite instance, whose blinds, nonces and keyshares are generated from the randomness of
+// the reader, and returns it. The reader is crypto/rand if nil. It is NewWithOptions(WithRandom(r)).
+func (i Identifier) NewWithRandom(r io.Reader) Suite {
+	return i.newSuite(WithRandom(r))
+}
+
+// NewWithKSFOptions initialize new suite instance, whose Stretch applies the options to the key stretching function
+// after the options of the suite, and returns it. The options can be computed with ksf.Calibrate, and must be the same
+// for the registration and the logins of a client. It is NewWithOptions(WithKSFOptions(options...)).
+func (i Identifier) NewWithKSFOptions(options ...ksf.Option) Suite {
+	return i.newSuite(WithKSFOptions(options...))
+}
+
+func (i Identifier) newSuite(options ...SuiteOption) *opaqueSuite {
+	var os *opaqueSuite
+
 	switch i {
 	case Ristretto255Suite:
-		return &opaqueSuite{oprf: oprf.SuiteRistretto255Sha512, group: eccgroup.Ristretto255Sha512, ksf: ksf.Scrypt, kdf: hash.SHA512, mac: hash.SHA512, hsh: hash.SHA512, context: []byte(libContext)}
+		os = &opaqueSuite{oprf: oprf.SuiteRistretto255Sha512, group: eccgroup.Ristretto255Sha512, ksf: ksf.Scrypt, kdf: hash.SHA512, mac: hash.SHA512, hsh: hash.SHA512, context: []byte(libContext)}
 	case P256Suite:
-		return &opaqueSuite{oprf: oprf.SuiteP256Sha256, group: eccgroup.P256Sha256, ksf: ksf.Scrypt, kdf: hash.SHA256, mac: hash.SHA256, hsh: hash.SHA256, context: []byte(libContext)}
+		os = &opaqueSuite{oprf: oprf.SuiteP256Sha256, group: eccgroup.P256Sha256, ksf: ksf.Scrypt, kdf: hash.SHA256, mac: hash.SHA256, hsh: hash.SHA256, context: []byte(libContext)}
+	case Decaf448Suite:
+		os = &opaqueSuite{oprf: oprf.SuiteDecaf448Shake256, group: eccgroup.Decaf448Shake256, ksf: ksf.Scrypt, kdf: hash.SHA512, mac: hash.SHA512, hsh: hash.SHA512, context: []byte(libContext)}
 	default:
+		os = i.newRegistered()
+	}
+
+	for _, option := range options {
+		option(os)
+	}
+
+	return os
+}
+
+func (i Identifier) newRegistered() *opaqueSuite {
+	registeredSuitesMu.RLock()
+	defer registeredSuitesMu.RUnlock()
+
+	if i < firstRegisteredSuite || int(i-firstRegisteredSuite) >= len(registeredSuites) {
 		panic("unsupported suite")
 	}
+
+	conf := registeredSuites[i-firstRegisteredSuite]
+
+	return &opaqueSuite{oprf: conf.OPRF, group: conf.OPRF.Group(), ksf: conf.KSF, ksfOptions: conf.KSFOptions, kdf: conf.KDF, mac: conf.MAC, hsh: conf.Hash, context: []byte(libContext)}
 }
 
 // Suite interface identifies the opaque protocol and required functions
@@ -62,8 +178,11 @@ type Suite interface {
 	// Recover implements opaque protocol's Envelope Recovery step.
 	// Reference: https://www.ietf.org/archive/id/draft-irtf-cfrg-opaque-09.html#name-envelope-recovery.
 	Recover(randomizedPwd []byte, sPubKey *PublicKey, envelope *Envelope, serverIdentity, clientIdentity []byte) (*PrivateKey, []byte, error)
-	// GenerateOPRFSeed generates random Nh bytes to use as oprf seed.
+	// GenerateOPRFSeed generates random Nh bytes to use as oprf seed. It panics if the source of randomness fails.
 	GenerateOprfSeed() []byte
+	// GenerateOprfSeedFrom reads random Nh bytes from r, or from the suite's source of randomness if r is nil, to use
+	// as oprf seed. It returns an error if the reader fails.
+	GenerateOprfSeedFrom(r io.Reader) ([]byte, error)
 
 	// Registration Functions
 	//
@@ -160,13 +279,15 @@ type Suite interface {
 }
 
 type opaqueSuite struct {
-	oprf    oprf.Suite
-	context []byte
-	group   eccgroup.Group
-	ksf     ksf.Identifier
-	kdf     hash.Hashing
-	mac     hash.Hashing
-	hsh     hash.Hashing
+	oprf       oprf.Suite
+	context    []byte
+	group      eccgroup.Group
+	ksf        ksf.Identifier
+	ksfOptions []ksf.Option // options of Stretch, from the suite configuration then the client configuration
+	kdf        hash.Hashing
+	mac        hash.Hashing
+	hsh        hash.Hashing
+	rnd        io.Reader // source of randomness, crypto/rand if nil
 }
 
 func (os *opaqueSuite) OPRF() oprf.Suite {
@@ -194,7 +315,12 @@ func (os *opaqueSuite) MAC(key, message []byte) ([]byte, error) {
 }
 
 func (os *opaqueSuite) Stretch(password []byte, length int) ([]byte, error) {
-	return os.ksf.New().Harden(password, nil, length)
+	k := os.ksf.New()
+	if err := k.SetOptions(os.ksfOptions...); err != nil {
+		return nil, err
+	}
+
+	return k.Harden(password, nil, length)
 }
 
 func (os *opaqueSuite) Nh() int {
@@ -210,11 +336,11 @@ func (os *opaqueSuite) Nsk() int {
 }
 
 func (os *opaqueSuite) Nm() int {
-	return os.mac.CryptoID().Size()
+	return os.mac.Size()
 }
 
 func (os *opaqueSuite) Nx() int {
-	return os.kdf.CryptoID().Size()
+	return os.kdf.Size()
 }
 
 func (os *opaqueSuite) Noe() int {
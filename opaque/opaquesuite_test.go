@@ -0,0 +1,273 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opaque
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cymony/cryptomony/drbg"
+	"github.com/cymony/cryptomony/eccgroup"
+	"github.com/cymony/cryptomony/hash"
+	"github.com/cymony/cryptomony/internal/test"
+	"github.com/cymony/cryptomony/ksf"
+	"github.com/cymony/cryptomony/oprf"
+)
+
+// renamedGroup is a group implementation registered under another ciphersuite identifier.
+type renamedGroup struct {
+	eccgroup.GroupImpl
+}
+
+func (r *renamedGroup) Ciphersuite() string {
+	return "opaque-test-P256"
+}
+
+func TestRegisterSuite(t *testing.T) {
+	group, err := eccgroup.Register(&renamedGroup{eccgroup.P256Sha256.Impl()})
+	test.CheckNoErr(t, err, "group registration failed")
+
+	oprfSuite, err := oprf.NewSuite(0xff01, "OPRF(registered P-256, SHA-256)", group, hash.SHA256)
+	test.CheckNoErr(t, err, "oprf suite creation failed")
+
+	_, err = RegisterSuite(&SuiteConfiguration{OPRF: oprfSuite, KSF: ksf.Identity, KDF: hash.SHA256, MAC: hash.SHA256})
+	test.CheckIsErr(t, err, "missing hash function should be rejected")
+
+	_, err = RegisterSuite(&SuiteConfiguration{KSF: ksf.Identity, KDF: hash.SHA256, MAC: hash.SHA256, Hash: hash.SHA256})
+	test.CheckIsErr(t, err, "missing oprf suite should be rejected")
+
+	_, err = RegisterSuite(&SuiteConfiguration{OPRF: oprfSuite, KSF: ksf.Identity, KDF: hash.SHAKE256, MAC: hash.SHA256, Hash: hash.SHA256})
+	test.CheckIsErr(t, err, "HKDF over an XOF should be rejected")
+
+	id, err := RegisterSuite(&SuiteConfiguration{OPRF: oprfSuite, KSF: ksf.Identity, KDF: hash.SHA256, MAC: hash.SHA256, Hash: hash.SHA256})
+	test.CheckNoErr(t, err, "suite registration failed")
+
+	err = test.CheckPanic(func() {
+		(id + 1).New()
+	})
+	test.CheckNoErr(t, err, "panic expected")
+
+	test.CheckOk(t, id.New().Group() == group, "registered suite group mismatch")
+
+	roundTrip(t, id)
+
+	// HKDF and HMAC may use hash functions without a counterpart in the crypto library
+	blake, err := RegisterSuite(&SuiteConfiguration{OPRF: oprfSuite, KSF: ksf.Identity, KDF: hash.BLAKE3, MAC: hash.BLAKE3, Hash: hash.SHA256})
+	test.CheckNoErr(t, err, "suite registration failed")
+
+	roundTrip(t, blake)
+}
+
+// roundTrip registers and logs in a client with the suite, and checks that the client and the server agree.
+func roundTrip(t *testing.T, id Identifier) {
+	t.Helper()
+
+	server, err := NewServer(&ServerConfiguration{ServerID: []byte("example.com"), OpaqueSuite: id})
+	test.CheckNoErr(t, err, "server creation failed")
+
+	client := NewClient(&ClientConfiguration{ServerID: []byte("example.com"), OpaqueSuite: id})
+
+	userID, password := []byte("user"), []byte("password")
+	oprfSeed, err := server.GenerateOprfSeedFrom(nil)
+	test.CheckNoErr(t, err, "oprf seed generation failed")
+	credentialIdentifier := []byte("credential identifier")
+
+	clRegState, regReq, err := client.CreateRegistrationRequest(password)
+	test.CheckNoErr(t, err, "registration request failed")
+	encodedRegReq, err := regReq.Encode()
+	test.CheckNoErr(t, err, "encoding failed")
+
+	regRes, err := server.CreateRegistrationResponse(encodedRegReq, credentialIdentifier, oprfSeed)
+	test.CheckNoErr(t, err, "registration response failed")
+	encodedRegRes, err := regRes.Encode()
+	test.CheckNoErr(t, err, "encoding failed")
+
+	record, regExportKey, err := client.FinalizeRegistrationRequest(clRegState, userID, encodedRegRes)
+	test.CheckNoErr(t, err, "registration finalization failed")
+	encodedRecord, err := record.Encode()
+	test.CheckNoErr(t, err, "encoding failed")
+
+	clLoginState, ke1, err := client.ClientInit(password)
+	test.CheckNoErr(t, err, "client init failed")
+	encodedKE1, err := ke1.Encode()
+	test.CheckNoErr(t, err, "encoding failed")
+
+	svLoginState, ke2, err := server.ServerInit(encodedRecord, encodedKE1, credentialIdentifier, userID, oprfSeed)
+	test.CheckNoErr(t, err, "server init failed")
+	encodedKE2, err := ke2.Encode()
+	test.CheckNoErr(t, err, "encoding failed")
+
+	ke3, clSessionKey, loginExportKey, err := client.ClientFinish(clLoginState, userID, encodedKE2)
+	test.CheckNoErr(t, err, "client finish failed")
+	encodedKE3, err := ke3.Encode()
+	test.CheckNoErr(t, err, "encoding failed")
+
+	svSessionKey, err := server.ServerFinish(svLoginState, encodedKE3)
+	test.CheckNoErr(t, err, "server finish failed")
+
+	test.CheckOk(t, bytes.Equal(clSessionKey, svSessionKey), "session keys mismatch")
+	test.CheckOk(t, bytes.Equal(regExportKey, loginExportKey), "export keys mismatch")
+}
+
+// seededTranscript registers and logs in a client with the randomness of DRBGs instantiated with the seed, and
+// returns the encoded messages and the session key.
+func seededTranscript(t *testing.T, seed []byte) []byte {
+	t.Helper()
+
+	newReader := func(nonce string) *drbg.Hash {
+		r, err := drbg.NewHash(hash.SHA256, seed, []byte(nonce), nil)
+		test.CheckNoErr(t, err, "drbg instantiation failed")
+
+		return r
+	}
+
+	server, err := NewServer(&ServerConfiguration{ServerID: []byte("example.com"), OpaqueSuite: P256Suite, Rand: newReader("server")})
+	test.CheckNoErr(t, err, "server creation failed")
+
+	client := NewClient(&ClientConfiguration{ServerID: []byte("example.com"), OpaqueSuite: P256Suite, Rand: newReader("client")})
+
+	userID, password := []byte("user"), []byte("password")
+	oprfSeed, err := server.GenerateOprfSeedFrom(nil)
+	test.CheckNoErr(t, err, "oprf seed generation failed")
+	credentialIdentifier := []byte("credential identifier")
+
+	clRegState, regReq, err := client.CreateRegistrationRequest(password)
+	test.CheckNoErr(t, err, "registration request failed")
+	encodedRegReq, err := regReq.Encode()
+	test.CheckNoErr(t, err, "encoding failed")
+
+	regRes, err := server.CreateRegistrationResponse(encodedRegReq, credentialIdentifier, oprfSeed)
+	test.CheckNoErr(t, err, "registration response failed")
+	encodedRegRes, err := regRes.Encode()
+	test.CheckNoErr(t, err, "encoding failed")
+
+	record, _, err := client.FinalizeRegistrationRequest(clRegState, userID, encodedRegRes)
+	test.CheckNoErr(t, err, "registration finalization failed")
+	encodedRecord, err := record.Encode()
+	test.CheckNoErr(t, err, "encoding failed")
+
+	clLoginState, ke1, err := client.ClientInit(password)
+	test.CheckNoErr(t, err, "client init failed")
+	encodedKE1, err := ke1.Encode()
+	test.CheckNoErr(t, err, "encoding failed")
+
+	svLoginState, ke2, err := server.ServerInit(encodedRecord, encodedKE1, credentialIdentifier, userID, oprfSeed)
+	test.CheckNoErr(t, err, "server init failed")
+	encodedKE2, err := ke2.Encode()
+	test.CheckNoErr(t, err, "encoding failed")
+
+	ke3, sessionKey, _, err := client.ClientFinish(clLoginState, userID, encodedKE2)
+	test.CheckNoErr(t, err, "client finish failed")
+	encodedKE3, err := ke3.Encode()
+	test.CheckNoErr(t, err, "encoding failed")
+
+	_, err = server.ServerFinish(svLoginState, encodedKE3)
+	test.CheckNoErr(t, err, "server finish failed")
+
+	return bytes.Join([][]byte{encodedRegReq, encodedRegRes, encodedRecord, encodedKE1, encodedKE2, encodedKE3, sessionKey}, nil)
+}
+
+func TestRandomReader(t *testing.T) {
+	seed := bytes.Repeat([]byte{0x33}, 32)
+
+	one, two := seededTranscript(t, seed), seededTranscript(t, seed)
+	test.CheckOk(t, bytes.Equal(one, two), "same seed should give the same transcript")
+
+	other := seededTranscript(t, bytes.Repeat([]byte{0x44}, 32))
+	test.CheckOk(t, !bytes.Equal(one, other), "different seeds should give different transcripts")
+
+	_, _, err := P256Suite.NewWithRandom(bytes.NewReader(nil)).ClientInit([]byte("password"))
+	test.CheckIsErr(t, err, "empty reader should fail")
+
+	// the reader has only the randomness of the server key pair
+	server, err := NewServer(&ServerConfiguration{OpaqueSuite: P256Suite, Rand: bytes.NewReader(make([]byte, 32))})
+	test.CheckNoErr(t, err, "server creation failed")
+
+	_, err = server.GenerateOprfSeedFrom(nil)
+	test.CheckIsErr(t, err, "exhausted reader should fail")
+
+	oprfSeed, err := server.GenerateOprfSeedFrom(bytes.NewReader(bytes.Repeat([]byte{0x55}, 32)))
+	test.CheckNoErr(t, err, "oprf seed generation failed")
+	test.CheckOk(t, bytes.Equal(oprfSeed, bytes.Repeat([]byte{0x55}, 32)), "oprf seed should be read from the reader")
+
+	err = test.CheckPanic(func() { server.GenerateOprfSeed() })
+	test.CheckNoErr(t, err, "exhausted reader should panic")
+}
+
+func TestKSFOptions(t *testing.T) {
+	options, err := ksf.Calibrate(ksf.Scrypt, 10*time.Millisecond, 4<<20)
+	test.CheckNoErr(t, err, "calibration failed")
+
+	password := []byte("password")
+
+	k := ksf.Scrypt.New()
+	test.CheckNoErr(t, k.SetOptions(options...), "ksf options failed")
+	want, err := k.Harden(password, nil, 32)
+	test.CheckNoErr(t, err, "harden failed")
+
+	got, err := P256Suite.NewWithKSFOptions(options...).Stretch(password, 32)
+	test.CheckNoErr(t, err, "stretch failed")
+	test.CheckOk(t, bytes.Equal(got, want), "stretch should apply the options")
+
+	_, err = P256Suite.NewWithKSFOptions(ksf.WithArgon2Memory(1024)).Stretch(password, 32)
+	test.CheckIsErr(t, err, "invalid option should be rejected")
+
+	suite := P256Suite.NewWithOptions(WithRandom(bytes.NewReader(nil)), WithKSFOptions(options...))
+	got, err = suite.Stretch(password, 32)
+	test.CheckNoErr(t, err, "stretch failed")
+	test.CheckOk(t, bytes.Equal(got, want), "stretch should apply the options with a reader")
+
+	_, _, err = suite.ClientInit(password)
+	test.CheckIsErr(t, err, "exhausted reader should be used with options")
+
+	server, err := NewServer(&ServerConfiguration{ServerID: []byte("example.com"), OpaqueSuite: P256Suite})
+	test.CheckNoErr(t, err, "server creation failed")
+
+	newClient := func(options ...ksf.Option) Client {
+		return NewClient(&ClientConfiguration{ServerID: []byte("example.com"), OpaqueSuite: P256Suite, KSFOptions: options})
+	}
+
+	userID, oprfSeed, credentialIdentifier := []byte("user"), server.GenerateOprfSeed(), []byte("credential identifier")
+
+	client := newClient(options...)
+
+	clRegState, regReq, err := client.CreateRegistrationRequest(password)
+	test.CheckNoErr(t, err, "registration request failed")
+	encodedRegReq, err := regReq.Encode()
+	test.CheckNoErr(t, err, "encoding failed")
+
+	regRes, err := server.CreateRegistrationResponse(encodedRegReq, credentialIdentifier, oprfSeed)
+	test.CheckNoErr(t, err, "registration response failed")
+	encodedRegRes, err := regRes.Encode()
+	test.CheckNoErr(t, err, "encoding failed")
+
+	record, _, err := client.FinalizeRegistrationRequest(clRegState, userID, encodedRegRes)
+	test.CheckNoErr(t, err, "registration finalization failed")
+	encodedRecord, err := record.Encode()
+	test.CheckNoErr(t, err, "encoding failed")
+
+	// the login succeeds with the options of the registration only
+	for _, v := range []struct {
+		client Client
+		ok     bool
+	}{
+		{newClient(options...), true},
+		{newClient(), false},
+	} {
+		clLoginState, ke1, err := v.client.ClientInit(password)
+		test.CheckNoErr(t, err, "client init failed")
+		encodedKE1, err := ke1.Encode()
+		test.CheckNoErr(t, err, "encoding failed")
+
+		_, ke2, err := server.ServerInit(encodedRecord, encodedKE1, credentialIdentifier, userID, oprfSeed)
+		test.CheckNoErr(t, err, "server init failed")
+		encodedKE2, err := ke2.Encode()
+		test.CheckNoErr(t, err, "encoding failed")
+
+		_, _, _, err = v.client.ClientFinish(clLoginState, userID, encodedKE2)
+		test.CheckOk(t, (err == nil) == v.ok, "unexpected client finish result")
+	}
+}
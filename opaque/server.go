@@ -4,6 +4,8 @@
 
 package opaque
 
+import "io"
+
 // Server interface represents the server instance.
 type Server interface {
 	// CreateRegistrationResponse evaluates the RegistrationRequest and returns RegistrationResponse
@@ -15,8 +17,12 @@ type Server interface {
 	// The ServerFinish function completes the AKE protocol for the server, yielding the session_key.
 	// Reference: https://www.ietf.org/archive/id/draft-irtf-cfrg-opaque-09.html#name-serverfinish
 	ServerFinish(svLoginState *ServerLoginState, ke3Message []byte) ([]byte, error)
-	// GenerateOprfSeed generates randomly secure oprf seed with suitable length
+	// GenerateOprfSeed generates randomly secure oprf seed with suitable length.
+	// It panics if the source of randomness fails, use GenerateOprfSeedFrom with a custom Rand.
 	GenerateOprfSeed() []byte
+	// GenerateOprfSeedFrom reads randomly secure oprf seed with suitable length from r, or from the configured source
+	// of randomness if r is nil. It returns an error if the reader fails.
+	GenerateOprfSeedFrom(r io.Reader) ([]byte, error)
 }
 
 // ServerConfiguration contains configurations to initialize server instance
@@ -24,6 +30,7 @@ type ServerConfiguration struct {
 	ServerID         []byte     // Server Identity. Usually, domain name
 	ServerPrivateKey []byte     // Serialized server private key value
 	OpaqueSuite      Identifier // Chosen Opaque Suite
+	Rand             io.Reader  // Source of randomness of keys, nonces and keyshares. crypto/rand if nil
 }
 
 type server struct {
@@ -37,7 +44,7 @@ type server struct {
 func NewServer(conf *ServerConfiguration) (Server, error) {
 	var serverPriv *PrivateKey
 
-	suite := conf.OpaqueSuite.New()
+	suite := conf.OpaqueSuite.NewWithRandom(conf.Rand)
 
 	if len(conf.ServerPrivateKey) == 0 {
 		priv, err := suite.GenerateKeyPair()
@@ -97,3 +104,7 @@ func (s *server) ServerFinish(svLoginState *ServerLoginState, ke3Message []byte)
 func (s *server) GenerateOprfSeed() []byte {
 	return s.suite.GenerateOprfSeed()
 }
+
+func (s *server) GenerateOprfSeedFrom(r io.Reader) ([]byte, error) {
+	return s.suite.GenerateOprfSeedFrom(r)
+}
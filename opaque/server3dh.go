@@ -7,6 +7,7 @@ package opaque
 import (
 	"crypto/hmac"
 
+	"github.com/cymony/cryptomony/internal/typedgroup"
 	"github.com/cymony/cryptomony/utils"
 )
 
@@ -52,17 +53,20 @@ func (os *opaqueSuite) AuthServerRespond(serverPrivKey *PrivateKey,
 		return nil, nil, err
 	}
 
+	// the shared secrets are serialized once computed, so they share an element
+	dh := g.NewElement()
+
 	//nolint:gocritic //not a commented code
 	// dh1 = SerializeElement(server_private_keyshare * ke1.auth_request.client_keyshare)
-	dh1 := g.NewElement().Add(clientShareEl).Multiply(serverPrivKeyshareSc).Encode()
+	dh1 := typedgroup.MultiplyInto(dh, serverPrivKeyshareSc, clientShareEl).Encode()
 
 	//nolint:gocritic //not a commented code
 	// dh2 = SerializeElement(server_private_key * ke1.auth_request.client_keyshare)
-	dh2 := g.NewElement().Add(clientShareEl).Multiply(serverPrivSc).Encode()
+	dh2 := typedgroup.MultiplyInto(dh, serverPrivSc, clientShareEl).Encode()
 
 	//nolint:gocritic //not a commented code
 	// dh3 = SerializeElement(server_private_keyshare * client_public_key)
-	dh3 := g.NewElement().Add(clientPubEl).Multiply(serverPrivKeyshareSc).Encode()
+	dh3 := typedgroup.MultiplyInto(dh, serverPrivKeyshareSc, clientPubEl).Encode()
 
 	//nolint:gocritic //not a commented code
 	// ikm = concat(dh1, dh2, dh3)
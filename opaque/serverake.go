@@ -13,11 +13,17 @@ func (os *opaqueSuite) ServerInit(serverPrivKey *PrivateKey,
 	credIdentifier, clientIdentity, serverIdentity, oprfSeed []byte) (*ServerLoginState, *KE2, error) {
 	//nolint:gocritic //not a commented code
 	//   masking_nonce = random(Nn)
-	chosenMaskingNonce := utils.RandomBytes(os.Nn())
+	chosenMaskingNonce, err := utils.RandomBytesFrom(os.rnd, os.Nn())
+	if err != nil {
+		return nil, nil, err
+	}
 
 	//nolint:gocritic //not a commented code
 	// server_nonce = random(Nn)
-	chosenServerNonce := utils.RandomBytes(os.Nn())
+	chosenServerNonce, err := utils.RandomBytesFrom(os.rnd, os.Nn())
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// (server_private_keyshare, server_keyshare) = GenerateAuthKeyPair()
 	chosenServerPrivateKeyshare, err := os.GenerateAuthKeyPair()
@@ -4,10 +4,27 @@
 
 package opaque
 
-import "github.com/cymony/cryptomony/utils"
+import (
+	"io"
+
+	"github.com/cymony/cryptomony/utils"
+)
 
 func (os *opaqueSuite) GenerateOprfSeed() []byte {
-	return utils.RandomBytes(os.Nh())
+	seed, err := os.GenerateOprfSeedFrom(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	return seed
+}
+
+func (os *opaqueSuite) GenerateOprfSeedFrom(r io.Reader) ([]byte, error) {
+	if r == nil {
+		r = os.rnd
+	}
+
+	return utils.RandomBytesFrom(r, os.Nh())
 }
 
 func (os *opaqueSuite) CreateRegistrationResponse(regReq *RegistrationRequest, serverPubKey *PublicKey, credentialIdentifier, oprfSeed []byte) (*RegistrationResponse, error) {
@@ -24,7 +24,7 @@ func getRandomServerLoginState(t *testing.T, suite Suite) *ServerLoginState {
 
 	ikm := utils.Concat(dh1, dh2, dh3)
 
-	_, _, sessionKey, err := deriveKeys(suite, ikm, utils.RandomBytes(100))
+	_, _, sessionKey, err := deriveKeys(suite.(*opaqueSuite), ikm, utils.RandomBytes(100))
 	test.CheckNoErr(t, err, "deriveKeys error")
 
 	sls.SessionKey = sessionKey
@@ -33,7 +33,7 @@ func getRandomServerLoginState(t *testing.T, suite Suite) *ServerLoginState {
 }
 
 func TestServerLoginStateEncodeDecode(t *testing.T) {
-	suites := []Suite{Ristretto255Suite.New(), P256Suite.New()}
+	suites := []Suite{Ristretto255Suite.New(), P256Suite.New(), Decaf448Suite.New()}
 
 	for _, suite := range suites {
 		t.Run(suite.Group().String(), func(tt *testing.T) {
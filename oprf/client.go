@@ -4,11 +4,22 @@
 
 package oprf
 
-import "github.com/cymony/cryptomony/eccgroup"
+import (
+	"io"
+
+	"github.com/cymony/cryptomony/eccgroup"
+	"github.com/cymony/cryptomony/internal/typedgroup"
+)
 
 type client struct {
 	s    Suite
 	mode ModeType
+	rnd  io.Reader
+}
+
+// SetRandom sets the source of randomness of the blinds, which is crypto/rand if nil.
+func (c *client) SetRandom(r io.Reader) {
+	c.rnd = r
 }
 
 func (c client) validate(finData *FinalizeData, evalRes *EvaluationResponse) error {
@@ -34,8 +45,7 @@ func (c client) blind(inputs [][]byte, blinds []*eccgroup.Scalar) ([]*eccgroup.E
 
 		//nolint:gocritic //not a commented code
 		// blindedElement = blind * inputElement
-		blindedElement := c.s.Group().NewElement().Set(inputElement).Multiply(blinds[i])
-		blindedElements[i] = blindedElement
+		blindedElements[i] = typedgroup.MultiplyInto(inputElement, blinds[i], inputElement)
 	}
 
 	return blindedElements, nil
@@ -5,6 +5,7 @@
 package oprf
 
 import (
+	"io"
 	"math/big"
 
 	"github.com/cymony/cryptomony/eccgroup"
@@ -103,11 +104,20 @@ func (pub *PublicKey) UnmarshalText(s Suite, text []byte) error {
 
 // GenerateKey generates a private key compatible with the suite.
 func GenerateKey(s Suite) (*PrivateKey, error) {
+	return GenerateKeyFrom(s, nil)
+}
+
+// GenerateKeyFrom generates a private key compatible with the suite, from the randomness of the reader.
+// The reader is crypto/rand if nil.
+func GenerateKeyFrom(s Suite, r io.Reader) (*PrivateKey, error) {
 	if !isSuiteAvailable(s) {
 		return nil, ErrInvalidSuite
 	}
 
-	privKey := s.Group().RandomScalar()
+	privKey, err := s.Group().RandomScalarFrom(r)
+	if err != nil {
+		return nil, err
+	}
 
 	return &PrivateKey{s, privKey, nil}, nil
 }
@@ -10,6 +10,7 @@ import (
 	"crypto/subtle"
 
 	"github.com/cymony/cryptomony/eccgroup"
+	"github.com/cymony/cryptomony/internal/typedgroup"
 )
 
 // Client is oprf client instance with mode ModeOPRF
@@ -23,7 +24,7 @@ func NewClient(s Suite) (*Client, error) {
 		return nil, ErrInvalidSuite
 	}
 
-	return &Client{client: client{s, ModeOPRF}}, nil
+	return &Client{client: client{s: s, mode: ModeOPRF}}, nil
 }
 
 // Blind function blinding given inputs, returns FinalizeData for Finaliza function and EvaluationRequest to send server
@@ -97,9 +98,11 @@ func (c *Client) Finalize(finData *FinalizeData, evalRes *EvaluationResponse) ([
 	}
 
 	outputs := make([][]byte, len(finData.Inputs))
+	invBlinds := invertBlinds(finData.Blinds)
+	N := c.s.Group().NewElement()
 
 	for i := range finData.Inputs {
-		out, err := finalizeOPRF(c.client, finData.Inputs[i], finData.Blinds[i], evalRes.EvaluatedElements[i])
+		out, err := finalizeOPRF(c.client, N, finData.Inputs[i], invBlinds[i], evalRes.EvaluatedElements[i])
 		if err != nil {
 			return nil, err
 		}
@@ -170,7 +173,11 @@ func blindOPRF(c client, inputs [][]byte) ([]*eccgroup.Scalar, []*eccgroup.Eleme
 	blinds := make([]*eccgroup.Scalar, len(inputs))
 
 	for i := range inputs {
-		blind := c.s.Group().RandomScalar()
+		blind, err := c.s.Group().RandomScalarFrom(c.rnd)
+		if err != nil {
+			return nil, nil, err
+		}
+
 		blinds[i] = blind
 	}
 
@@ -184,16 +191,18 @@ func blindOPRF(c client, inputs [][]byte) ([]*eccgroup.Scalar, []*eccgroup.Eleme
 
 // https://www.ietf.org/archive/id/draft-irtf-cfrg-voprf-12.html#name-oprf-protocol
 func blindEvaluateOPRF(s server, blindedElement *eccgroup.Element) *eccgroup.Element {
-	evaluatedElement := s.s.Group().NewElement().Set(blindedElement).Multiply(s.privKey.k)
+	evaluatedElement := typedgroup.MultiplyInto(s.s.Group().NewElement(), s.privKey.k, blindedElement)
 	return evaluatedElement
 }
 
 // https://www.ietf.org/archive/id/draft-irtf-cfrg-voprf-12.html#name-oprf-protocol
-func finalizeOPRF(c client, input []byte, blind *eccgroup.Scalar, evaluatedElement *eccgroup.Element) ([]byte, error) {
+// The blind is given inverted, so that a batch of blinds is inverted at once, and N is computed in the given element,
+// reused across the batch.
+func finalizeOPRF(c client, N *eccgroup.Element, input []byte, invBlind *eccgroup.Scalar, evaluatedElement *eccgroup.Element) ([]byte, error) {
 	//nolint:gocritic //it is not commented code
 	// N = G.ScalarInverse(blind) * evaluatedElement
 	// unblindedElement = G.SerializeElement(N)
-	unblindedElement := produceUnblind(c.s.Group(), blind, evaluatedElement)
+	unblindedElement := produceUnblind(N, invBlind, evaluatedElement)
 
 	//nolint:gocritic //it is not commented code
 	// hashInput = I2OSP(len(input), 2) || input || I2OSP(len(unblindedElement), 2) || unblindedElement || "Finalize"
@@ -216,7 +225,7 @@ func evaluateOPRF(s server, input []byte) ([]byte, error) {
 	}
 	//nolint:gocritic //it is not commented code
 	// evaluatedElement = skS * inputElement
-	evaluatedElement := s.s.Group().NewElement().Set(inputElement).Multiply(s.privKey.k)
+	evaluatedElement := typedgroup.MultiplyInto(inputElement, s.privKey.k, inputElement)
 	//nolint:gocritic //it is not commented code
 	// issuedElement = G.SerializeElement(evaluatedElement)
 	issuedElement := evaluatedElement.Encode()
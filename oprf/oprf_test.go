@@ -10,10 +10,15 @@ import (
 	"encoding/binary"
 	"fmt"
 	"log"
+	"sync"
 	"testing"
 
+	"github.com/cymony/cryptomony/drbg"
 	"github.com/cymony/cryptomony/eccgroup"
+	"github.com/cymony/cryptomony/hash"
 	"github.com/cymony/cryptomony/internal/test"
+	"github.com/cymony/cryptomony/msgexpand"
+	"github.com/cymony/cryptomony/xof"
 )
 
 type commonClient interface {
@@ -139,14 +144,110 @@ func testAPI(t *testing.T, server commonServer, client commonClient) {
 	}
 }
 
+// renamedGroup is a group implementation registered under another ciphersuite identifier.
+type renamedGroup struct {
+	eccgroup.GroupImpl
+}
+
+func (r *renamedGroup) Ciphersuite() string {
+	return "oprf-test-ristretto255"
+}
+
+var (
+	registeredSuiteOnce sync.Once
+	registeredSuite     Suite
+)
+
+// newRegisteredSuite returns a suite over a group registered with eccgroup.Register.
+func newRegisteredSuite(t *testing.T) Suite {
+	t.Helper()
+
+	registeredSuiteOnce.Do(func() {
+		group, err := eccgroup.Register(&renamedGroup{eccgroup.Ristretto255Sha512.Impl()})
+		test.CheckNoErr(t, err, "group registration failed")
+
+		registeredSuite, err = NewSuite(0xff01, "OPRF(registered ristretto255, SHA-512)", group, hash.SHA512)
+		test.CheckNoErr(t, err, "suite creation failed")
+	})
+
+	return registeredSuite
+}
+
+var (
+	blake3SuiteOnce sync.Once
+	blake3Suite     Suite
+)
+
+// newBlake3Suite returns a suite hashing with BLAKE3, to the group with a hash-to-curve suite expanding with the
+// BLAKE3 XOF, and in produceHashResult with the BLAKE3 hash.
+func newBlake3Suite(t *testing.T) Suite {
+	t.Helper()
+
+	blake3SuiteOnce.Do(func() {
+		h2c, err := eccgroup.NewHashToCurveSuite(eccgroup.Ristretto255Sha512, msgexpand.NewMessageExpandXOF(xof.BLAKE3, 128))
+		test.CheckNoErr(t, err, "hash-to-curve suite creation failed")
+
+		group, err := h2c.Register()
+		test.CheckNoErr(t, err, "group registration failed")
+
+		blake3Suite, err = NewSuite(0xff03, "OPRF(ristretto255, BLAKE3)", group, hash.BLAKE3)
+		test.CheckNoErr(t, err, "suite creation failed")
+	})
+
+	return blake3Suite
+}
+
+func TestNewSuite(t *testing.T) {
+	group := newRegisteredSuite(t).Group()
+
+	_, err := NewSuite(uint16(SuiteP256Sha256.SuiteID()), "colliding", group, hash.SHA512)
+	test.CheckIsErr(t, err, "suite identifier of the specification should be rejected")
+
+	_, err = NewSuite(0, "zero", group, hash.SHA512)
+	test.CheckIsErr(t, err, "zero suite identifier should be rejected")
+
+	_, err = NewSuite(0xff02, "unavailable group", eccgroup.Group(0xff), hash.SHA512)
+	test.CheckIsErr(t, err, "unavailable group should be rejected")
+
+	_, err = NewSuite(0xff02, "unavailable hash", group, hash.Hashing(0))
+	test.CheckIsErr(t, err, "unavailable hash should be rejected")
+
+	// the suite identifier separates the domains of suites over the same group
+	other, err := NewSuite(0xff02, "other", group, hash.SHA512)
+	test.CheckNoErr(t, err, "suite creation failed")
+
+	input := []byte("input")
+	private, err := DeriveKey(newRegisteredSuite(t), ModeOPRF, []byte("seed seed seed seed seed seed 32"), nil)
+	test.CheckNoErr(t, err, "key derivation failed")
+
+	otherPrivate, err := DeriveKey(other, ModeOPRF, []byte("seed seed seed seed seed seed 32"), nil)
+	test.CheckNoErr(t, err, "key derivation failed")
+
+	s, err := NewServer(newRegisteredSuite(t), private)
+	test.CheckNoErr(t, err, "server creation")
+	out, err := s.FinalEvaluate(input)
+	test.CheckNoErr(t, err, "evaluation failed")
+
+	otherServer, err := NewServer(other, otherPrivate)
+	test.CheckNoErr(t, err, "server creation")
+	otherOut, err := otherServer.FinalEvaluate(input)
+	test.CheckNoErr(t, err, "evaluation failed")
+
+	test.CheckOk(t, !bytes.Equal(out, otherOut), "suites with different identifiers should have different outputs")
+}
+
 func TestAPI(t *testing.T) {
 	info := []byte("shared info")
 
 	for _, suite := range []Suite{
 		SuiteRistretto255Sha512,
+		SuiteDecaf448Shake256,
 		SuiteP256Sha256,
 		SuiteP384Sha384,
 		SuiteP521Sha512,
+		SuiteSecp256k1Sha256,
+		newRegisteredSuite(t),
+		newBlake3Suite(t),
 	} {
 		t.Run(suite.(fmt.Stringer).String(), func(t *testing.T) {
 			private, err := GenerateKey(suite)
@@ -184,6 +285,81 @@ func TestAPI(t *testing.T) {
 	}
 }
 
+// transcript runs the VOPRF and POPRF protocols with the randomness of a DRBG instantiated with the seed, and
+// returns the encoded private key, blinded elements and proofs.
+func transcript(t *testing.T, suite Suite, seed []byte) []byte {
+	t.Helper()
+
+	rnd, err := drbg.NewHMAC(hash.SHA256, seed, []byte("oprf transcript"), nil)
+	test.CheckNoErr(t, err, "drbg instantiation failed")
+
+	private, err := GenerateKeyFrom(suite, rnd)
+	test.CheckNoErr(t, err, "failed private key generation")
+
+	out, err := private.MarshalBinary()
+	test.CheckNoErr(t, err, "private key marshal failed")
+
+	inputs := [][]byte{[]byte("first"), []byte("second")}
+
+	vs, err := NewVerifiableServer(suite, private)
+	test.CheckNoErr(t, err, "server creation")
+	vs.SetRandom(rnd)
+
+	vc, err := NewVerifiableClient(suite, vs.PublicKey())
+	test.CheckNoErr(t, err, "client creation")
+	vc.SetRandom(rnd)
+
+	ps, err := NewPartialObliviousServer(suite, private)
+	test.CheckNoErr(t, err, "server creation")
+	ps.SetRandom(rnd)
+
+	pc, err := NewPartialObliviousClient(suite, ps.PublicKey())
+	test.CheckNoErr(t, err, "client creation")
+	pc.SetRandom(rnd)
+
+	for _, p := range []struct {
+		s commonServer
+		c commonClient
+	}{
+		{vs, vc},
+		{&s1{ps, []byte("info")}, &c1{pc, []byte("info")}},
+	} {
+		finData, evalReq, err := p.c.Blind(inputs)
+		test.CheckNoErr(t, err, "invalid blinding of client")
+
+		eval, err := p.s.BlindEvaluate(evalReq)
+		test.CheckNoErr(t, err, "invalid evaluation of server")
+
+		_, err = p.c.Finalize(finData, eval)
+		test.CheckNoErr(t, err, "invalid finalize of client")
+
+		for _, e := range evalReq.BlindedElements {
+			out = append(out, e.Encode()...)
+		}
+
+		out = append(out, eval.Proof...)
+	}
+
+	return out
+}
+
+func TestRandomReader(t *testing.T) {
+	seed := bytes.Repeat([]byte{0x5a}, 32)
+
+	for _, suite := range []Suite{SuiteRistretto255Sha512, SuiteP256Sha256, SuiteDecaf448Shake256} {
+		t.Run(suite.(fmt.Stringer).String(), func(t *testing.T) {
+			one, two := transcript(t, suite, seed), transcript(t, suite, seed)
+			test.CheckOk(t, bytes.Equal(one, two), "same seed should give the same transcript")
+
+			other := transcript(t, suite, bytes.Repeat([]byte{0xa5}, 32))
+			test.CheckOk(t, !bytes.Equal(one, other), "different seeds should give different transcripts")
+		})
+	}
+
+	_, err := GenerateKeyFrom(SuiteP256Sha256, bytes.NewReader(nil))
+	test.CheckIsErr(t, err, "empty reader should fail")
+}
+
 func TestErrors(t *testing.T) {
 	goodID := SuiteP256Sha256
 	strErrNil := "must be nil"
@@ -362,9 +538,11 @@ func Example_oprf() {
 func BenchmarkAPI(b *testing.B) {
 	for _, suite := range []Suite{
 		SuiteRistretto255Sha512,
+		SuiteDecaf448Shake256,
 		SuiteP256Sha256,
 		SuiteP384Sha384,
 		SuiteP521Sha512,
+		SuiteSecp256k1Sha256,
 	} {
 		key, err := GenerateKey(suite)
 		test.CheckNoErr(b, err, "failed key generation")
@@ -407,24 +585,32 @@ func benchAPI(b *testing.B, server commonServer, client commonClient) {
 	test.CheckNoErr(b, err, "failed client finalize")
 
 	b.Run("Client/Request", func(b *testing.B) {
+		b.ReportAllocs()
+
 		for i := 0; i < b.N; i++ {
 			_, _, _ = client.Blind(inputs) //nolint:errcheck // benchmark
 		}
 	})
 
 	b.Run("Server/Evaluate", func(b *testing.B) {
+		b.ReportAllocs()
+
 		for i := 0; i < b.N; i++ {
 			_, _ = server.BlindEvaluate(evalReq) //nolint:errcheck // benchmark
 		}
 	})
 
 	b.Run("Client/Finalize", func(b *testing.B) {
+		b.ReportAllocs()
+
 		for i := 0; i < b.N; i++ {
 			_, _ = client.Finalize(finData, eval) //nolint:errcheck // benchmark
 		}
 	})
 
 	b.Run("Server/VerifyFinalize", func(b *testing.B) {
+		b.ReportAllocs()
+
 		for i := 0; i < b.N; i++ {
 			for j := range inputs {
 				server.VerifyFinalize(inputs[j], clientOutputs[j])
@@ -433,6 +619,8 @@ func benchAPI(b *testing.B, server commonServer, client commonClient) {
 	})
 
 	b.Run("Server/FullEvaluate", func(b *testing.B) {
+		b.ReportAllocs()
+
 		for i := 0; i < b.N; i++ {
 			for j := range inputs {
 				_, _ = server.FinalEvaluate(inputs[j]) //nolint:errcheck // benchmark
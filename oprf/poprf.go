@@ -8,6 +8,7 @@ import (
 	"crypto/subtle"
 
 	"github.com/cymony/cryptomony/eccgroup"
+	"github.com/cymony/cryptomony/internal/typedgroup"
 )
 
 // PartialObliviousClient is oprf client instance with mode ModePOPRF
@@ -27,7 +28,7 @@ func NewPartialObliviousClient(s Suite, sPub *PublicKey) (*PartialObliviousClien
 		return nil, ErrEmptyKey
 	}
 
-	return &PartialObliviousClient{client: client{s, ModePOPRF}, sPubKey: sPub}, nil
+	return &PartialObliviousClient{client: client{s: s, mode: ModePOPRF}, sPubKey: sPub}, nil
 }
 
 // Blind function blinding given inputs, returns FinalizeData for Finaliza function and EvaluationRequest to send server
@@ -206,13 +207,12 @@ func blindEvaluatePOPRF(s server, blindedElements []*eccgroup.Element, info []by
 	}
 
 	evaluatedElements := make([]*eccgroup.Element, len(blindedElements))
+	invT := s.s.Group().NewScalar().Set(t).Invert()
 
 	for i := range blindedElements {
 		//nolint:gocritic // it is not commented code
 		// evaluatedElement = G.ScalarInverse(t) * blindedElement
-		invT := s.s.Group().NewScalar().Set(t).Invert()
-		evaluatedElement := s.s.Group().NewElement().Set(blindedElements[i]).Multiply(invT)
-		evaluatedElements[i] = evaluatedElement
+		evaluatedElements[i] = typedgroup.MultiplyInto(s.s.Group().NewElement(), invT, blindedElements[i])
 	}
 	//nolint:gocritic // it is not commented code
 	// tweakedKey = G.ScalarBaseMult(t)
@@ -220,7 +220,7 @@ func blindEvaluatePOPRF(s server, blindedElements []*eccgroup.Element, info []by
 
 	//nolint:gocritic // it is not commented code
 	// proof = GenerateProof(t, G.Generator(), tweakedKey, evaluatedElements, blindedElements)
-	proof, err := produceProof(s.s.Group(), s.mode, s.s, t, s.s.Group().Base(), tweakedKey, evaluatedElements, blindedElements, nil)
+	proof, err := produceProof(s.s.Group(), s.mode, s.s, t, s.s.Group().Base(), tweakedKey, evaluatedElements, blindedElements, nil, s.rnd)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -235,15 +235,14 @@ func finalizePOPRF(c client, blinds []*eccgroup.Scalar, inputs [][]byte, info []
 	}
 
 	outputs := make([][]byte, len(inputs))
+	invBlinds := invertBlinds(blinds)
+	N := c.s.Group().NewElement()
 
 	for i := range inputs {
 		//nolint:gocritic // it is not commented code
 		// N = G.ScalarInverse(blind) * evaluatedElement
-		invBlind := c.s.Group().NewScalar().Set(blinds[i]).Invert()
-		N := c.s.Group().NewElement().Set(evaluatedElements[i]).Multiply(invBlind)
-		//nolint:gocritic // it is not commented code
 		// unblindedElement = G.SerializeElement(N)
-		unblindedElement := N.Encode()
+		unblindedElement := produceUnblind(N, invBlinds[i], evaluatedElements[i])
 
 		//nolint:gocritic // it is not commented code
 		// hashInput = I2OSP(len(input), 2) || input || I2OSP(len(info), 2) || info || I2OSP(len(unblindedElement), 2) || unblindedElement || "Finalize"
@@ -285,7 +284,7 @@ func evaluatePOPRF(s server, input, info []byte) ([]byte, error) {
 	//nolint:gocritic // it is not commented code
 	// evaluatedElement = G.ScalarInverse(t) * inputElement
 	invT := s.s.Group().NewScalar().Set(t).Invert()
-	evaluatedElement := s.s.Group().NewElement().Set(inputElement).Multiply(invT)
+	evaluatedElement := typedgroup.MultiplyInto(inputElement, invT, inputElement)
 
 	//nolint:gocritic // it is not commented code
 	// issuedElement = G.SerializeElement(evaluatedElement)
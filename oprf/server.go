@@ -4,10 +4,18 @@
 
 package oprf
 
+import "io"
+
 type server struct {
 	privKey *PrivateKey
 	s       Suite
 	mode    ModeType
+	rnd     io.Reader
+}
+
+// SetRandom sets the source of randomness of the proofs, which is crypto/rand if nil.
+func (s *server) SetRandom(r io.Reader) {
+	s.rnd = r
 }
 
 func (s server) PublicKey() *PublicKey { return s.privKey.Public() }
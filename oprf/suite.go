@@ -14,6 +14,10 @@ var (
 	// See https://www.ietf.org/archive/id/draft-irtf-cfrg-voprf-12.html#name-oprfristretto255-sha-512
 	SuiteRistretto255Sha512 Suite = &suite{suiteID: 0x0001, group: eccgroup.Ristretto255Sha512, hash: hash.SHA512, strRep: "OPRF(ristretto255, SHA-512)"}
 
+	// SuiteDecaf448Shake256 suite identify the OPRF with Decaf448 and SHAKE256.
+	// See https://www.ietf.org/archive/id/draft-irtf-cfrg-voprf-12.html#name-oprfdecaf448-shake-256
+	SuiteDecaf448Shake256 Suite = &suite{suiteID: 0x0002, group: eccgroup.Decaf448Shake256, hash: hash.SHAKE256, strRep: "OPRF(decaf448, SHAKE-256)"}
+
 	// SuiteP256Sha256 suite identify the OPRF with P256 and SHA256.
 	// See https://www.ietf.org/archive/id/draft-irtf-cfrg-voprf-12.html#name-oprfp-256-sha-256
 	SuiteP256Sha256 Suite = &suite{suiteID: 0x0003, group: eccgroup.P256Sha256, hash: hash.SHA256, strRep: "OPRF(P-256, SHA-256)"}
@@ -25,6 +29,10 @@ var (
 	// SuiteP521Sha512 suite identify the OPRF with P521 and SHA512.
 	// See https://www.ietf.org/archive/id/draft-irtf-cfrg-voprf-12.html#name-oprfp-521-sha-512
 	SuiteP521Sha512 Suite = &suite{suiteID: 0x0005, group: eccgroup.P521Sha512, hash: hash.SHA512, strRep: "OPRF(P-521, SHA-512)"}
+
+	// SuiteSecp256k1Sha256 suite identify the OPRF with secp256k1 and SHA256.
+	// It is not defined by the specification, and its identifier is only meaningful between users of this package.
+	SuiteSecp256k1Sha256 Suite = &suite{suiteID: 0x0006, group: eccgroup.Secp256k1Sha256, hash: hash.SHA256, strRep: "OPRF(secp256k1, SHA-256)"}
 )
 
 // Suite is an interface that identify underlying prime-order curve and hash
@@ -37,10 +45,28 @@ type Suite interface {
 }
 
 type suite struct {
-	strRep  string
-	suiteID uint16
-	group   eccgroup.Group
-	hash    hash.Hashing
+	strRep     string
+	suiteID    uint16
+	group      eccgroup.Group
+	hash       hash.Hashing
+	registered bool
+}
+
+// NewSuite returns a Suite over the given group, typically registered with eccgroup.Register, and hash function.
+// The suite identifier is part of the context string of the protocol, and so of its domain separation tags.
+// It must be unique, and is rejected if it is the identifier of one of the suites of this package.
+func NewSuite(suiteID uint16, name string, group eccgroup.Group, h hash.Hashing) (Suite, error) {
+	for _, s := range []Suite{SuiteP256Sha256, SuiteP384Sha384, SuiteP521Sha512, SuiteRistretto255Sha512, SuiteDecaf448Shake256, SuiteSecp256k1Sha256} {
+		if s.SuiteID() == int(suiteID) {
+			return nil, ErrInvalidSuite
+		}
+	}
+
+	if suiteID == 0 || !group.Available() || h == 0 || !h.Available() {
+		return nil, ErrInvalidSuite
+	}
+
+	return &suite{suiteID: suiteID, strRep: name, group: group, hash: h, registered: true}, nil
 }
 
 func (s *suite) String() string {
@@ -63,9 +89,10 @@ func (s *suite) blockExternalImplement() {}
 
 func isSuiteAvailable(s Suite) bool {
 	switch s {
-	case SuiteP256Sha256, SuiteP384Sha384, SuiteP521Sha512, SuiteRistretto255Sha512:
+	case SuiteP256Sha256, SuiteP384Sha384, SuiteP521Sha512, SuiteRistretto255Sha512, SuiteDecaf448Shake256, SuiteSecp256k1Sha256:
 		return true
 	default:
-		return false
+		sc, ok := s.(*suite)
+		return ok && sc.registered
 	}
 }
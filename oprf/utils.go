@@ -5,18 +5,22 @@
 package oprf
 
 import (
+	"io"
 	"math/big"
 
 	"github.com/cymony/cryptomony/dleq"
 	"github.com/cymony/cryptomony/eccgroup"
 	"github.com/cymony/cryptomony/hash"
+	"github.com/cymony/cryptomony/internal/typedgroup"
 	"github.com/cymony/cryptomony/utils"
 )
 
-func produceProof(g eccgroup.Group, mode ModeType, s Suite, k *eccgroup.Scalar, a, b *eccgroup.Element, c, d []*eccgroup.Element, rnd *eccgroup.Scalar) ([]byte, error) {
+func produceProof(g eccgroup.Group, mode ModeType, s Suite, k *eccgroup.Scalar, a, b *eccgroup.Element, c, d []*eccgroup.Element, rnd *eccgroup.Scalar, r io.Reader) ([]byte, error) {
 	cnf := &dleq.Configuration{
 		Group: g,
 		DST:   createContextString(mode, s),
+		Hash:  s.Hash(),
+		Rand:  r,
 	}
 
 	prover, err := dleq.NewProver(cnf)
@@ -45,6 +49,7 @@ func produceVerify(g eccgroup.Group, mode ModeType, s Suite, a, b *eccgroup.Elem
 	cnf := &dleq.Configuration{
 		Group: g,
 		DST:   createContextString(mode, s),
+		Hash:  s.Hash(),
 	}
 
 	verifier, err := dleq.NewVerifier(cnf)
@@ -59,11 +64,23 @@ func produceVerify(g eccgroup.Group, mode ModeType, s Suite, a, b *eccgroup.Elem
 	return nil
 }
 
-func produceUnblind(g eccgroup.Group, blind *eccgroup.Scalar, evaluatedElement *eccgroup.Element) []byte {
+// invertBlinds returns the inverses of the blinds, computed with a single scalar inversion.
+func invertBlinds(blinds []*eccgroup.Scalar) []*eccgroup.Scalar {
+	invBlinds := make([]*eccgroup.Scalar, len(blinds))
+	for i := range blinds {
+		invBlinds[i] = blinds[i].Copy()
+	}
+
+	eccgroup.BatchInvert(invBlinds)
+
+	return invBlinds
+}
+
+// produceUnblind computes N in the given element, which callers reuse across a batch.
+func produceUnblind(N *eccgroup.Element, invBlind *eccgroup.Scalar, evaluatedElement *eccgroup.Element) []byte {
 	//nolint:gocritic // it is not commented code
 	// N = G.ScalarInverse(blind) * evaluatedElement
-	invBlind := g.NewScalar().Set(blind).Invert()
-	N := g.NewElement().Set(evaluatedElement).Multiply(invBlind)
+	typedgroup.MultiplyInto(N, invBlind, evaluatedElement)
 
 	//nolint:gocritic // it is not commented code
 	// unblindedElement = G.SerializeElement(N)
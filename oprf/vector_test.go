@@ -120,6 +120,8 @@ func getSuite(id int) (Suite, error) {
 	switch id {
 	case SuiteRistretto255Sha512.SuiteID():
 		return SuiteRistretto255Sha512, nil
+	case SuiteDecaf448Shake256.SuiteID():
+		return SuiteDecaf448Shake256, nil
 	case SuiteP256Sha256.SuiteID():
 		return SuiteP256Sha256, nil
 	case SuiteP384Sha384.SuiteID():
@@ -266,7 +268,8 @@ func (v *vector) test(t *testing.T) {
 					ss.server.PublicKey().e,
 					evalReq.BlindedElements,
 					eval.EvaluatedElements,
-					randomness)
+					randomness,
+					nil)
 				test.CheckNoErr(t, err, "failed proof generation")
 			case ModePOPRF:
 				ss := server.(*s1) //nolint:errcheck //no need to check err
@@ -280,7 +283,8 @@ func (v *vector) test(t *testing.T) {
 					tweakedKey,
 					eval.EvaluatedElements,
 					evalReq.BlindedElements,
-					randomness)
+					randomness,
+					nil)
 				test.CheckNoErr(t, err, "failed proof generation")
 			}
 
@@ -26,7 +26,7 @@ func NewVerifiableClient(s Suite, sPub *PublicKey) (*VerifiableClient, error) {
 		return nil, ErrEmptyKey
 	}
 
-	return &VerifiableClient{client: client{s, ModeVOPRF}, sPubKey: sPub}, nil
+	return &VerifiableClient{client: client{s: s, mode: ModeVOPRF}, sPubKey: sPub}, nil
 }
 
 // Blind function blinding given inputs, returns FinalizeData for Finaliza function and EvaluationRequest to send server
@@ -162,7 +162,7 @@ func blindEvaluateVOPRF(s server, blindedElements []*eccgroup.Element) ([]*eccgr
 
 	//nolint:gocritic // it is not commented code
 	// proof = GenerateProof(skS, G.Generator(), pkS, blindedElements, evaluatedElements)
-	proof, err := produceProof(s.s.Group(), s.mode, s.s, s.privKey.k, s.s.Group().Base(), s.privKey.Public().e, blindedElements, evaluatedEls, nil)
+	proof, err := produceProof(s.s.Group(), s.mode, s.s, s.privKey.k, s.s.Group().Base(), s.privKey.Public().e, blindedElements, evaluatedEls, nil, s.rnd)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -177,9 +177,11 @@ func finalizeVOPRF(c client, inputs [][]byte, blinds []*eccgroup.Scalar, serverP
 	}
 
 	outputs := make([][]byte, len(inputs))
+	invBlinds := invertBlinds(blinds)
+	N := c.s.Group().NewElement()
 
 	for i := range inputs {
-		out, err := finalizeOPRF(c, inputs[i], blinds[i], evaluatedElements[i])
+		out, err := finalizeOPRF(c, N, inputs[i], invBlinds[i], evaluatedElements[i])
 		if err != nil {
 			return nil, err
 		}
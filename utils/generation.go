@@ -7,6 +7,7 @@ package utils
 import (
 	"crypto/rand"
 	"fmt"
+	"io"
 )
 
 // RandomBytes returns random bytes of with given length (wrapper for crypto/rand).
@@ -19,3 +20,17 @@ func RandomBytes(length int) []byte {
 
 	return random
 }
+
+// RandomBytesFrom returns length bytes read from the reader, or from crypto/rand if it is nil.
+func RandomBytesFrom(r io.Reader, length int) ([]byte, error) {
+	if r == nil {
+		return RandomBytes(length), nil
+	}
+
+	random := make([]byte, length)
+	if _, err := io.ReadFull(r, random); err != nil {
+		return nil, fmt.Errorf("unexpected error in generating random bytes : %w", err)
+	}
+
+	return random, nil
+}
@@ -5,6 +5,7 @@
 package utils_test
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/cymony/cryptomony/internal/test"
@@ -54,3 +55,18 @@ func TestRandomBytes(t *testing.T) {
 		}
 	}
 }
+
+func TestRandomBytesFrom(t *testing.T) {
+	src := bytes.Repeat([]byte{0x01, 0x02}, 16)
+
+	got, err := utils.RandomBytesFrom(bytes.NewReader(src), 24)
+	test.CheckNoErr(t, err, "read failed")
+	test.CheckOk(t, bytes.Equal(got, src[:24]), "bytes should come from the reader")
+
+	_, err = utils.RandomBytesFrom(bytes.NewReader(src), 40)
+	test.CheckIsErr(t, err, "short reader should fail")
+
+	got, err = utils.RandomBytesFrom(nil, 32)
+	test.CheckNoErr(t, err, "nil reader should use crypto/rand")
+	test.CheckOk(t, len(got) == 32, "wrong length")
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xof
+
+import (
+	"github.com/cymony/cryptomony/internal/blake3"
+)
+
+const (
+	strBLAKE3          = "BLAKE3"
+	strBLAKE3Keyed     = "BLAKE3-keyed"
+	strBLAKE3DeriveKey = "BLAKE3-derive-key"
+)
+
+// NewBLAKE3Keyed returns a new BLAKE3 XOF in the keyed hash mode, and an error if the key is not 32 bytes long.
+func NewBLAKE3Keyed(key []byte) (XOF, error) {
+	h, err := blake3.NewKeyed(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blake3XOF{h: h, str: strBLAKE3Keyed}, nil
+}
+
+// NewBLAKE3DeriveKey returns a new BLAKE3 XOF in the key derivation mode with the context string, to which the key
+// material is written. The context should be hardcoded, globally unique, and application-specific.
+func NewBLAKE3DeriveKey(context string) XOF {
+	return &blake3XOF{h: blake3.NewDeriveKey(context), str: strBLAKE3DeriveKey}
+}
+
+type blake3XOF struct {
+	h   *blake3.Hasher
+	r   *blake3.OutputReader // set on the first Read
+	str string
+}
+
+func (b *blake3XOF) Write(p []byte) (int, error) {
+	if b.r != nil {
+		panic("xof: Write after Read")
+	}
+
+	return b.h.Write(p)
+}
+
+func (b *blake3XOF) Read(p []byte) (int, error) {
+	if b.r == nil {
+		b.r = b.h.XOF()
+	}
+
+	return b.r.Read(p)
+}
+
+func (b *blake3XOF) Clone() XOF {
+	c := &blake3XOF{h: b.h.Clone(), str: b.str}
+
+	if b.r != nil {
+		r := *b.r
+		c.r = &r
+	}
+
+	return c
+}
+
+func (b *blake3XOF) Reset() {
+	b.h.Reset()
+	b.r = nil
+}
+
+func (b *blake3XOF) MustWriteAll(inputs ...[]byte) error {
+	return mustWriteAll(b, inputs...)
+}
+
+func (b *blake3XOF) MustReadFull(buf []byte) error {
+	return mustReadFull(b, buf)
+}
+
+func (b *blake3XOF) String() string {
+	return b.str
+}
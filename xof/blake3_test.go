@@ -0,0 +1,66 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xof_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cymony/cryptomony/internal/test"
+	"github.com/cymony/cryptomony/xof"
+)
+
+// Vectors of https://github.com/BLAKE3-team/BLAKE3/blob/master/test_vectors/test_vectors.json, with an empty input.
+func TestBLAKE3(t *testing.T) {
+	keyed, err := xof.NewBLAKE3Keyed([]byte("whats the Elvish word for friend"))
+	test.CheckNoErr(t, err, "keyed XOF creation failed")
+
+	for _, v := range []struct {
+		x   xof.XOF
+		str string
+		out string
+	}{
+		{
+			x:   xof.BLAKE3.New(),
+			str: "BLAKE3",
+			out: "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262",
+		},
+		{
+			x:   keyed,
+			str: "BLAKE3-keyed",
+			out: "92b2b75604ed3c761f9d6f62392c8a9227ad0ea3f09573e783f1498a4ed60d26",
+		},
+		{
+			x:   xof.NewBLAKE3DeriveKey("BLAKE3 2019-12-27 16:29:52 test vectors context"),
+			str: "BLAKE3-derive-key",
+			out: "2cc39783c223154fea8dfb7c1b1660f2ac2dcbd1c1de8277b0b0dd39b7e50d7d",
+		},
+	} {
+		t.Run(v.str, func(t *testing.T) {
+			test.CheckOk(t, v.x.String() == v.str, "wrong XOF name "+v.x.String())
+
+			want := hexDecode(t, v.out)
+			long := make([]byte, 200)
+			test.CheckNoErr(t, v.x.MustReadFull(long), "read err not expected")
+
+			if !bytes.Equal(long[:len(want)], want) {
+				test.Report(t, long[:len(want)], want)
+			}
+
+			// the output continues after a Clone and restarts after a Reset
+			clone := v.x.Clone()
+			next := make([]byte, 10)
+			test.CheckNoErr(t, clone.MustReadFull(next), "read err not expected")
+			test.CheckOk(t, !bytes.Equal(next, long[:10]), "clone should continue the output")
+
+			v.x.Reset()
+			test.CheckNoErr(t, v.x.MustReadFull(next), "read err not expected")
+			test.CheckOk(t, bytes.Equal(next, long[:10]), "reset should restart the output")
+		})
+	}
+
+	_, err = xof.NewBLAKE3Keyed([]byte("short key"))
+	test.CheckIsErr(t, err, "short key should be rejected")
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xof
+
+import (
+	"runtime"
+	"sync"
+)
+
+const (
+	// chunkSize is the size of the chunks of the KangarooTwelve tree.
+	chunkSize = 8192
+
+	// domains of the KangarooTwelve nodes
+	domainSingle = 0x07
+	domainFinal  = 0x06
+	domainLeaf   = 0x0B
+
+	strKT128 = "KT128"
+	strKT256 = "KT256"
+)
+
+// NewKT128 returns a new KT128 (KangarooTwelve) XOF with the customization string.
+// Inputs longer than 8 KiB are hashed as a tree, whose leaves are hashed concurrently.
+func NewKT128(customization []byte) XOF {
+	return newKangarooTwelve(rate128, 32, customization, strKT128) //nolint:gomnd //256-bit chaining values
+}
+
+// NewKT256 returns a new KT256 XOF with the customization string.
+// Inputs longer than 8 KiB are hashed as a tree, whose leaves are hashed concurrently.
+func NewKT256(customization []byte) XOF {
+	return newKangarooTwelve(rate256, 64, customization, strKT256) //nolint:gomnd //512-bit chaining values
+}
+
+// kangarooTwelve streams the input S = M || C || length_encode(|C|) to the final node, until it is longer than
+// a chunk, and then to the leaves, whose chaining values are absorbed by the final node.
+type kangarooTwelve struct {
+	final     *turboSHAKE
+	leaf      *turboSHAKE // current leaf, if leafLen > 0
+	rate      int
+	cvSize    int
+	custom    []byte
+	written   int    // bytes written to the first chunk
+	tree      bool   // whether S is longer than a chunk
+	leafLen   int    // bytes written to the current leaf
+	leaves    uint64 // number of chaining values absorbed by the final node
+	squeezing bool
+	str       string
+}
+
+func newKangarooTwelve(rate, cvSize int, customization []byte, str string) *kangarooTwelve {
+	return &kangarooTwelve{
+		final:  newTurboSHAKE(rate, domainSingle, str),
+		rate:   rate,
+		cvSize: cvSize,
+		custom: append([]byte(nil), customization...),
+		str:    str,
+	}
+}
+
+func (k *kangarooTwelve) newLeaf() *turboSHAKE {
+	return newTurboSHAKE(k.rate, domainLeaf, k.str)
+}
+
+func (k *kangarooTwelve) Write(p []byte) (int, error) {
+	if k.squeezing {
+		panic("xof: Write after Read")
+	}
+
+	k.write(p)
+
+	return len(p), nil
+}
+
+func (k *kangarooTwelve) write(p []byte) {
+	if k.written < chunkSize {
+		n := chunkSize - k.written
+		if n > len(p) {
+			n = len(p)
+		}
+
+		_, _ = k.final.Write(p[:n]) //nolint:errcheck //sponge writes never fail
+		k.written += n
+		p = p[n:]
+	}
+
+	if len(p) == 0 {
+		return
+	}
+
+	if !k.tree {
+		// S_0 || 0x03 || 0x00^7
+		_, _ = k.final.Write([]byte{0x03, 0, 0, 0, 0, 0, 0, 0}) //nolint:errcheck //sponge writes never fail
+		k.final.domain = domainFinal
+		k.tree = true
+	}
+
+	// complete the current leaf first
+	if k.leafLen > 0 {
+		n := chunkSize - k.leafLen
+		if n > len(p) {
+			n = len(p)
+		}
+
+		_, _ = k.leaf.Write(p[:n]) //nolint:errcheck //sponge writes never fail
+		k.leafLen += n
+		p = p[n:]
+
+		if k.leafLen < chunkSize {
+			return
+		}
+
+		k.absorbLeaf(k.leaf)
+	}
+
+	full := len(p) - len(p)%chunkSize
+	if full > 0 {
+		k.hashLeaves(p[:full])
+	}
+
+	if p = p[full:]; len(p) > 0 {
+		k.leaf = k.newLeaf()
+		_, _ = k.leaf.Write(p) //nolint:errcheck //sponge writes never fail
+		k.leafLen = len(p)
+	}
+}
+
+// absorbLeaf absorbs the chaining value of the leaf in the final node.
+func (k *kangarooTwelve) absorbLeaf(leaf *turboSHAKE) {
+	cv := make([]byte, k.cvSize)
+	_, _ = leaf.Read(cv)     //nolint:errcheck //sponge reads never fail
+	_, _ = k.final.Write(cv) //nolint:errcheck //sponge writes never fail
+
+	k.leaves++
+	k.leaf = nil
+	k.leafLen = 0
+}
+
+// hashLeaves absorbs the chaining values of the full chunks in data, computed concurrently.
+func (k *kangarooTwelve) hashLeaves(data []byte) {
+	n := len(data) / chunkSize
+	cvs := make([]byte, n*k.cvSize)
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func(w int) {
+			defer wg.Done()
+
+			for i := w; i < n; i += workers {
+				leaf := k.newLeaf()
+				_, _ = leaf.Write(data[i*chunkSize : (i+1)*chunkSize]) //nolint:errcheck //sponge writes never fail
+				_, _ = leaf.Read(cvs[i*k.cvSize : (i+1)*k.cvSize])     //nolint:errcheck //sponge reads never fail
+			}
+		}(w)
+	}
+
+	wg.Wait()
+
+	_, _ = k.final.Write(cvs) //nolint:errcheck //sponge writes never fail
+	k.leaves += uint64(n)
+}
+
+func (k *kangarooTwelve) Read(p []byte) (int, error) {
+	if !k.squeezing {
+		k.write(k.custom)
+		k.write(lengthEncode(uint64(len(k.custom))))
+
+		if k.tree {
+			if k.leafLen > 0 {
+				k.absorbLeaf(k.leaf)
+			}
+
+			_, _ = k.final.Write(lengthEncode(k.leaves)) //nolint:errcheck //sponge writes never fail
+			_, _ = k.final.Write([]byte{0xFF, 0xFF})     //nolint:errcheck //sponge writes never fail
+		}
+
+		k.squeezing = true
+	}
+
+	return k.final.Read(p)
+}
+
+// lengthEncode returns length_encode(x), the minimal big-endian encoding of x followed by its length.
+// Unlike right_encode, 0 is encoded with no byte.
+func lengthEncode(x uint64) []byte {
+	if x == 0 {
+		return []byte{0}
+	}
+
+	return rightEncode(x)
+}
+
+func (k *kangarooTwelve) Clone() XOF {
+	c := *k
+
+	c.final = k.final.Clone().(*turboSHAKE) //nolint:forcetypeassert //same type
+	if k.leaf != nil {
+		c.leaf = k.leaf.Clone().(*turboSHAKE) //nolint:forcetypeassert //same type
+	}
+
+	return &c
+}
+
+func (k *kangarooTwelve) Reset() {
+	*k = *newKangarooTwelve(k.rate, k.cvSize, k.custom, k.str)
+}
+
+func (k *kangarooTwelve) MustWriteAll(inputs ...[]byte) error {
+	return mustWriteAll(k, inputs...)
+}
+
+func (k *kangarooTwelve) MustReadFull(buf []byte) error {
+	return mustReadFull(k, buf)
+}
+
+func (k *kangarooTwelve) String() string {
+	return k.str
+}
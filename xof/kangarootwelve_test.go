@@ -0,0 +1,164 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xof_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cymony/cryptomony/internal/test"
+	"github.com/cymony/cryptomony/xof"
+)
+
+// ptn returns the pattern 00 01 .. FA 00 01 .. of RFC 9861 test vectors, truncated to n bytes.
+func ptn(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i % 0xFB)
+	}
+
+	return b
+}
+
+func repeat(b byte, n int) []byte {
+	return bytes.Repeat([]byte{b}, n)
+}
+
+// Test vectors of https://www.rfc-editor.org/rfc/rfc9861.html#section-5
+func TestKangarooTwelve(t *testing.T) {
+	for _, v := range []struct {
+		name   string
+		newXOF func() xof.XOF
+		msg    []byte
+		out    string
+	}{
+		{
+			name:   "TurboSHAKE128(M=`00`^0, D=`1F`)",
+			newXOF: xof.TurboSHAKE128.New,
+			out:    "1e415f1c5983aff2169217277d17bb538cd945a397ddec541f1ce41af2c1b74c",
+		},
+		{
+			name:   "TurboSHAKE256(M=`00`^0, D=`1F`)",
+			newXOF: xof.TurboSHAKE256.New,
+			out: "367a329dafea871c7802ec67f905ae13c57695dc2c6663c61035f59a18f8e7db" +
+				"11edc0e12e91ea60eb6b32df06dd7f002fbafabb6e13ec1cc20d995547600db0",
+		},
+		{
+			name:   "KT128(M=`00`^0, C=`00`^0)",
+			newXOF: xof.KT128.New,
+			out:    "1ac2d450fc3b4205d19da7bfca1b37513c0803577ac7167f06fe2ce1f0ef39e5",
+		},
+		{
+			name:   "KT128(M=`00`^0, C=`00`^0), 64 bytes",
+			newXOF: xof.KT128.New,
+			out: "1ac2d450fc3b4205d19da7bfca1b37513c0803577ac7167f06fe2ce1f0ef39e5" +
+				"4269c056b8c82e48276038b6d292966cc07a3d4645272e31ff38508139eb0a71",
+		},
+		{name: "KT128(M=ptn(1))", newXOF: xof.KT128.New, msg: ptn(1), out: "2bda92450e8b147f8a7cb629e784a058efca7cf7d8218e02d345dfaa65244a1f"},
+		{name: "KT128(M=ptn(17))", newXOF: xof.KT128.New, msg: ptn(17), out: "6bf75fa2239198db4772e36478f8e19b0f371205f6a9a93a273f51df37122888"},
+		{name: "KT128(M=ptn(17^2))", newXOF: xof.KT128.New, msg: ptn(17 * 17), out: "0c315ebcdedbf61426de7dcf8fb725d1e74675d7f5327a5067f367b108ecb67c"},
+		{name: "KT128(M=ptn(17^3))", newXOF: xof.KT128.New, msg: ptn(17 * 17 * 17), out: "cb552e2ec77d9910701d578b457ddf772c12e322e4ee7fe417f92c758f0d59d0"},
+		{name: "KT128(M=ptn(17^4))", newXOF: xof.KT128.New, msg: ptn(17 * 17 * 17 * 17), out: "8701045e22205345ff4dda05555cbb5c3af1a771c2b89baef37db43d9998b9fe"},
+		{name: "KT128(M=ptn(17^5))", newXOF: xof.KT128.New, msg: ptn(17 * 17 * 17 * 17 * 17), out: "844d610933b1b9963cbdeb5ae3b6b05cc7cbd67ceedf883eb678a0a8e0371682"},
+		{name: "KT128(M=ptn(17^6))", newXOF: xof.KT128.New, msg: ptn(17 * 17 * 17 * 17 * 17 * 17), out: "3c390782a8a4e89fa6367f72feaaf13255c8d95878481d3cd8ce85f58e880af8"},
+		{
+			name:   "KT128(M=`00`^0, C=ptn(1))",
+			newXOF: func() xof.XOF { return xof.NewKT128(ptn(1)) },
+			out:    "fab658db63e94a246188bf7af69a133045f46ee984c56e3c3328caaf1aa1a583",
+		},
+		{
+			name:   "KT128(M=`FF`, C=ptn(41))",
+			newXOF: func() xof.XOF { return xof.NewKT128(ptn(41)) },
+			msg:    repeat(0xFF, 1),
+			out:    "d848c5068ced736f4462159b9867fd4c20b808acc3d5bc48e0b06ba0a3762ec4",
+		},
+		{
+			name:   "KT128(M=`FF FF FF`, C=ptn(41^2))",
+			newXOF: func() xof.XOF { return xof.NewKT128(ptn(41 * 41)) },
+			msg:    repeat(0xFF, 3),
+			out:    "c389e5009ae57120854c2e8c64670ac01358cf4c1baf89447a724234dc7ced74",
+		},
+		{
+			name:   "KT128(M=`FF`^7, C=ptn(41^3))",
+			newXOF: func() xof.XOF { return xof.NewKT128(ptn(41 * 41 * 41)) },
+			msg:    repeat(0xFF, 7),
+			out:    "75d2f86a2e644566726b4fbcfc5657b9dbcf070c7b0dca06450ab291d7443bcf",
+		},
+		{name: "KT128(M=ptn(8191))", newXOF: xof.KT128.New, msg: ptn(8191), out: "1b577636f723643e990cc7d6a659837436fd6a103626600eb8301cd1dbe553d6"},
+		{name: "KT128(M=ptn(8192))", newXOF: xof.KT128.New, msg: ptn(8192), out: "48f256f6772f9edfb6a8b661ec92dc93b95ebd05a08a17b39ae3490870c926c3"},
+		{
+			name:   "KT128(M=ptn(8192), C=ptn(8189))",
+			newXOF: func() xof.XOF { return xof.NewKT128(ptn(8189)) },
+			msg:    ptn(8192),
+			out:    "3ed12f70fb05ddb58689510ab3e4d23c6c6033849aa01e1d8c220a297fedcd0b",
+		},
+		{
+			name:   "KT128(M=ptn(8192), C=ptn(8190))",
+			newXOF: func() xof.XOF { return xof.NewKT128(ptn(8190)) },
+			msg:    ptn(8192),
+			out:    "6a7c1b6a5cd0d8c9ca943a4a216cc64604559a2ea45f78570a15253d67ba00ae",
+		},
+		{
+			name:   "KT256(M=`00`^0, C=`00`^0)",
+			newXOF: xof.KT256.New,
+			out: "b23d2e9cea9f4904e02bec06817fc10ce38ce8e93ef4c89e6537076af8646404" +
+				"e3e8b68107b8833a5d30490aa33482353fd4adc7148ecb782855003aaebde4a9",
+		},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			want := hexDecode(t, v.out)
+
+			x := v.newXOF()
+			test.CheckNoErr(t, x.MustWriteAll(v.msg), "write err not expected")
+
+			got := make([]byte, len(want))
+			test.CheckNoErr(t, x.MustReadFull(got), "read err not expected")
+
+			if !bytes.Equal(got, want) {
+				test.Report(t, got, want, v.name)
+			}
+
+			// streaming the input in odd pieces gives the same output
+			x.Reset()
+
+			for i := 0; i < len(v.msg); i += 1000 {
+				_, err := x.Write(v.msg[i:minInt(i+1000, len(v.msg))])
+				test.CheckNoErr(t, err, "write err not expected")
+			}
+
+			half := len(want) / 2
+			test.CheckNoErr(t, x.MustReadFull(got[:half]), "read err not expected")
+			test.CheckNoErr(t, x.MustReadFull(got[half:]), "read err not expected")
+
+			if !bytes.Equal(got, want) {
+				test.Report(t, got, want, v.name+" streamed")
+			}
+		})
+	}
+
+	// the domain separation byte must be in [0x01, 0x7F]
+	err := test.CheckPanic(func() { xof.NewTurboSHAKE128(0x80) })
+	test.CheckNoErr(t, err, "panic expected")
+
+	err = test.CheckPanic(func() { xof.NewTurboSHAKE256(0x00) })
+	test.CheckNoErr(t, err, "panic expected")
+}
+
+func BenchmarkKangarooTwelve(b *testing.B) {
+	msg := make([]byte, 1<<20)
+	out := make([]byte, 32)
+
+	for _, id := range []xof.Extendable{xof.SHAKE256, xof.TurboSHAKE128, xof.TurboSHAKE256, xof.KT128, xof.KT256} {
+		b.Run(id.New().String(), func(b *testing.B) {
+			b.SetBytes(int64(len(msg)))
+
+			for i := 0; i < b.N; i++ {
+				x := id.New()
+				_, _ = x.Write(msg)
+				_, _ = x.Read(out)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xof
+
+import "math/bits"
+
+// roundConstants are the round constants of Keccak-f[1600]. Keccak-p[1600, n] uses the last n of them.
+var roundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808A, 0x8000000080008000,
+	0x000000000000808B, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008A, 0x0000000000000088, 0x0000000080008009, 0x000000008000000A,
+	0x000000008000808B, 0x800000000000008B, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800A, 0x800000008000000A,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+// keccakP1600 applies the Keccak-p[1600, n] permutation to the state, with n the number of round constants.
+// TurboSHAKE and KangarooTwelve use n = 12, Keccak-f[1600] is n = 24.
+func keccakP1600(a *[25]uint64, rc []uint64) {
+	var c0, c1, c2, c3, c4, d0, d1, d2, d3, d4 uint64
+
+	a0, a1, a2, a3, a4 := a[0], a[1], a[2], a[3], a[4]
+	a5, a6, a7, a8, a9 := a[5], a[6], a[7], a[8], a[9]
+	a10, a11, a12, a13, a14 := a[10], a[11], a[12], a[13], a[14]
+	a15, a16, a17, a18, a19 := a[15], a[16], a[17], a[18], a[19]
+	a20, a21, a22, a23, a24 := a[20], a[21], a[22], a[23], a[24]
+
+	for _, k := range rc {
+		// θ
+		c0 = a0 ^ a5 ^ a10 ^ a15 ^ a20
+		c1 = a1 ^ a6 ^ a11 ^ a16 ^ a21
+		c2 = a2 ^ a7 ^ a12 ^ a17 ^ a22
+		c3 = a3 ^ a8 ^ a13 ^ a18 ^ a23
+		c4 = a4 ^ a9 ^ a14 ^ a19 ^ a24
+		d0 = c4 ^ bits.RotateLeft64(c1, 1)
+		d1 = c0 ^ bits.RotateLeft64(c2, 1)
+		d2 = c1 ^ bits.RotateLeft64(c3, 1)
+		d3 = c2 ^ bits.RotateLeft64(c4, 1)
+		d4 = c3 ^ bits.RotateLeft64(c0, 1)
+
+		// ρ and π
+		b00 := a0 ^ d0
+		b01 := bits.RotateLeft64(a6^d1, 44)
+		b02 := bits.RotateLeft64(a12^d2, 43)
+		b03 := bits.RotateLeft64(a18^d3, 21)
+		b04 := bits.RotateLeft64(a24^d4, 14)
+		b10 := bits.RotateLeft64(a3^d3, 28)
+		b11 := bits.RotateLeft64(a9^d4, 20)
+		b12 := bits.RotateLeft64(a10^d0, 3)
+		b13 := bits.RotateLeft64(a16^d1, 45)
+		b14 := bits.RotateLeft64(a22^d2, 61)
+		b20 := bits.RotateLeft64(a1^d1, 1)
+		b21 := bits.RotateLeft64(a7^d2, 6)
+		b22 := bits.RotateLeft64(a13^d3, 25)
+		b23 := bits.RotateLeft64(a19^d4, 8)
+		b24 := bits.RotateLeft64(a20^d0, 18)
+		b30 := bits.RotateLeft64(a4^d4, 27)
+		b31 := bits.RotateLeft64(a5^d0, 36)
+		b32 := bits.RotateLeft64(a11^d1, 10)
+		b33 := bits.RotateLeft64(a17^d2, 15)
+		b34 := bits.RotateLeft64(a23^d3, 56)
+		b40 := bits.RotateLeft64(a2^d2, 62)
+		b41 := bits.RotateLeft64(a8^d3, 55)
+		b42 := bits.RotateLeft64(a14^d4, 39)
+		b43 := bits.RotateLeft64(a15^d0, 41)
+		b44 := bits.RotateLeft64(a21^d1, 2)
+
+		// χ and ι
+		a0 = b00 ^ (^b01 & b02)
+		a1 = b01 ^ (^b02 & b03)
+		a2 = b02 ^ (^b03 & b04)
+		a3 = b03 ^ (^b04 & b00)
+		a4 = b04 ^ (^b00 & b01)
+		a5 = b10 ^ (^b11 & b12)
+		a6 = b11 ^ (^b12 & b13)
+		a7 = b12 ^ (^b13 & b14)
+		a8 = b13 ^ (^b14 & b10)
+		a9 = b14 ^ (^b10 & b11)
+		a10 = b20 ^ (^b21 & b22)
+		a11 = b21 ^ (^b22 & b23)
+		a12 = b22 ^ (^b23 & b24)
+		a13 = b23 ^ (^b24 & b20)
+		a14 = b24 ^ (^b20 & b21)
+		a15 = b30 ^ (^b31 & b32)
+		a16 = b31 ^ (^b32 & b33)
+		a17 = b32 ^ (^b33 & b34)
+		a18 = b33 ^ (^b34 & b30)
+		a19 = b34 ^ (^b30 & b31)
+		a20 = b40 ^ (^b41 & b42)
+		a21 = b41 ^ (^b42 & b43)
+		a22 = b42 ^ (^b43 & b44)
+		a23 = b43 ^ (^b44 & b40)
+		a24 = b44 ^ (^b40 & b41)
+		a0 ^= k
+	}
+
+	a[0], a[1], a[2], a[3], a[4] = a0, a1, a2, a3, a4
+	a[5], a[6], a[7], a[8], a[9] = a5, a6, a7, a8, a9
+	a[10], a[11], a[12], a[13], a[14] = a10, a11, a12, a13, a14
+	a[15], a[16], a[17], a[18], a[19] = a15, a16, a17, a18, a19
+	a[20], a[21], a[22], a[23], a[24] = a20, a21, a22, a23, a24
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xof
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// firstRegistered is the identifier of the first registered XOF. Identifiers below it are reserved for the XOFs of
+// this package.
+const firstRegistered Extendable = 0x80
+
+var (
+	// registryMu serializes the registrations, and registry is the copy-on-write list of the registered constructors,
+	// so that hashing reads it without locking.
+	registryMu sync.Mutex
+	registry   atomic.Pointer[[]func() XOF]
+
+	errNilConstructor    = errors.New("xof: nil XOF constructor")
+	errRegistryExhausted = errors.New("xof: no identifier left to register")
+)
+
+// Register makes the XOF returned by newXOF available under a new Extendable identifier, and returns the identifier.
+// This makes parameterized XOFs, e.g. NewCSHAKE256 with a customization string or NewKMACXOF128 with a key, usable
+// wherever an Extendable is, e.g. in msgexpand.NewMessageExpandXOF.
+//
+// newXOF must return a new XOF in its initial state on every call.
+// Identifiers are assigned in registration order, so they are only stable across runs if registrations are too.
+func Register(newXOF func() XOF) (Extendable, error) {
+	if newXOF == nil {
+		return 0, errNilConstructor
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	constructors := registeredConstructors()
+	if uint64(len(constructors)) >= uint64(^Extendable(0)-firstRegistered) {
+		return 0, errRegistryExhausted
+	}
+
+	// the registered constructors are never modified, so that they can be read while registering
+	next := make([]func() XOF, len(constructors), len(constructors)+1)
+	copy(next, constructors)
+	next = append(next, newXOF)
+	registry.Store(&next)
+
+	return firstRegistered + Extendable(len(constructors)), nil
+}
+
+// registeredConstructors returns the registered constructors, in registration order.
+func registeredConstructors() []func() XOF {
+	if constructors := registry.Load(); constructors != nil {
+		return *constructors
+	}
+
+	return nil
+}
+
+// Available reports whether the given Extendable is implemented by this package, or registered with Register.
+func (id Extendable) Available() bool {
+	if id >= SHAKE128 && id < maxID {
+		return true
+	}
+
+	_, ok := id.registered()
+
+	return ok
+}
+
+// registered returns the registered constructor of the XOF, and whether there is one.
+func (id Extendable) registered() (func() XOF, bool) {
+	if id < firstRegistered {
+		return nil, false
+	}
+
+	if constructors, i := registeredConstructors(), id-firstRegistered; uint64(i) < uint64(len(constructors)) {
+		return constructors[i], true
+	}
+
+	return nil, false
+}
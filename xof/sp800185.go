@@ -0,0 +1,491 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xof
+
+import (
+	"io"
+	"runtime"
+	"sync"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Functions of NIST SP 800-185, built on cSHAKE.
+// See https://nvlpubs.nist.gov/nistpubs/SpecialPublications/NIST.SP.800-185.pdf
+
+const (
+	rate128 = 168 // cSHAKE128 rate in bytes
+	rate256 = 136 // cSHAKE256 rate in bytes
+
+	strCSHAKE128          = "cSHAKE128"
+	strCSHAKE256          = "cSHAKE256"
+	strKMAC128            = "KMAC128"
+	strKMAC256            = "KMAC256"
+	strKMACXOF128         = "KMACXOF128"
+	strKMACXOF256         = "KMACXOF256"
+	strTupleHash128       = "TupleHash128"
+	strTupleHash256       = "TupleHash256"
+	strTupleHashXOF128    = "TupleHashXOF128"
+	strTupleHashXOF256    = "TupleHashXOF256"
+	strParallelHash128    = "ParallelHash128"
+	strParallelHash256    = "ParallelHash256"
+	strParallelHashXOF128 = "ParallelHashXOF128"
+	strParallelHashXOF256 = "ParallelHashXOF256"
+)
+
+// NewCSHAKE128 returns a new cSHAKE128 XOF with the function name N and the customization string S.
+// With both empty, it is SHAKE128.
+func NewCSHAKE128(functionName, customization []byte) XOF {
+	return shake{sha3.NewCShake128(functionName, customization), strCSHAKE128}
+}
+
+// NewCSHAKE256 returns a new cSHAKE256 XOF with the function name N and the customization string S.
+// With both empty, it is SHAKE256.
+func NewCSHAKE256(functionName, customization []byte) XOF {
+	return shake{sha3.NewCShake256(functionName, customization), strCSHAKE256}
+}
+
+// NewKMAC128 returns a new KMAC128 instance with the key and the customization string, whose output is bound to its
+// length in bytes, which must be positive. Reading more than length bytes returns io.EOF.
+func NewKMAC128(key, customization []byte, length int) XOF {
+	checkLength(length)
+
+	return newKMAC(sha3.NewCShake128, rate128, key, customization, length, strKMAC128)
+}
+
+// NewKMAC256 returns a new KMAC256 instance with the key and the customization string, whose output is bound to its
+// length in bytes, which must be positive. Reading more than length bytes returns io.EOF.
+func NewKMAC256(key, customization []byte, length int) XOF {
+	checkLength(length)
+
+	return newKMAC(sha3.NewCShake256, rate256, key, customization, length, strKMAC256)
+}
+
+// NewKMACXOF128 returns a new KMACXOF128 XOF with the key and the customization string, whose output does not depend
+// on the length read.
+func NewKMACXOF128(key, customization []byte) XOF {
+	return newKMAC(sha3.NewCShake128, rate128, key, customization, 0, strKMACXOF128)
+}
+
+// NewKMACXOF256 returns a new KMACXOF256 XOF with the key and the customization string, whose output does not depend
+// on the length read.
+func NewKMACXOF256(key, customization []byte) XOF {
+	return newKMAC(sha3.NewCShake256, rate256, key, customization, 0, strKMACXOF256)
+}
+
+// KMAC128 returns the length bytes long KMAC128 tag of the message with the key and the customization string.
+func KMAC128(key, message, customization []byte, length int) []byte {
+	return sum(NewKMAC128(key, customization, length), length, message)
+}
+
+// KMAC256 returns the length bytes long KMAC256 tag of the message with the key and the customization string.
+func KMAC256(key, message, customization []byte, length int) []byte {
+	return sum(NewKMAC256(key, customization, length), length, message)
+}
+
+// NewTupleHash128 returns a new TupleHash128 instance with the customization string, whose output is bound to its
+// length in bytes, which must be positive. Every call to Write absorbs one element of the tuple, so that the output
+// depends on how the input is split, and MustWriteAll absorbs one element per input.
+func NewTupleHash128(customization []byte, length int) XOF {
+	checkLength(length)
+
+	return newTupleHash(sha3.NewCShake128, customization, length, strTupleHash128)
+}
+
+// NewTupleHash256 returns a new TupleHash256 instance with the customization string, whose output is bound to its
+// length in bytes, which must be positive. Every call to Write absorbs one element of the tuple, so that the output
+// depends on how the input is split, and MustWriteAll absorbs one element per input.
+func NewTupleHash256(customization []byte, length int) XOF {
+	checkLength(length)
+
+	return newTupleHash(sha3.NewCShake256, customization, length, strTupleHash256)
+}
+
+// NewTupleHashXOF128 returns a new TupleHashXOF128 XOF with the customization string. Every call to Write absorbs
+// one element of the tuple.
+func NewTupleHashXOF128(customization []byte) XOF {
+	return newTupleHash(sha3.NewCShake128, customization, 0, strTupleHashXOF128)
+}
+
+// NewTupleHashXOF256 returns a new TupleHashXOF256 XOF with the customization string. Every call to Write absorbs
+// one element of the tuple.
+func NewTupleHashXOF256(customization []byte) XOF {
+	return newTupleHash(sha3.NewCShake256, customization, 0, strTupleHashXOF256)
+}
+
+// TupleHash128 returns the length bytes long TupleHash128 of the tuple with the customization string.
+func TupleHash128(tuple [][]byte, customization []byte, length int) []byte {
+	return sum(NewTupleHash128(customization, length), length, tuple...)
+}
+
+// TupleHash256 returns the length bytes long TupleHash256 of the tuple with the customization string.
+func TupleHash256(tuple [][]byte, customization []byte, length int) []byte {
+	return sum(NewTupleHash256(customization, length), length, tuple...)
+}
+
+// NewParallelHash128 returns a new ParallelHash128 instance with the block size in bytes and the customization
+// string, whose output is bound to its length in bytes, which must be positive. Full blocks are hashed concurrently.
+func NewParallelHash128(blockSize int, customization []byte, length int) XOF {
+	checkLength(length)
+
+	return newParallelHash(sha3.NewCShake128, 32, blockSize, customization, length, strParallelHash128) //nolint:gomnd //256-bit chaining values
+}
+
+// NewParallelHash256 returns a new ParallelHash256 instance with the block size in bytes and the customization
+// string, whose output is bound to its length in bytes, which must be positive. Full blocks are hashed concurrently.
+func NewParallelHash256(blockSize int, customization []byte, length int) XOF {
+	checkLength(length)
+
+	return newParallelHash(sha3.NewCShake256, 64, blockSize, customization, length, strParallelHash256) //nolint:gomnd //512-bit chaining values
+}
+
+// NewParallelHashXOF128 returns a new ParallelHashXOF128 XOF with the block size in bytes and the customization
+// string.
+func NewParallelHashXOF128(blockSize int, customization []byte) XOF {
+	return newParallelHash(sha3.NewCShake128, 32, blockSize, customization, 0, strParallelHashXOF128) //nolint:gomnd //256-bit chaining values
+}
+
+// NewParallelHashXOF256 returns a new ParallelHashXOF256 XOF with the block size in bytes and the customization
+// string.
+func NewParallelHashXOF256(blockSize int, customization []byte) XOF {
+	return newParallelHash(sha3.NewCShake256, 64, blockSize, customization, 0, strParallelHashXOF256) //nolint:gomnd //512-bit chaining values
+}
+
+// checkLength panics if the output length of a fixed-length function is not positive, since a length of 0 selects
+// the XOF variant.
+func checkLength(length int) {
+	if length <= 0 {
+		panic("xof: invalid output length")
+	}
+}
+
+func sum(x XOF, length int, inputs ...[]byte) []byte {
+	if err := x.MustWriteAll(inputs...); err != nil {
+		panic(err)
+	}
+
+	out := make([]byte, length)
+	if err := x.MustReadFull(out); err != nil {
+		panic(err)
+	}
+
+	return out
+}
+
+// leftEncode returns left_encode(x), the minimal big-endian encoding of x prefixed by its length.
+func leftEncode(x uint64) []byte {
+	b := rightEncode(x)
+	n := b[len(b)-1]
+
+	return append([]byte{n}, b[:n]...)
+}
+
+// rightEncode returns right_encode(x), the minimal big-endian encoding of x followed by its length.
+func rightEncode(x uint64) []byte {
+	var buf [9]byte
+
+	n := 1
+	for v := x >> 8; v > 0; v >>= 8 {
+		n++
+	}
+
+	for i := 0; i < n; i++ {
+		buf[n-1-i] = byte(x >> (8 * i))
+	}
+
+	buf[n] = byte(n)
+
+	return buf[:n+1]
+}
+
+// encodeString returns encode_string(s), the bit length of s left-encoded followed by s.
+func encodeString(s []byte) []byte {
+	return append(leftEncode(uint64(len(s))*8), s...) //nolint:gomnd //bit length
+}
+
+// bytepad returns bytepad(x, w), x prefixed by left_encode(w) and padded with zeros to a multiple of w bytes.
+func bytepad(x []byte, w int) []byte {
+	b := append(leftEncode(uint64(w)), x...)
+
+	if r := len(b) % w; r != 0 {
+		b = append(b, make([]byte, w-r)...)
+	}
+
+	return b
+}
+
+// encoder holds the output state shared by the functions of SP 800-185 that encode their output length in their
+// input: the length is absorbed on the first Read, and bounds the output, or is 0 for the XOF variants.
+type encoder struct {
+	h       sha3.ShakeHash
+	initial sha3.ShakeHash
+	length  int
+	read    int
+	reading bool
+	str     string
+}
+
+func newEncoder(h sha3.ShakeHash, length int, str string) encoder {
+	return encoder{h: h, initial: h.Clone(), length: length, str: str}
+}
+
+func (e *encoder) clone() encoder {
+	c := *e
+	c.h = e.h.Clone()
+
+	return c
+}
+
+func (e *encoder) reset() {
+	e.h = e.initial.Clone()
+	e.read = 0
+	e.reading = false
+}
+
+func (e *encoder) write(p []byte) {
+	if e.reading {
+		panic("xof: Write after Read")
+	}
+
+	_, _ = e.h.Write(p) //nolint:errcheck //sha3 writes never fail
+}
+
+// readOutput absorbs right_encode(L) before the first output, and returns io.EOF once length bytes have been read.
+func (e *encoder) readOutput(p []byte, finish func()) (int, error) {
+	if !e.reading {
+		finish()
+		e.write(rightEncode(uint64(e.length) * 8)) //nolint:gomnd //bit length
+		e.reading = true
+	}
+
+	if e.length > 0 {
+		left := e.length - e.read
+		if left == 0 {
+			return 0, io.EOF
+		}
+
+		if len(p) > left {
+			p = p[:left]
+		}
+	}
+
+	n, err := e.h.Read(p)
+	e.read += n
+
+	return n, err
+}
+
+type kmac struct {
+	encoder
+}
+
+func newKMAC(newCShake func(N, S []byte) sha3.ShakeHash, rate int, key, customization []byte, length int,
+	str string,
+) *kmac {
+	h := newCShake([]byte("KMAC"), customization)
+	_, _ = h.Write(bytepad(encodeString(key), rate)) //nolint:errcheck //sha3 writes never fail
+
+	return &kmac{newEncoder(h, length, str)}
+}
+
+func (k *kmac) Write(p []byte) (int, error) {
+	k.write(p)
+	return len(p), nil
+}
+
+func (k *kmac) Read(p []byte) (int, error) {
+	return k.readOutput(p, func() {})
+}
+
+func (k *kmac) Clone() XOF { return &kmac{k.clone()} }
+
+func (k *kmac) Reset() { k.reset() }
+
+func (k *kmac) MustWriteAll(inputs ...[]byte) error {
+	return mustWriteAll(k, inputs...)
+}
+
+func (k *kmac) MustReadFull(buf []byte) error {
+	return mustReadFull(k, buf)
+}
+
+func (k *kmac) String() string {
+	return k.str
+}
+
+type tupleHash struct {
+	encoder
+}
+
+func newTupleHash(newCShake func(N, S []byte) sha3.ShakeHash, customization []byte, length int, str string) *tupleHash {
+	return &tupleHash{newEncoder(newCShake([]byte("TupleHash"), customization), length, str)}
+}
+
+// Write absorbs p as one element of the tuple.
+func (t *tupleHash) Write(p []byte) (int, error) {
+	t.write(encodeString(p))
+	return len(p), nil
+}
+
+func (t *tupleHash) Read(p []byte) (int, error) {
+	return t.readOutput(p, func() {})
+}
+
+func (t *tupleHash) Clone() XOF { return &tupleHash{t.clone()} }
+
+func (t *tupleHash) Reset() { t.reset() }
+
+func (t *tupleHash) MustWriteAll(inputs ...[]byte) error {
+	return mustWriteAll(t, inputs...)
+}
+
+func (t *tupleHash) MustReadFull(buf []byte) error {
+	return mustReadFull(t, buf)
+}
+
+func (t *tupleHash) String() string {
+	return t.str
+}
+
+type parallelHash struct {
+	encoder
+	newCShake func(N, S []byte) sha3.ShakeHash
+	chainSize int
+	blockSize int
+	blocks    uint64
+	buf       []byte
+}
+
+func newParallelHash(newCShake func(N, S []byte) sha3.ShakeHash, chainSize, blockSize int, customization []byte,
+	length int, str string,
+) *parallelHash {
+	if blockSize <= 0 {
+		panic("xof: invalid ParallelHash block size")
+	}
+
+	h := newCShake([]byte("ParallelHash"), customization)
+	_, _ = h.Write(leftEncode(uint64(blockSize))) //nolint:errcheck //sha3 writes never fail
+
+	return &parallelHash{
+		encoder:   newEncoder(h, length, str),
+		newCShake: newCShake,
+		chainSize: chainSize,
+		blockSize: blockSize,
+	}
+}
+
+// hashBlocks absorbs the chaining values of the full blocks in data, computed concurrently.
+func (ph *parallelHash) hashBlocks(data []byte) {
+	n := len(data) / ph.blockSize
+	chains := make([]byte, n*ph.chainSize)
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func(w int) {
+			defer wg.Done()
+
+			for i := w; i < n; i += workers {
+				h := ph.newCShake(nil, nil)
+				_, _ = h.Write(data[i*ph.blockSize : (i+1)*ph.blockSize])  //nolint:errcheck //sha3 writes never fail
+				_, _ = h.Read(chains[i*ph.chainSize : (i+1)*ph.chainSize]) //nolint:errcheck //sha3 reads never fail
+			}
+		}(w)
+	}
+
+	wg.Wait()
+
+	ph.write(chains)
+	ph.blocks += uint64(n)
+}
+
+func (ph *parallelHash) Write(p []byte) (int, error) {
+	if ph.reading {
+		panic("xof: Write after Read")
+	}
+
+	written := len(p)
+
+	// complete a buffered block first
+	if len(ph.buf) > 0 {
+		k := ph.blockSize - len(ph.buf)
+		if k > len(p) {
+			k = len(p)
+		}
+
+		ph.buf = append(ph.buf, p[:k]...)
+		p = p[k:]
+
+		if len(ph.buf) < ph.blockSize {
+			return written, nil
+		}
+
+		ph.hashBlocks(ph.buf)
+		ph.buf = ph.buf[:0]
+	}
+
+	full := len(p) - len(p)%ph.blockSize
+	if full > 0 {
+		ph.hashBlocks(p[:full])
+	}
+
+	ph.buf = append(ph.buf, p[full:]...)
+
+	return written, nil
+}
+
+func (ph *parallelHash) finish() {
+	if len(ph.buf) > 0 {
+		// the last block may be partial
+		h := ph.newCShake(nil, nil)
+		_, _ = h.Write(ph.buf) //nolint:errcheck //sha3 writes never fail
+
+		chain := make([]byte, ph.chainSize)
+		_, _ = h.Read(chain) //nolint:errcheck //sha3 reads never fail
+
+		ph.write(chain)
+		ph.blocks++
+		ph.buf = ph.buf[:0]
+	}
+
+	ph.write(rightEncode(ph.blocks))
+}
+
+func (ph *parallelHash) Read(p []byte) (int, error) {
+	return ph.readOutput(p, ph.finish)
+}
+
+func (ph *parallelHash) Clone() XOF {
+	c := *ph
+	c.encoder = ph.clone()
+	c.buf = append([]byte(nil), ph.buf...)
+
+	return &c
+}
+
+func (ph *parallelHash) Reset() {
+	ph.reset()
+	ph.blocks = 0
+	ph.buf = ph.buf[:0]
+}
+
+func (ph *parallelHash) MustWriteAll(inputs ...[]byte) error {
+	return mustWriteAll(ph, inputs...)
+}
+
+func (ph *parallelHash) MustReadFull(buf []byte) error {
+	return mustReadFull(ph, buf)
+}
+
+func (ph *parallelHash) String() string {
+	return ph.str
+}
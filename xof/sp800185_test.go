@@ -0,0 +1,301 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xof_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/cymony/cryptomony/internal/test"
+	"github.com/cymony/cryptomony/msgexpand"
+	"github.com/cymony/cryptomony/xof"
+)
+
+// Samples of https://csrc.nist.gov/projects/cryptographic-standards-and-guidelines/example-values
+func TestSP800185(t *testing.T) {
+	data := hexDecode(t, "00010203")
+	key := hexDecode(t, "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f")
+	tuple := [][]byte{hexDecode(t, "000102"), hexDecode(t, "101112131415")}
+	parallel := hexDecode(t, "000102030405060710111213141516172021222324252627")
+
+	for _, v := range []struct {
+		name   string
+		x      xof.XOF
+		inputs [][]byte
+		out    string
+	}{
+		{
+			name:   "cSHAKE128",
+			x:      xof.NewCSHAKE128(nil, []byte("Email Signature")),
+			inputs: [][]byte{data},
+			out:    "c1c36925b6409a04f1b504fcbca9d82b4017277cb5ed2b2065fc1d3814d5aaf5",
+		},
+		{
+			name:   "cSHAKE256",
+			x:      xof.NewCSHAKE256(nil, []byte("Email Signature")),
+			inputs: [][]byte{data},
+			out: "d008828e2b80ac9d2218ffee1d070c48b8e4c87bff32c9699d5b6896eee0edd1" +
+				"64020e2be0560858d9c00c037e34a96937c561a74c412bb4c746469527281c8c",
+		},
+		{
+			name:   "KMAC128",
+			x:      xof.NewKMAC128(key, nil, 32),
+			inputs: [][]byte{data},
+			out:    "e5780b0d3ea6f7d3a429c5706aa43a00fadbd7d49628839e3187243f456ee14e",
+		},
+		{
+			name:   "KMAC128 with customization",
+			x:      xof.NewKMAC128(key, []byte("My Tagged Application"), 32),
+			inputs: [][]byte{data},
+			out:    "3b1fba963cd8b0b59e8c1a6d71888b7143651af8ba0a7070c0979e2811324aa5",
+		},
+		{
+			name:   "KMAC256",
+			x:      xof.NewKMAC256(key, []byte("My Tagged Application"), 64),
+			inputs: [][]byte{data},
+			out: "20c570c31346f703c9ac36c61c03cb64c3970d0cfc787e9b79599d273a68d2f7" +
+				"f69d4cc3de9d104a351689f27cf6f5951f0103f33f4f24871024d9c27773a8dd",
+		},
+		{
+			name:   "KMACXOF128",
+			x:      xof.NewKMACXOF128(key, nil),
+			inputs: [][]byte{data},
+			out:    "cd83740bbd92ccc8cf032b1481a0f4460e7ca9dd12b08a0c4031178bacd6ec35",
+		},
+		{
+			name:   "TupleHash128",
+			x:      xof.NewTupleHash128(nil, 32),
+			inputs: tuple,
+			out:    "c5d8786c1afb9b82111ab34b65b2c0048fa64e6d48e263264ce1707d3ffc8ed1",
+		},
+		{
+			name:   "TupleHash128 with customization",
+			x:      xof.NewTupleHash128([]byte("My Tuple App"), 32),
+			inputs: tuple,
+			out:    "75cdb20ff4db1154e841d758e24160c54bae86eb8c13e7f5f40eb35588e96dfb",
+		},
+		{
+			name:   "TupleHash256",
+			x:      xof.NewTupleHash256(nil, 64),
+			inputs: tuple,
+			out: "cfb7058caca5e668f81a12a20a2195ce97a925f1dba3e7449a56f82201ec6073" +
+				"11ac2696b1ab5ea2352df1423bde7bd4bb78c9aed1a853c78672f9eb23bbe194",
+		},
+		{
+			name:   "ParallelHash128",
+			x:      xof.NewParallelHash128(8, nil, 32),
+			inputs: [][]byte{parallel},
+			out:    "ba8dc1d1d979331d3f813603c67f72609ab5e44b94a0b8f9af46514454a2b4f5",
+		},
+		{
+			name:   "ParallelHash128 with split input",
+			x:      xof.NewParallelHash128(8, nil, 32),
+			inputs: [][]byte{parallel[:3], parallel[3:13], parallel[13:]},
+			out:    "ba8dc1d1d979331d3f813603c67f72609ab5e44b94a0b8f9af46514454a2b4f5",
+		},
+		{
+			name:   "ParallelHash256",
+			x:      xof.NewParallelHash256(8, nil, 64),
+			inputs: [][]byte{parallel},
+			out: "bc1ef124da34495e948ead207dd9842235da432d2bbc54b4c110e64c45110553" +
+				"1b7f2a3e0ce055c02805e7c2de1fb746af97a1dd01f43b824e31b87612410429",
+		},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			want := hexDecode(t, v.out)
+			test.CheckNoErr(t, v.x.MustWriteAll(v.inputs...), "write err not expected")
+
+			clone := v.x.Clone()
+
+			for _, x := range []xof.XOF{v.x, clone} {
+				got := make([]byte, len(want))
+				test.CheckNoErr(t, x.MustReadFull(got), "read err not expected")
+
+				if !bytes.Equal(got, want) {
+					test.Report(t, got, want, v.name)
+				}
+			}
+
+			// Reset restores the customized initial state
+			v.x.Reset()
+			test.CheckNoErr(t, v.x.MustWriteAll(v.inputs...), "write err not expected")
+
+			got := make([]byte, len(want))
+			test.CheckNoErr(t, v.x.MustReadFull(got), "read err not expected")
+
+			if !bytes.Equal(got, want) {
+				test.Report(t, got, want, v.name+" after Reset")
+			}
+		})
+	}
+
+	// the one-shot functions give the same results
+	want := hexDecode(t, "e5780b0d3ea6f7d3a429c5706aa43a00fadbd7d49628839e3187243f456ee14e")
+	if got := xof.KMAC128(key, data, nil, 32); !bytes.Equal(got, want) {
+		test.Report(t, got, want, "KMAC128")
+	}
+
+	want = hexDecode(t, "c5d8786c1afb9b82111ab34b65b2c0048fa64e6d48e263264ce1707d3ffc8ed1")
+	if got := xof.TupleHash128(tuple, nil, 32); !bytes.Equal(got, want) {
+		test.Report(t, got, want, "TupleHash128")
+	}
+
+	// bounded outputs end after their length, and their tags depend on it
+	k := xof.NewKMAC256(key, nil, 16)
+	test.CheckNoErr(t, k.MustWriteAll(data), "write err not expected")
+
+	tag, err := io.ReadAll(k)
+	test.CheckNoErr(t, err, "read err not expected")
+	test.CheckOk(t, len(tag) == 16, "KMAC256 should output its length")
+	test.CheckOk(t, !bytes.Equal(tag, xof.KMAC256(key, data, nil, 32)[:16]), "KMAC256 tags should depend on their length")
+
+	// the tuple elements are delimited
+	test.CheckOk(t, !bytes.Equal(xof.TupleHash256([][]byte{[]byte("ab"), []byte("c")}, nil, 32),
+		xof.TupleHash256([][]byte{[]byte("a"), []byte("bc")}, nil, 32)), "TupleHash256 should delimit its elements")
+
+	err = test.CheckPanic(func() { xof.NewParallelHash128(0, nil, 32) })
+	test.CheckNoErr(t, err, "panic expected")
+
+	// a bounded output needs a positive length, a length of 0 is not the XOF variant
+	for _, f := range []func(){
+		func() { xof.NewKMAC128(key, nil, 0) },
+		func() { xof.NewKMAC256(key, nil, -1) },
+		func() { xof.NewTupleHash128(nil, 0) },
+		func() { xof.NewTupleHash256(nil, 0) },
+		func() { xof.NewParallelHash128(8, nil, 0) },
+		func() { xof.NewParallelHash256(8, nil, 0) },
+	} {
+		err = test.CheckPanic(f)
+		test.CheckNoErr(t, err, "panic expected")
+	}
+}
+
+func TestNames(t *testing.T) {
+	for _, v := range []struct {
+		x    xof.XOF
+		name string
+	}{
+		{xof.NewKMAC128(nil, nil, 32), "KMAC128"},
+		{xof.NewKMACXOF128(nil, nil), "KMACXOF128"},
+		{xof.NewTupleHash128(nil, 32), "TupleHash128"},
+		{xof.NewTupleHash256(nil, 64), "TupleHash256"},
+		{xof.NewTupleHashXOF128(nil), "TupleHashXOF128"},
+		{xof.NewTupleHashXOF256(nil), "TupleHashXOF256"},
+		{xof.NewParallelHash128(8, nil, 32), "ParallelHash128"},
+		{xof.NewParallelHash256(8, nil, 64), "ParallelHash256"},
+		{xof.NewParallelHashXOF128(8, nil), "ParallelHashXOF128"},
+		{xof.NewParallelHashXOF256(8, nil), "ParallelHashXOF256"},
+	} {
+		test.CheckOk(t, v.x.String() == v.name, "wrong name "+v.x.String()+" for "+v.name)
+	}
+}
+
+func TestParallelHashBlocks(t *testing.T) {
+	in := make([]byte, 10000)
+	for i := range in {
+		in[i] = byte(i)
+	}
+
+	// concurrent hashing of many blocks agrees with hashing them one by one
+	whole := xof.NewParallelHashXOF256(64, []byte("blocks"))
+	test.CheckNoErr(t, whole.MustWriteAll(in), "write err not expected")
+
+	split := xof.NewParallelHashXOF256(64, []byte("blocks"))
+	for i := 0; i < len(in); i += 7 {
+		_, err := split.Write(in[i:minInt(i+7, len(in))])
+		test.CheckNoErr(t, err, "write err not expected")
+	}
+
+	got, want := make([]byte, 100), make([]byte, 100)
+	test.CheckNoErr(t, whole.MustReadFull(want), "read err not expected")
+	test.CheckNoErr(t, split.MustReadFull(got), "read err not expected")
+
+	if !bytes.Equal(got, want) {
+		test.Report(t, got, want)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	key := []byte("registered KMACXOF256 key")
+
+	id, err := xof.Register(func() xof.XOF { return xof.NewKMACXOF256(key, []byte("expander")) })
+	test.CheckNoErr(t, err, "registration failed")
+	test.CheckOk(t, id.Available(), "registered XOF should be available")
+	test.CheckOk(t, id.New().String() == "KMACXOF256", "wrong XOF")
+
+	_, err = xof.Register(nil)
+	test.CheckIsErr(t, err, "nil constructor should be rejected")
+
+	var nonID xof.Extendable
+	test.CheckOk(t, !nonID.Available(), "zero identifier should not be available")
+	test.CheckOk(t, xof.SHAKE256.Available(), "SHAKE256 should be available")
+
+	// the registered XOF can be used with the message expanders
+	exp := msgexpand.NewMessageExpandXOF(id, 128)
+	name := exp.(fmt.Stringer).String()
+	test.CheckOk(t, name == "XOF:KMACXOF256", "wrong expander identifier "+name)
+
+	dst := []byte("QUUX-V01-CS02-with-expander-KMACXOF256")
+	out, err := exp.Expand([]byte("abc"), dst, 64)
+	test.CheckNoErr(t, err, "expansion failed")
+
+	other, err := msgexpand.NewMessageExpandXOF(xof.SHAKE256, 128).Expand([]byte("abc"), dst, 64)
+	test.CheckNoErr(t, err, "expansion failed")
+	test.CheckOk(t, len(out) == 64 && !bytes.Equal(out, other), "keyed expansion should differ from SHAKE256")
+}
+
+func hexDecode(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	test.CheckNoErr(t, err, "decode string err")
+
+	return b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func TestRegisterConcurrent(t *testing.T) {
+	id, err := xof.Register(func() xof.XOF { return xof.NewCSHAKE128(nil, []byte("concurrent")) })
+	test.CheckNoErr(t, err, "registration failed")
+
+	want := make([]byte, 32)
+	test.CheckNoErr(t, xof.NewCSHAKE128(nil, []byte("concurrent")).MustReadFull(want), "read err not expected")
+
+	// registered XOFs are used while others are registered
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(2) //nolint:gomnd //two goroutines
+
+		go func(i int) {
+			defer wg.Done()
+
+			other, err := xof.Register(func() xof.XOF { return xof.NewCSHAKE128(nil, []byte{byte(i)}) })
+			test.CheckNoErr(t, err, "registration failed")
+			test.CheckOk(t, other.Available(), "registered XOF should be available")
+		}(i)
+
+		go func() {
+			defer wg.Done()
+
+			got := make([]byte, 32)
+			test.CheckNoErr(t, id.New().MustReadFull(got), "read err not expected")
+			test.CheckOk(t, bytes.Equal(got, want), "wrong registered XOF")
+		}()
+	}
+
+	wg.Wait()
+}
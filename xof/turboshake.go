@@ -0,0 +1,159 @@
+// Copyright (c) 2022 Cymony Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xof
+
+import (
+	"encoding/binary"
+)
+
+// TurboSHAKE and KangarooTwelve, the Keccak-p[1600, 12] based XOFs of RFC 9861.
+// See https://www.rfc-editor.org/rfc/rfc9861.html
+
+const (
+	turboRounds = 12
+
+	// defaultDomain is the domain separation byte of the TurboSHAKE128 and TurboSHAKE256 Extendable values.
+	defaultDomain = 0x1F
+
+	strTurboSHAKE128 = "TurboSHAKE128"
+	strTurboSHAKE256 = "TurboSHAKE256"
+)
+
+// NewTurboSHAKE128 returns a new TurboSHAKE128 XOF with the domain separation byte, which must be in [0x01, 0x7F].
+// The TurboSHAKE128 Extendable uses 0x1F.
+func NewTurboSHAKE128(domain byte) XOF {
+	return newTurboSHAKE(rate128, domain, strTurboSHAKE128)
+}
+
+// NewTurboSHAKE256 returns a new TurboSHAKE256 XOF with the domain separation byte, which must be in [0x01, 0x7F].
+// The TurboSHAKE256 Extendable uses 0x1F.
+func NewTurboSHAKE256(domain byte) XOF {
+	return newTurboSHAKE(rate256, domain, strTurboSHAKE256)
+}
+
+// turboSHAKE is the sponge of TurboSHAKE, over the Keccak-p[1600, 12] permutation.
+type turboSHAKE struct {
+	a         [25]uint64
+	buf       [rate128]byte // absorbed bytes of the current block, or squeezed block
+	n         int           // number of bytes absorbed in buf, or read from it
+	rate      int
+	domain    byte
+	squeezing bool
+	str       string
+}
+
+func newTurboSHAKE(rate int, domain byte, str string) *turboSHAKE {
+	if domain < 0x01 || domain > 0x7F {
+		panic("xof: invalid TurboSHAKE domain separation byte")
+	}
+
+	return &turboSHAKE{rate: rate, domain: domain, str: str}
+}
+
+func (t *turboSHAKE) permute() {
+	keccakP1600(&t.a, roundConstants[len(roundConstants)-turboRounds:])
+}
+
+// xorIn absorbs a full block.
+func (t *turboSHAKE) xorIn(block []byte) {
+	for i := 0; i < t.rate/8; i++ {
+		t.a[i] ^= binary.LittleEndian.Uint64(block[8*i:])
+	}
+
+	t.permute()
+}
+
+func (t *turboSHAKE) Write(p []byte) (int, error) {
+	if t.squeezing {
+		panic("xof: Write after Read")
+	}
+
+	written := len(p)
+
+	for len(p) > 0 {
+		if t.n == 0 && len(p) >= t.rate {
+			t.xorIn(p[:t.rate])
+			p = p[t.rate:]
+
+			continue
+		}
+
+		k := copy(t.buf[t.n:t.rate], p)
+		t.n += k
+		p = p[k:]
+
+		if t.n == t.rate {
+			t.xorIn(t.buf[:t.rate])
+			t.n = 0
+		}
+	}
+
+	return written, nil
+}
+
+// pad absorbs the padded domain separation byte, and switches to squeezing.
+func (t *turboSHAKE) pad() {
+	for i := t.n; i < t.rate; i++ {
+		t.buf[i] = 0
+	}
+
+	t.buf[t.n] ^= t.domain
+	t.buf[t.rate-1] ^= 0x80
+	t.xorIn(t.buf[:t.rate])
+
+	t.squeezing = true
+	t.fill()
+}
+
+// fill copies the rate part of the state to buf.
+func (t *turboSHAKE) fill() {
+	for i := 0; i < t.rate/8; i++ {
+		binary.LittleEndian.PutUint64(t.buf[8*i:], t.a[i])
+	}
+
+	t.n = 0
+}
+
+func (t *turboSHAKE) Read(p []byte) (int, error) {
+	if !t.squeezing {
+		t.pad()
+	}
+
+	read := len(p)
+
+	for len(p) > 0 {
+		if t.n == t.rate {
+			t.permute()
+			t.fill()
+		}
+
+		k := copy(p, t.buf[t.n:t.rate])
+		t.n += k
+		p = p[k:]
+	}
+
+	return read, nil
+}
+
+func (t *turboSHAKE) Clone() XOF {
+	c := *t
+	return &c
+}
+
+func (t *turboSHAKE) Reset() {
+	*t = turboSHAKE{rate: t.rate, domain: t.domain, str: t.str}
+}
+
+func (t *turboSHAKE) MustWriteAll(inputs ...[]byte) error {
+	return mustWriteAll(t, inputs...)
+}
+
+func (t *turboSHAKE) MustReadFull(buf []byte) error {
+	return mustReadFull(t, buf)
+}
+
+func (t *turboSHAKE) String() string {
+	return t.str
+}
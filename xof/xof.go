@@ -11,6 +11,8 @@ import (
 	"golang.org/x/crypto/blake2b"
 	"golang.org/x/crypto/blake2s"
 	"golang.org/x/crypto/sha3"
+
+	"github.com/cymony/cryptomony/internal/blake3"
 )
 
 // XOF defines the interface to hash functions that support arbitrary-length output
@@ -44,10 +46,16 @@ type XOF interface {
 type Extendable uint
 
 const (
-	SHAKE128 Extendable = 1 + iota //nolint:revive //no need doc
-	SHAKE256                       //nolint:revive //no need doc
-	BLAKE2XB                       //nolint:revive //no need doc
-	BLAKE2XS                       //nolint:revive //no need doc
+	SHAKE128      Extendable = 1 + iota //nolint:revive //no need doc
+	SHAKE256                            //nolint:revive //no need doc
+	BLAKE2XB                            //nolint:revive //no need doc
+	BLAKE2XS                            //nolint:revive //no need doc
+	TurboSHAKE128                       //nolint:revive //no need doc
+	TurboSHAKE256                       //nolint:revive //no need doc
+	KT128                               //nolint:revive //no need doc
+	KT256                               //nolint:revive //no need doc
+	BLAKE3                              //nolint:revive //no need doc
+	maxID
 )
 
 const (
@@ -80,7 +88,21 @@ func (id Extendable) New() XOF {
 		}
 
 		return blake2xs{b, strBLAKE2XS}
+	case TurboSHAKE128:
+		return NewTurboSHAKE128(defaultDomain)
+	case TurboSHAKE256:
+		return NewTurboSHAKE256(defaultDomain)
+	case KT128:
+		return NewKT128(nil)
+	case KT256:
+		return NewKT256(nil)
+	case BLAKE3:
+		return &blake3XOF{h: blake3.New(), str: strBLAKE3}
 	default:
+		if newXOF, ok := id.registered(); ok {
+			return newXOF()
+		}
+
 		panic("xof: XOF function unavalable")
 	}
 }